@@ -22,6 +22,13 @@
 //	app.AddRunners(myRunner)
 //	app.AddClosers(myCloser)
 //
+// Or add named components with dependencies:
+//
+//	app.AddComponents(
+//		app.Component{Name: "db", Closer: db},
+//		app.Component{Name: "http", DependsOn: []string{"db"}, Runner: srv, Closer: srv},
+//	)
+//
 // Start the application:
 //
 //	if err := app.Run(); err != nil {
@@ -35,6 +42,9 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/config"
@@ -45,18 +55,33 @@ import (
 // It coordinates the startup and graceful shutdown of multiple Runner components,
 // providing context propagation and resource cleanup through Closers.
 //
-// Application is not safe for concurrent modification. All AddRunners and AddClosers
-// calls should be made before calling Run.
+// Application is not safe for concurrent modification. All AddRunners, AddClosers
+// and AddComponents calls should be made before calling Run.
 type Application struct {
 	ctx    context.Context
 	cfg    *config.Config
 	logger *log.Adapter
 
-	cancel  context.CancelFunc
-	runners []Runner
-	closers []Closer
+	cancel          context.CancelFunc
+	runners         []Runner
+	closers         []Closer
+	components      []Component
+	startedLock     sync.Mutex
+	started         map[string]bool
+	startTimeout    time.Duration
+	stopTimeout     time.Duration
+	shutdownTimeout time.Duration
 }
 
+const (
+	// DefaultStartTimeout is the default time limit for a component to become ready.
+	DefaultStartTimeout = 30 * time.Second
+	// DefaultStopTimeout is the default time limit for a single closer.
+	DefaultStopTimeout = 15 * time.Second
+	// DefaultShutdownTimeout is the default total time budget for shutdown.
+	DefaultShutdownTimeout = 60 * time.Second
+)
+
 // New creates a new Application instance with the provided options.
 // It applies the options to a DefaultConfig and then creates
 // the application from the resulting configuration.
@@ -97,7 +122,11 @@ func NewFromConfig(appConfig Config) (*Application, error) {
 			_ = logger.Sync()
 			return nil
 		})},
-		cancel: cancel,
+		cancel:          cancel,
+		started:         make(map[string]bool),
+		startTimeout:    durationOrDefault(appConfig.StartTimeout, DefaultStartTimeout),
+		stopTimeout:     durationOrDefault(appConfig.StopTimeout, DefaultStopTimeout),
+		shutdownTimeout: durationOrDefault(appConfig.ShutdownTimeout, DefaultShutdownTimeout),
 	}, nil
 }
 
@@ -133,32 +162,56 @@ func (a *Application) AddClosers(closers ...Closer) {
 	a.closers = append(a.closers, closers...)
 }
 
-// Run starts all registered runners and blocks until one of them returns an error
-// or the application context is cancelled.
+// AddComponents appends the provided named components to the application.
+// Components are started in dependency order when Run is called and closed
+// in reverse order during shutdown, before plain closers.
 //
-// Each runner executes in its own goroutine. If any runner fails, Run returns
-// the first error encountered. If the context is cancelled via Shutdown,
-// Run returns nil.
+// AddComponents should be called before Run to ensure proper initialization.
+func (a *Application) AddComponents(components ...Component) {
+	a.components = append(a.components, components...)
+}
+
+// Run starts all registered components in dependency order, waiting for each one
+// to become ready, then starts all registered runners. Blocks until one of them
+// returns an error or the application context is cancelled.
+//
+// Each runner executes in its own goroutine. If any component fails to start or
+// any runner fails, Run returns the first error encountered. If the context is
+// cancelled via Shutdown, including while components are still starting, Run returns nil.
 //
 // Run is safe to call only once per Application instance.
 func (a *Application) Run() error {
-	errChan := make(chan error)
+	errChan := make(chan error, 1)
+
+	components, err := sortComponents(a.components)
+	if err != nil {
+		return errors.WithMessage(err, "sort components")
+	}
+	for _, component := range components {
+		err := a.startComponent(component, errChan)
+		if a.ctx.Err() != nil {
+			// shutdown during start is a normal stop
+			return nil
+		}
+		if err != nil {
+			return errors.WithMessagef(err, "start component %s", component.Name)
+		}
+	}
 
 	for i := range a.runners {
 		go func(index int, runner Runner) {
 			err := runner.Run(a.ctx)
 			if err != nil {
-				select {
-				case errChan <- errors.WithMessagef(err, "start runner[%d] -> %T", index, runner):
-				default:
-					a.logger.Error(a.ctx, errors.WithMessagef(err, "start runner[%d] -> %T", index, runner))
-				}
+				a.reportRunError(errChan, errors.WithMessagef(err, "start runner[%d] -> %T", index, runner))
 			}
 		}(i, a.runners[i])
 	}
 
 	select {
 	case err := <-errChan:
+		if a.ctx.Err() != nil {
+			return nil
+		}
 		return err
 	case <-a.ctx.Done():
 		return nil
@@ -174,14 +227,178 @@ func (a *Application) Shutdown() {
 	a.cancel()
 }
 
-// Close invokes all registered closers to release resources.
-// Errors from individual closers are logged but do not halt the shutdown process.
+// Close closes components in reverse dependency order and then invokes all
+// registered closers. Failed and hung closers are logged but do not halt the shutdown process.
 func (a *Application) Close() {
-	for i := range a.closers {
-		closer := a.closers[i]
-		err := closer.Close()
-		if err != nil {
-			a.logger.Error(a.ctx, errors.WithMessagef(err, "closers[%d] -> %T", i, closer))
+	report := a.CloseWithReport()
+	for _, component := range report.Failed() {
+		a.logger.Error(
+			a.ctx,
+			errors.WithMessagef(component.Err, "app: close %s", component.Name),
+			log.Bool("timedOut", component.TimedOut),
+			log.Bool("skipped", component.Skipped),
+			log.String("duration", component.Duration.String()),
+		)
+	}
+}
+
+// CloseWithReport behaves like Close but does not log failures and returns
+// a structured report instead.
+//
+// Only components started by Run are closed: components following a component which failed
+// to start or left after a shutdown during start are not closed. Components without
+// Runner and Ready hold resources created before Run, so they are always closed.
+//
+// Each closer is limited by its stop timeout and all closers together are limited
+// by the shutdown timeout. Closers left after the budget is exhausted are skipped.
+func (a *Application) CloseWithReport() ShutdownReport {
+	start := time.Now()
+	deadline := start.Add(a.shutdownTimeout)
+
+	components, err := sortComponents(a.components)
+	if err != nil {
+		components = a.components
+	}
+	reports := make([]ComponentReport, 0, len(components)+len(a.closers))
+	for i := len(components) - 1; i >= 0; i-- {
+		component := components[i]
+		if component.Closer == nil || !a.isStarted(component) {
+			continue
 		}
+		stopTimeout := durationOrDefault(component.StopTimeout, a.stopTimeout)
+		reports = append(reports, closeWithDeadline(component.Name, component.Closer, stopTimeout, deadline))
+	}
+	for i, closer := range a.closers {
+		name := fmt.Sprintf("closers[%d] -> %T", i, closer)
+		reports = append(reports, closeWithDeadline(name, closer, a.stopTimeout, deadline))
+	}
+
+	return ShutdownReport{
+		Duration:   time.Since(start),
+		Components: reports,
+	}
+}
+
+// startComponent starts the component runner and waits for the component readiness.
+// Runner errors are forwarded to errChan.
+// The component is closed on shutdown even if it fails to become ready, as its runner may be running.
+func (a *Application) startComponent(component Component, errChan chan error) error {
+	start := time.Now()
+	a.startedLock.Lock()
+	a.started[component.Name] = true
+	a.startedLock.Unlock()
+
+	runErr := make(chan error, 1)
+	if component.Runner != nil {
+		go func() {
+			err := component.Runner.Run(a.ctx)
+			if err != nil {
+				err = errors.WithMessagef(err, "run component %s", component.Name)
+			}
+			runErr <- err
+			if err != nil {
+				a.reportRunError(errChan, err)
+			}
+		}()
+	}
+
+	if component.Ready != nil {
+		startTimeout := durationOrDefault(component.StartTimeout, a.startTimeout)
+		ctx, cancel := context.WithTimeout(a.ctx, startTimeout)
+		defer cancel()
+
+		readyErr := make(chan error, 1)
+		go func() {
+			readyErr <- component.Ready(ctx)
+		}()
+
+		// Ready may ignore ctx, so the timeout is also awaited here
+		select {
+		case err := <-readyErr:
+			if err != nil {
+				return errors.WithMessage(err, "wait ready")
+			}
+		case err := <-runErr:
+			if err != nil {
+				return err
+			}
+			select {
+			case err := <-readyErr:
+				if err != nil {
+					return errors.WithMessage(err, "wait ready")
+				}
+			case <-ctx.Done():
+				return errors.WithMessagef(ctx.Err(), "wait ready: start timeout %s", startTimeout)
+			}
+		case <-ctx.Done():
+			return errors.WithMessagef(ctx.Err(), "wait ready: start timeout %s", startTimeout)
+		}
+	}
+
+	a.logger.Info(
+		a.ctx,
+		"app: component started",
+		log.String("component", component.Name),
+		log.String("duration", time.Since(start).String()),
+	)
+	return nil
+}
+
+// isStarted reports whether the component was started by Run or has neither Runner nor Ready.
+func (a *Application) isStarted(component Component) bool {
+	if component.Runner == nil && component.Ready == nil {
+		return true
+	}
+	a.startedLock.Lock()
+	defer a.startedLock.Unlock()
+	return a.started[component.Name]
+}
+
+// reportRunError passes the runner error to Run or logs it if Run is no longer waiting.
+func (a *Application) reportRunError(errChan chan error, err error) {
+	select {
+	case errChan <- err:
+	default:
+		a.logger.Error(a.ctx, err)
+	}
+}
+
+// closeWithDeadline invokes the closer limited by stopTimeout and the total deadline.
+func closeWithDeadline(name string, closer Closer, stopTimeout time.Duration, deadline time.Time) ComponentReport {
+	report := ComponentReport{
+		Name: name,
+	}
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		report.Skipped = true
+		report.Err = errors.New("shutdown timeout exceeded")
+		return report
+	}
+
+	timeout := min(stopTimeout, remaining)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- closer.Close()
+	}()
+	select {
+	case err := <-done:
+		report.Err = err
+	case <-timer.C:
+		report.TimedOut = true
+		report.Err = errors.Errorf("close timeout %s exceeded", timeout)
+	}
+	report.Duration = time.Since(start)
+	return report
+}
+
+// durationOrDefault returns value if it is positive, otherwise defaultValue.
+func durationOrDefault(value time.Duration, defaultValue time.Duration) time.Duration {
+	if value > 0 {
+		return value
 	}
+	return defaultValue
 }
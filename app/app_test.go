@@ -0,0 +1,269 @@
+package app_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/app"
+)
+
+type journal struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (j *journal) add(event string) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.events = append(j.events, event)
+}
+
+func (j *journal) list() []string {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return append([]string{}, j.events...)
+}
+
+func (j *journal) component(name string, dependsOn ...string) app.Component {
+	ready := make(chan struct{})
+	return app.Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Runner: app.RunnerFunc(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			j.add("start " + name)
+			close(ready)
+			<-ctx.Done()
+			return nil
+		}),
+		Ready: func(ctx context.Context) error {
+			select {
+			case <-ready:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		Closer: app.CloserFunc(func() error {
+			j.add("stop " + name)
+			return nil
+		}),
+	}
+}
+
+func TestComponentsOrder(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New()
+	require.NoError(err)
+	j := &journal{}
+	application.AddComponents(
+		j.component("http", "db", "kafka"),
+		j.component("kafka"),
+		j.component("db"),
+		j.component("consumer", "http"),
+	)
+
+	runErr := make(chan error)
+	go func() {
+		runErr <- application.Run()
+	}()
+	require.Eventually(func() bool {
+		return len(j.list()) == 4
+	}, time.Second, 5*time.Millisecond)
+
+	application.Shutdown()
+	require.NoError(<-runErr)
+
+	require.Equal([]string{
+		"start kafka", "start db", "start http", "start consumer",
+		"stop consumer", "stop http", "stop db", "stop kafka",
+	}, j.list())
+}
+
+func TestComponentsInvalidGraph(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New()
+	require.NoError(err)
+	application.AddComponents(
+		app.Component{Name: "a", DependsOn: []string{"b"}},
+		app.Component{Name: "b", DependsOn: []string{"a"}},
+	)
+	err = application.Run()
+	require.ErrorContains(err, "dependency cycle")
+
+	application, err = app.New()
+	require.NoError(err)
+	application.AddComponents(app.Component{Name: "a", DependsOn: []string{"unknown"}})
+	err = application.Run()
+	require.ErrorContains(err, "unknown dependency")
+}
+
+func TestComponentStartFailed(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New(app.WithStartTimeout(50 * time.Millisecond))
+	require.NoError(err)
+	application.AddComponents(
+		app.Component{
+			Name: "broken",
+			Runner: app.RunnerFunc(func(ctx context.Context) error {
+				return errors.New("listen failed")
+			}),
+			Ready: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+		app.Component{
+			Name: "hung",
+			Ready: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+	)
+	err = application.Run()
+	require.ErrorContains(err, "start component broken")
+	require.ErrorContains(err, "listen failed")
+}
+
+func TestShutdownDuringStart(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New()
+	require.NoError(err)
+	started := make(chan struct{})
+	application.AddComponents(app.Component{
+		Name: "slow",
+		Ready: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	runErr := make(chan error)
+	go func() {
+		runErr <- application.Run()
+	}()
+	<-started
+	application.Shutdown()
+
+	select {
+	case err := <-runErr:
+		require.NoError(err)
+	case <-time.After(time.Second):
+		require.Fail("run is not stopped")
+	}
+}
+
+func TestShutdownTimeouts(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New(
+		app.WithStopTimeout(50*time.Millisecond),
+		app.WithShutdownTimeout(80*time.Millisecond),
+	)
+	require.NoError(err)
+	hang := app.CloserFunc(func() error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	application.AddComponents(
+		app.Component{Name: "db", Closer: hang},
+		app.Component{Name: "cache", Closer: hang},
+		app.Component{Name: "http", DependsOn: []string{"db", "cache"}, Closer: app.CloserFunc(func() error {
+			return errors.New("close failed")
+		})},
+	)
+
+	report := application.CloseWithReport()
+	failed := report.Failed()
+	require.Len(failed, 4)
+	require.Equal("http", failed[0].Name)
+	require.EqualError(failed[0].Err, "close failed")
+	require.Equal("cache", failed[1].Name)
+	require.True(failed[1].TimedOut)
+	require.Equal("db", failed[2].Name)
+	require.True(failed[2].TimedOut)
+	require.Equal("closers[0] -> app.CloserFunc", failed[3].Name)
+	require.True(failed[3].Skipped)
+	require.Less(report.Duration, 200*time.Millisecond)
+}
+
+func TestReadyIgnoringContext(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	block := make(chan struct{})
+	defer close(block)
+	ready := func(ctx context.Context) error {
+		<-block
+		return nil
+	}
+	components := []app.Component{
+		{Name: "ignoring", Ready: ready},
+		{Name: "stopped", Ready: ready, Runner: app.RunnerFunc(func(ctx context.Context) error {
+			return nil
+		})},
+	}
+	for _, component := range components {
+		application, err := app.New(app.WithStartTimeout(50 * time.Millisecond))
+		require.NoError(err)
+		application.AddComponents(component)
+
+		start := time.Now()
+		err = application.Run()
+		require.ErrorIs(err, context.DeadlineExceeded)
+		require.ErrorContains(err, "start component "+component.Name)
+		require.ErrorContains(err, "start timeout 50ms")
+		require.Less(time.Since(start), time.Second)
+	}
+}
+
+func TestCloseStartedComponents(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	application, err := app.New()
+	require.NoError(err)
+	j := &journal{}
+	application.AddComponents(
+		app.Component{
+			Name: "broken",
+			Ready: func(ctx context.Context) error {
+				return errors.New("connect failed")
+			},
+			Closer: app.CloserFunc(func() error {
+				j.add("stop broken")
+				return nil
+			}),
+		},
+		j.component("http", "broken"),
+		app.Component{
+			Name: "db",
+			Closer: app.CloserFunc(func() error {
+				j.add("stop db")
+				return nil
+			}),
+		},
+	)
+
+	err = application.Run()
+	require.ErrorContains(err, "connect failed")
+
+	report := application.CloseWithReport()
+	require.Empty(report.Failed())
+	require.Equal([]string{"stop db", "stop broken"}, j.list())
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ReadyFunc blocks until a component is ready to serve or ctx is done.
+// Returns an error if the component cannot become ready.
+type ReadyFunc func(ctx context.Context) error
+
+// Component is a named part of the application with explicit dependencies.
+//
+// Components are started in topological order: a component is started only after
+// every component listed in DependsOn has become ready. On shutdown components are
+// closed in reverse order, so a component is closed before its dependencies.
+type Component struct {
+	// Name uniquely identifies the component. Required.
+	Name string
+	// DependsOn lists names of components that must be ready before this one starts.
+	DependsOn []string
+	// Runner is started in its own goroutine. Optional.
+	Runner Runner
+	// Ready reports when the component is ready. Optional.
+	// If nil, the component is ready as soon as its Runner is started.
+	Ready ReadyFunc
+	// Closer releases component resources on shutdown. Optional.
+	Closer Closer
+	// StartTimeout limits waiting for readiness. Zero means Config.StartTimeout.
+	StartTimeout time.Duration
+	// StopTimeout limits Closer execution. Zero means Config.StopTimeout.
+	StopTimeout time.Duration
+}
+
+// ComponentReport describes the outcome of a single component lifecycle phase.
+type ComponentReport struct {
+	Name     string
+	Duration time.Duration
+	// TimedOut is true if the phase did not finish within the deadline.
+	TimedOut bool
+	// Skipped is true if the phase was not executed because the total budget was exhausted.
+	Skipped bool
+	Err     error
+}
+
+// ShutdownReport is the structured outcome of Application.CloseWithReport.
+type ShutdownReport struct {
+	Duration   time.Duration
+	Components []ComponentReport
+}
+
+// Failed returns reports of components that failed, hung or were skipped.
+func (r ShutdownReport) Failed() []ComponentReport {
+	failed := make([]ComponentReport, 0)
+	for _, component := range r.Components {
+		if component.Err != nil || component.TimedOut || component.Skipped {
+			failed = append(failed, component)
+		}
+	}
+	return failed
+}
+
+// sortComponents orders components so that every component follows its dependencies.
+// Components without mutual dependencies keep their registration order.
+// Returns an error on empty or duplicate names, unknown dependencies and cycles.
+func sortComponents(components []Component) ([]Component, error) {
+	indexByName := make(map[string]int, len(components))
+	for i, component := range components {
+		if component.Name == "" {
+			return nil, errors.Errorf("component[%d]: empty name", i)
+		}
+		_, exists := indexByName[component.Name]
+		if exists {
+			return nil, errors.Errorf("component %s: duplicate name", component.Name)
+		}
+		indexByName[component.Name] = i
+	}
+
+	inDegree := make([]int, len(components))
+	dependents := make([][]int, len(components))
+	for i, component := range components {
+		for _, dependency := range component.DependsOn {
+			j, ok := indexByName[dependency]
+			if !ok {
+				return nil, errors.Errorf("component %s: unknown dependency %s", component.Name, dependency)
+			}
+			inDegree[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	sorted := make([]Component, 0, len(components))
+	visited := make([]bool, len(components))
+	for len(sorted) < len(components) {
+		next := -1
+		for i := range components {
+			if !visited[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			cycled := make([]string, 0)
+			for i, component := range components {
+				if !visited[i] {
+					cycled = append(cycled, component.Name)
+				}
+			}
+			return nil, errors.Errorf("dependency cycle detected between components %v", cycled)
+		}
+		visited[next] = true
+		sorted = append(sorted, components[next])
+		for _, dependent := range dependents[next] {
+			inDegree[dependent]--
+		}
+	}
+	return sorted, nil
+}
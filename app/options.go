@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"github.com/txix-open/isp-kit/config"
 	"github.com/txix-open/isp-kit/log"
 )
@@ -19,6 +21,15 @@ type Config struct {
 	LoggerConfigSupplier LoggerConfigSupplier
 	// ConfigOptions are passed to the underlying configuration system.
 	ConfigOptions []config.Option
+	// StartTimeout is the default limit for a component to become ready.
+	// Zero means DefaultStartTimeout.
+	StartTimeout time.Duration
+	// StopTimeout is the default limit for a single closer.
+	// Zero means DefaultStopTimeout.
+	StopTimeout time.Duration
+	// ShutdownTimeout is the total budget for closing all components and closers.
+	// Zero means DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
 }
 
 // DefaultConfig returns a new Config with sensible defaults.
@@ -28,6 +39,9 @@ func DefaultConfig() *Config {
 		LoggerConfigSupplier: func(cfg *config.Config) log.Config {
 			return *log.DefaultConfig()
 		},
+		StartTimeout:    DefaultStartTimeout,
+		StopTimeout:     DefaultStopTimeout,
+		ShutdownTimeout: DefaultShutdownTimeout,
 	}
 }
 
@@ -65,3 +79,27 @@ func WithLoggerConfigSupplier(supplier LoggerConfigSupplier) Option {
 		c.LoggerConfigSupplier = supplier
 	}
 }
+
+// WithStartTimeout creates an Option that sets the default time limit
+// for a component to become ready.
+func WithStartTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.StartTimeout = timeout
+	}
+}
+
+// WithStopTimeout creates an Option that sets the default time limit
+// for a single component or closer to close.
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.StopTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout creates an Option that sets the total time budget
+// for closing all components and closers.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.ShutdownTimeout = timeout
+	}
+}
@@ -2,6 +2,7 @@ package bgjobx
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -10,6 +11,7 @@ import (
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/bgjob_metrics"
+	"github.com/txix-open/isp-kit/observability/tracing/bgjob_tracing"
 	"github.com/txix-open/isp-kit/requestid"
 )
 
@@ -89,6 +91,8 @@ func (c *Client) Upgrade(ctx context.Context, workerConfigs []WorkerConfig) erro
 // If the request does not contain a RequestId, it attempts to extract one from
 // the context, or generates a new one if none is present.
 //
+// The trace context of ctx is stored in the request id of the job, see bgjob_tracing.
+//
 // Returns an error if the database connection cannot be established.
 func (c *Client) Enqueue(ctx context.Context, req bgjob.EnqueueRequest) error {
 	db, err := c.db.DB()
@@ -106,7 +110,11 @@ func (c *Client) Enqueue(ctx context.Context, req bgjob.EnqueueRequest) error {
 	}
 	req.RequestId = requestId
 
-	return bgjob.Enqueue(ctx, db, req)
+	list := []bgjob.EnqueueRequest{req}
+	endSpans := bgjob_tracing.NewConfig().StartEnqueue(ctx, list)
+	err = bgjob.Enqueue(ctx, db, list[0])
+	endSpans(err)
+	return err
 }
 
 // BulkEnqueue adds multiple jobs to the background job queue in a single operation.
@@ -125,12 +133,18 @@ func (c *Client) BulkEnqueue(ctx context.Context, list []bgjob.EnqueueRequest) e
 		mainRequestId = requestid.Next()
 	}
 
+	// the list is copied, so the trace context is not exposed in the request ids of the caller
+	list = slices.Clone(list)
 	for i := range list {
 		if list[i].RequestId == "" {
 			list[i].RequestId = mainRequestId
 		}
 	}
-	return bgjob.BulkEnqueue(ctx, db, list)
+
+	endSpans := bgjob_tracing.NewConfig().StartEnqueue(ctx, list)
+	err = bgjob.BulkEnqueue(ctx, db, list)
+	endSpans(err)
+	return err
 }
 
 // Close gracefully shuts down all running workers.
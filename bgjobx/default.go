@@ -2,21 +2,24 @@ package bgjobx
 
 import (
 	"github.com/txix-open/isp-kit/bgjobx/handler"
+	"github.com/txix-open/isp-kit/observability/tracing/bgjob_tracing"
 )
 
 // NewDefaultHandler creates a handler with standard middleware applied.
-// It wraps the provided adapter with metrics collection, panic recovery,
+// It wraps the provided adapter with tracing, metrics collection, panic recovery,
 // and request ID propagation middleware.
 //
 // The middleware stack is applied in the following order:
 //  1. RequestId - propagates request IDs to the context
 //  2. Recovery - catches panics and moves jobs to DLQ
 //  3. Metrics - records execution duration and job outcomes
+//  4. Tracing - restores the request ID and starts a span linked to the enqueuing one
 //
 // Returns a Sync handler ready to be used with workers.
 func NewDefaultHandler(adapter handler.SyncHandlerAdapter, metricStorage handler.MetricStorage) handler.Sync {
 	return handler.NewSync(
 		adapter,
+		bgjob_tracing.NewConfig().Middleware(),
 		handler.Metrics(metricStorage),
 		handler.Recovery(),
 		handler.RequestId(),
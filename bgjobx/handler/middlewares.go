@@ -55,18 +55,19 @@ func Recovery() Middleware {
 }
 
 // RequestId creates a middleware that ensures request IDs are available
-// in the handler context. If the job does not have a RequestId, it
+// in the handler context. The trace context propagated in the RequestId of the job
+// is removed, see InjectTraceContext. If the job does not have a RequestId, it
 // generates a new one. The request ID is added to the context for
 // downstream logging and tracing.
 func RequestId() Middleware {
 	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
 		return SyncHandlerAdapterFunc(func(ctx context.Context, job bgjob.Job) Result {
-			requestId := job.RequestId
+			requestId, _ := ExtractTraceContext(job.RequestId)
 
 			if requestId == "" {
 				requestId = requestid.Next()
-				job.RequestId = requestId
 			}
+			job.RequestId = requestId
 
 			ctx = requestid.ToContext(ctx, requestId)
 			ctx = log.ToContext(ctx, log.String(requestid.LogKey, requestId))
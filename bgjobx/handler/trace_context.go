@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// traceContextSeparator separates the request id of a job from its encoded trace context.
+const traceContextSeparator = "|trace:"
+
+// InjectTraceContext returns the request id with the trace context of the carrier appended to it.
+// Jobs do not have headers, so the request id is the only field carrying the trace context
+// from the enqueuing side to the worker. An empty carrier leaves the request id unchanged.
+// The trace context is never exposed as a part of the request id: RequestId and
+// bgjob_tracing middlewares restore the original request id of the job with ExtractTraceContext.
+func InjectTraceContext(requestId string, carrier propagation.MapCarrier) string {
+	if len(carrier) == 0 {
+		return requestId
+	}
+	values := url.Values{}
+	for key, value := range carrier {
+		values.Set(key, value)
+	}
+	return requestId + traceContextSeparator + values.Encode()
+}
+
+// ExtractTraceContext splits the value produced by InjectTraceContext into the original request id
+// and the carrier with the trace context. A value without trace context is returned as is
+// with an empty carrier.
+func ExtractTraceContext(value string) (string, propagation.MapCarrier) {
+	carrier := propagation.MapCarrier{}
+	idx := strings.LastIndex(value, traceContextSeparator)
+	if idx < 0 {
+		return value, carrier
+	}
+
+	values, err := url.ParseQuery(value[idx+len(traceContextSeparator):])
+	if err != nil {
+		return value, carrier
+	}
+	for key := range values {
+		carrier.Set(key, values.Get(key))
+	}
+	return value[:idx], carrier
+}
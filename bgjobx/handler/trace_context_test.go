@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/bgjob"
+	"github.com/txix-open/isp-kit/bgjobx/handler"
+	"github.com/txix-open/isp-kit/requestid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestTraceContext(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	value := handler.InjectTraceContext("abc", propagation.MapCarrier{"traceparent": "00-1-2-01"})
+	requestId, carrier := handler.ExtractTraceContext(value)
+	require.Equal("abc", requestId)
+	require.Equal(propagation.MapCarrier{"traceparent": "00-1-2-01"}, carrier)
+
+	require.Equal("abc", handler.InjectTraceContext("abc", propagation.MapCarrier{}))
+	requestId, carrier = handler.ExtractTraceContext("abc")
+	require.Equal("abc", requestId)
+	require.Empty(carrier)
+}
+
+func TestRequestIdWithoutTraceContext(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var jobRequestId, ctxRequestId string
+	adapter := handler.RequestId()(handler.SyncHandlerAdapterFunc(func(ctx context.Context, job bgjob.Job) handler.Result {
+		jobRequestId = job.RequestId
+		ctxRequestId = requestid.FromContext(ctx)
+		return handler.Complete()
+	}))
+
+	value := handler.InjectTraceContext("abc", propagation.MapCarrier{"traceparent": "00-1-2-01"})
+	adapter.Handle(context.Background(), bgjob.Job{RequestId: value})
+	require.Equal("abc", jobRequestId)
+	require.Equal("abc", ctxRequestId)
+}
@@ -13,14 +13,17 @@ import (
 	"github.com/txix-open/isp-kit/config"
 	"github.com/txix-open/isp-kit/healthcheck"
 	"github.com/txix-open/isp-kit/infra"
+	"github.com/txix-open/isp-kit/infra/log_level"
 	"github.com/txix-open/isp-kit/infra/pprof"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/log/file"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/app_metrics"
+	"github.com/txix-open/isp-kit/observability/otlp"
 	"github.com/txix-open/isp-kit/observability/sentry"
 	"github.com/txix-open/isp-kit/observability/tracing"
 	"github.com/txix-open/isp-kit/validator"
+	"go.opentelemetry.io/otel/log/global"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -34,6 +37,8 @@ const (
 	defaultEnableLogSampling       = false
 	defaultMaxLogSamplingPerSecond = 1000
 	defaulLogSamplingPassEvery     = 100
+
+	defaultRemoteConfigCacheFallbackTimeout = 30 * time.Second
 )
 
 // BaseBootstrap provides the core initialization context for all application types.
@@ -119,6 +124,14 @@ func bootstrap(
 	)
 	tracing.DefaultProvider = tracingProvider
 
+	initOtlp(
+		application,
+		sentryHub,
+		localConfig,
+		moduleVersion,
+		instanceId,
+	)
+
 	application.AddClosers(
 		app.CloserFunc(func() error {
 			sentryHub.Flush()
@@ -168,12 +181,28 @@ func initInfra(application *app.Application, localConfig LocalConfig) (*infra.Se
 	}))
 
 	metricsReg := metrics.DefaultRegistry
-	hcReg := healthcheck.NewRegistry(localConfig.HealthcheckHandlerTimeout)
+	hcReg := healthcheck.NewRegistry(
+		localConfig.HealthcheckHandlerTimeout,
+		healthcheck.WithContext(application.Context()),
+	)
 
 	infraServer.Handle("/internal/metrics", metricsReg.MetricsHandler())
 	infraServer.Handle("/internal/metrics/descriptions", metricsReg.MetricsDescriptionHandler())
 	infraServer.Handle("/internal/health", hcReg.Handler())
+	infraServer.Handle("/internal/health/liveness", hcReg.LivenessHandler())
+	infraServer.Handle("/internal/health/readiness", hcReg.ReadinessHandler())
+	infraServer.Handle("/internal/health/startup", hcReg.StartupHandler())
+	application.AddClosers(app.CloserFunc(func() error {
+		hcReg.Close()
+		return nil
+	}))
+	// runners start after the application is assembled, so all components are registered by then
+	application.AddRunners(app.RunnerFunc(func(ctx context.Context) error {
+		hcReg.CompleteRegistration()
+		return nil
+	}))
 	pprof.RegisterHandlers("/internal", infraServer)
+	log_level.RegisterHandlers("/internal", infraServer, application.Logger())
 
 	application.Logger().Info(application.Context(),
 		"infra server handlers",
@@ -182,7 +211,10 @@ func initInfra(application *app.Application, localConfig LocalConfig) (*infra.Se
 				"/internal/metrics",
 				"/internal/metrics/descriptions",
 				"/internal/health",
-			}, pprof.Endpoints("/internal")...),
+				"/internal/health/liveness",
+				"/internal/health/readiness",
+				"/internal/health/startup",
+			}, append(pprof.Endpoints("/internal"), log_level.Endpoints("/internal")...)...),
 		),
 	)
 
@@ -198,15 +230,7 @@ func initTracing(
 	instanceId string,
 	logger log.Logger,
 ) tracing.Provider {
-	tracingCfg := tracing.Config{
-		Enable:        cfg.Observability.Tracing.Enable,
-		Address:       cfg.Observability.Tracing.Address,
-		ModuleName:    cfg.ModuleName,
-		ModuleVersion: version,
-		Environment:   cfg.Observability.Tracing.Environment,
-		InstanceId:    instanceId,
-		Attributes:    cfg.Observability.Tracing.Attributes,
-	}
+	tracingCfg := tracingConfig(cfg, version, instanceId)
 	provider, err := tracing.NewProviderFromConfiguration(
 		ctx,
 		logger,
@@ -218,9 +242,115 @@ func initTracing(
 		logger.Error(ctx, err)
 		return tracing.NewNoopProvider()
 	}
+
+	propagator, err := tracing.NewPropagator(tracingCfg.Propagators)
+	if err != nil {
+		err = errors.WithMessage(err, "new tracing propagator, default propagator will be used")
+		hub.CatchError(ctx, err, log.ErrorLevel)
+		logger.Error(ctx, err)
+		return provider
+	}
+	tracing.DefaultPropagator = propagator
 	return provider
 }
 
+func tracingConfig(cfg LocalConfig, version string, instanceId string) tracing.Config {
+	return tracing.Config{
+		Enable:        cfg.Observability.Tracing.Enable,
+		Address:       cfg.Observability.Tracing.Address,
+		ModuleName:    cfg.ModuleName,
+		ModuleVersion: version,
+		Environment:   cfg.Observability.Tracing.Environment,
+		InstanceId:    instanceId,
+		Attributes:    cfg.Observability.Tracing.Attributes,
+		Protocol:      cfg.Observability.Tracing.Protocol,
+		Headers:       cfg.Observability.Tracing.Headers,
+		Tls:           cfg.Observability.Tracing.Tls,
+		Sampling:      cfg.Observability.Tracing.Sampling,
+		Propagators:   cfg.Observability.Tracing.Propagators,
+	}
+}
+
+// initOtlp starts export of metrics and logs via OTLP if it is enabled.
+// Export failures are reported, but do not stop the application.
+func initOtlp(
+	application *app.Application,
+	hub sentry.Hub,
+	cfg LocalConfig,
+	version string,
+	instanceId string,
+) {
+	ctx := application.Context()
+	logger := application.Logger()
+	if !cfg.Observability.OtlpMetrics.Enable && !cfg.Observability.OtlpLogs.Enable {
+		return
+	}
+
+	tracingCfg := tracingConfig(cfg, version, instanceId)
+	res, err := tracing.NewResource(tracingCfg)
+	if err != nil {
+		err = errors.WithMessage(err, "new otlp resource, otlp export will be disabled")
+		hub.CatchError(ctx, err, log.ErrorLevel)
+		logger.Error(ctx, err)
+		return
+	}
+	otlpCfg := otlp.Config{
+		Address:  tracingCfg.Address,
+		Protocol: tracingCfg.Protocol,
+		Headers:  tracingCfg.Headers,
+		Tls:      tracingCfg.Tls,
+		Resource: res,
+	}
+
+	if cfg.Observability.OtlpMetrics.Enable {
+		meterProvider, err := otlp.NewMeterProvider(
+			ctx,
+			withOtlpAddress(otlpCfg, cfg.Observability.OtlpMetrics.Address),
+			metrics.DefaultRegistry,
+			cfg.Observability.OtlpMetrics.ExportInterval,
+		)
+		if err != nil {
+			err = errors.WithMessage(err, "new otlp meter provider, otlp metrics export will be disabled")
+			hub.CatchError(ctx, err, log.ErrorLevel)
+			logger.Error(ctx, err)
+		} else {
+			application.AddClosers(app.CloserFunc(func() error {
+				err := meterProvider.Shutdown(context.Background())
+				if err != nil {
+					return errors.WithMessage(err, "shutdown otlp meter provider")
+				}
+				return nil
+			}))
+		}
+	}
+
+	if cfg.Observability.OtlpLogs.Enable {
+		loggerProvider, err := otlp.NewLoggerProvider(ctx, withOtlpAddress(otlpCfg, cfg.Observability.OtlpLogs.Address))
+		if err != nil {
+			err = errors.WithMessage(err, "new otlp logger provider, otlp logs export will be disabled")
+			hub.CatchError(ctx, err, log.ErrorLevel)
+			logger.Error(ctx, err)
+		} else {
+			global.SetLoggerProvider(loggerProvider)
+			application.AddClosers(app.CloserFunc(func() error {
+				err := loggerProvider.Shutdown(context.Background())
+				if err != nil {
+					return errors.WithMessage(err, "shutdown otlp logger provider")
+				}
+				return nil
+			}))
+		}
+	}
+}
+
+// withOtlpAddress returns the configuration with the collector address of a signal if it is set.
+func withOtlpAddress(cfg otlp.Config, address string) otlp.Config {
+	if address != "" {
+		cfg.Address = address
+	}
+	return cfg
+}
+
 func appConfig(isDev bool) (*app.Config, error) {
 	localConfigPath, err := configFilePath(isDev)
 	if err != nil {
@@ -265,6 +395,12 @@ func appConfig(isDev bool) (*app.Config, error) {
 			}
 		}
 
+		var cores []zapcore.Core
+		if cfg.Optional().Bool("OBSERVABILITY.OTLPLOGS.ENABLE", false) {
+			// records are dropped until the logger provider is set in bootstrap
+			cores = append(cores, log.NewOtlpCore(global.GetLoggerProvider()))
+		}
+
 		return log.Config{
 			IsInDevMode:  isDev,
 			OutputPaths:  outputPaths,
@@ -273,6 +409,7 @@ func appConfig(isDev bool) (*app.Config, error) {
 			Hooks: []func(entry zapcore.Entry) error{
 				logCounter.SampledLogCounter(),
 			},
+			Cores: cores,
 		}
 	})
 
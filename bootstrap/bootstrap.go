@@ -343,12 +343,25 @@ func initClusterClient(
 		return nil, errors.WithMessage(err, "parse config service hosts")
 	}
 
+	opts := make([]cluster.ClientOption, 0)
+	if localConfig.RemoteConfigCache.Path != "" {
+		fallbackTimeout := localConfig.RemoteConfigCache.FallbackTimeout
+		if fallbackTimeout <= 0 {
+			fallbackTimeout = defaultRemoteConfigCacheFallbackTimeout
+		}
+		opts = append(opts, cluster.WithConfigCache(
+			cluster.NewConfigCache(localConfig.RemoteConfigCache.Path),
+			fallbackTimeout,
+		))
+	}
+
 	return cluster.NewClient(
 		moduleInfo,
 		configData,
 		configServiceHosts,
 		localConfig.RemoteConfigReceiverTimeout,
 		logger,
+		opts...,
 	), nil
 }
 
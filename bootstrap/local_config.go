@@ -1,6 +1,10 @@
 package bootstrap
 
-import "time"
+import (
+	"time"
+
+	"github.com/txix-open/isp-kit/observability/tracing"
+)
 
 // LocalConfig defines the local configuration structure for standalone applications.
 //
@@ -41,6 +45,7 @@ type LocalConfig struct {
 //   - DefaultRemoteConfigPath: Custom path for default remote configuration file (optional)
 //   - RemoteConfigReceiverTimeout: Timeout for receiving remote configuration updates
 //   - MetricsAutodiscovery: Configuration for Prometheus metrics auto-discovery
+//   - RemoteConfigCache: Local persistence of the last-known-good remote configuration
 type ClusteredLocalConfig struct {
 	LocalConfig
 
@@ -48,6 +53,18 @@ type ClusteredLocalConfig struct {
 	DefaultRemoteConfigPath     string
 	RemoteConfigReceiverTimeout time.Duration
 	MetricsAutodiscovery        MetricsAutodiscovery
+	RemoteConfigCache           RemoteConfigCache
+}
+
+// RemoteConfigCache configures local persistence of accepted remote configurations.
+//
+// Fields:
+//   - Path: Path to the cache file; the cache is disabled if empty
+//   - FallbackTimeout: Time to wait for the config service on cold start before applying
+//     the cached configuration (default: 30s)
+type RemoteConfigCache struct {
+	Path            string
+	FallbackTimeout time.Duration
 }
 
 // Logs configures log sampling and rate limiting.
@@ -110,14 +127,19 @@ type GrpcInnerAddr struct {
 	Port int    `validate:"required"`
 }
 
-// Observability configures observability features including Sentry and tracing.
+// Observability configures observability features including Sentry, tracing
+// and export of metrics and logs via OTLP.
 //
 // Fields:
 //   - Sentry: Error reporting configuration
 //   - Tracing: Distributed tracing configuration
+//   - OtlpMetrics: Export of metrics to the tracing collector
+//   - OtlpLogs: Export of logs to the tracing collector
 type Observability struct {
-	Sentry  Sentry
-	Tracing Tracing
+	Sentry      Sentry
+	Tracing     Tracing
+	OtlpMetrics OtlpMetrics
+	OtlpLogs    OtlpLogs
 }
 
 // Sentry configures Sentry error reporting.
@@ -141,11 +163,47 @@ type Sentry struct {
 //   - Address: Tracing collector endpoint address
 //   - Environment: Environment name for trace attribution
 //   - Attributes: Additional attributes for trace spans
+//   - Protocol: OTLP exporter protocol, "http" (default) or "grpc"
+//   - Headers: Headers sent with every export request, e.g. for authorization
+//   - Tls: TLS or mTLS settings of the collector connection (optional, insecure if empty)
+//   - Sampling: Parent based ratio sampling and per-endpoint rules (optional, every span is sampled if empty)
+//   - Propagators: Context propagators, e.g. ["tracecontext", "baggage", "b3"] (default: tracecontext)
 type Tracing struct {
 	Enable      bool
 	Address     string
 	Environment string
 	Attributes  map[string]string
+	Protocol    string
+	Headers     map[string]string
+	Tls         *tracing.TlsConfig
+	Sampling    *tracing.SamplingConfig
+	Propagators []string
+}
+
+// OtlpMetrics configures export of all metrics of the default registry via OTLP
+// alongside the Prometheus endpoint. Protocol, Headers and Tls of Tracing are always used,
+// the address of Tracing is used unless Address is set.
+//
+// Fields:
+//   - Enable: Enable export of metrics
+//   - Address: Collector endpoint address of metrics (optional, defaults to Tracing.Address)
+//   - ExportInterval: Interval between exports (default: 1m)
+type OtlpMetrics struct {
+	Enable         bool
+	Address        string
+	ExportInterval time.Duration
+}
+
+// OtlpLogs configures export of log records via OTLP alongside the log outputs.
+// Records are correlated with the active span. Protocol, Headers and Tls of Tracing are always used,
+// the address of Tracing is used unless Address is set.
+//
+// Fields:
+//   - Enable: Enable export of logs
+//   - Address: Collector endpoint address of logs (optional, defaults to Tracing.Address)
+type OtlpLogs struct {
+	Enable  bool
+	Address string
 }
 
 // MetricsAutodiscovery configures Prometheus metrics auto-discovery.
@@ -0,0 +1,230 @@
+// Package breaker provides a circuit breaker protecting callers from a failing downstream.
+// A breaker counts call results in a sliding window and opens when the failure rate
+// exceeds the threshold. An open breaker rejects calls immediately with ErrOpen,
+// after the open timeout it lets a few trial calls through in the half-open state
+// and closes again if they succeed.
+//
+// Breakers are grouped by a key, e.g. a host or an endpoint, so one failing
+// downstream does not affect the others. State changes are logged and exported
+// through circuit_breaker_metrics.
+package breaker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics/circuit_breaker_metrics"
+)
+
+var (
+	// ErrOpen is returned when the breaker rejects a call.
+	ErrOpen = errors.New("circuit breaker is open")
+)
+
+// State is a state of the circuit breaker.
+type State int
+
+const (
+	// StateClosed lets all calls through and counts their results.
+	StateClosed State = iota
+	// StateHalfOpen lets a limited number of trial calls through.
+	StateHalfOpen
+	// StateOpen rejects all calls until the open timeout expires.
+	StateOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Result is an outcome of a call passed through the breaker.
+type Result int
+
+const (
+	// ResultSuccess marks a successful call.
+	ResultSuccess Result = iota
+	// ResultFailure marks a failed call, it is counted toward the failure rate.
+	ResultFailure
+	// ResultIgnored marks a call which result says nothing about the downstream,
+	// e.g. cancelled by the caller. It is not counted.
+	ResultIgnored
+)
+
+// Done reports the result of a call allowed by the breaker.
+type Done func(result Result)
+
+// Breaker is a circuit breaker for a single key.
+//
+// Breaker is safe for concurrent use by multiple goroutines.
+type Breaker struct {
+	name   string
+	key    string
+	cfg    *config
+	window *window
+
+	lock              sync.Mutex
+	state             State
+	generation        uint64
+	openedAt          time.Time
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+}
+
+// newBreaker creates a closed breaker for the key.
+func newBreaker(name string, key string, cfg *config) *Breaker {
+	b := &Breaker{
+		name:   name,
+		key:    key,
+		cfg:    cfg,
+		window: newWindow(cfg.window, cfg.windowBuckets),
+		state:  StateClosed,
+	}
+	cfg.metrics.SetState(name, key, circuit_breaker_metrics.StateClosed)
+	return b
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refresh(context.Background(), time.Now())
+	return b.state
+}
+
+// Allow checks whether a call may proceed.
+// If so, it returns a Done function which must be called exactly once with the result of the call.
+// Otherwise, it returns ErrOpen.
+func (b *Breaker) Allow(ctx context.Context) (Done, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refresh(ctx, time.Now())
+	switch b.state {
+	case StateOpen:
+		b.cfg.metrics.IncRejected(b.name, b.key)
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.halfOpenInFlight+b.halfOpenSuccesses >= b.cfg.halfOpenMaxRequests {
+			b.cfg.metrics.IncRejected(b.name, b.key)
+			return nil, ErrOpen
+		}
+		b.halfOpenInFlight++
+	}
+
+	generation := b.generation
+	once := sync.Once{}
+	return func(result Result) {
+		once.Do(func() {
+			b.done(ctx, generation, result)
+		})
+	}, nil
+}
+
+// Do runs fn if the breaker allows it. Any error returned by fn is counted as a failure.
+// If the breaker is open, fn is not called and ErrOpen is returned.
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	done, err := b.Allow(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	if err != nil {
+		done(ResultFailure)
+		return err
+	}
+	done(ResultSuccess)
+	return nil
+}
+
+// done records the result of a call started in the generation.
+// Results of calls started before the last state transition are ignored.
+func (b *Breaker) done(ctx context.Context, generation uint64, result Result) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	now := time.Now()
+	switch b.state {
+	case StateClosed:
+		if result == ResultIgnored {
+			return
+		}
+		b.window.record(now, result == ResultSuccess)
+		total, failures := b.window.counts(now)
+		if total >= b.cfg.minRequests && float64(failures)/float64(total) >= b.cfg.failureRateThreshold {
+			b.transit(ctx, now, StateOpen)
+		}
+	case StateHalfOpen:
+		b.halfOpenInFlight--
+		switch result {
+		case ResultFailure:
+			b.transit(ctx, now, StateOpen)
+		case ResultSuccess:
+			b.halfOpenSuccesses++
+			if b.halfOpenSuccesses >= b.cfg.halfOpenMaxRequests {
+				b.transit(ctx, now, StateClosed)
+			}
+		}
+	}
+}
+
+// refresh switches an open breaker to half-open when the open timeout expires.
+func (b *Breaker) refresh(ctx context.Context, now time.Time) {
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.cfg.openTimeout {
+		b.transit(ctx, now, StateHalfOpen)
+	}
+}
+
+// transit moves the breaker to the state, resets counters and reports the change.
+func (b *Breaker) transit(ctx context.Context, now time.Time, state State) {
+	prev := b.state
+	b.state = state
+	b.generation++
+	b.halfOpenInFlight = 0
+	b.halfOpenSuccesses = 0
+	b.window.reset()
+	if state == StateOpen {
+		b.openedAt = now
+	}
+
+	b.cfg.metrics.SetState(b.name, b.key, stateValue(state))
+	b.cfg.metrics.IncTransition(b.name, b.key, state.String())
+	if b.cfg.logger != nil {
+		b.cfg.logger.Warn(ctx, "circuit breaker: state changed",
+			log.String("breaker", b.name),
+			log.String("key", b.key),
+			log.String("from", prev.String()),
+			log.String("to", state.String()),
+		)
+	}
+}
+
+// stateValue converts the state to the metric gauge value.
+func stateValue(state State) float64 {
+	switch state {
+	case StateHalfOpen:
+		return circuit_breaker_metrics.StateHalfOpen
+	case StateOpen:
+		return circuit_breaker_metrics.StateOpen
+	default:
+		return circuit_breaker_metrics.StateClosed
+	}
+}
@@ -0,0 +1,114 @@
+package breaker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/breaker"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+func TestBreaker_Transitions(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	group := breaker.NewGroup("test",
+		breaker.WithMinRequests(4),
+		breaker.WithFailureRateThreshold(0.5),
+		breaker.WithOpenTimeout(100*time.Millisecond),
+		breaker.WithHalfOpenMaxRequests(2),
+		breaker.WithMetrics(metrics.NewRegistry()),
+	)
+	b := group.Get("host")
+	require.Same(b, group.Get("host"))
+	require.NotSame(b, group.Get("other"))
+
+	fail := func(ctx context.Context) error {
+		return errors.New("downstream error")
+	}
+	ok := func(ctx context.Context) error {
+		return nil
+	}
+
+	require.NoError(b.Do(t.Context(), ok))
+	require.NoError(b.Do(t.Context(), ok))
+	require.Error(b.Do(t.Context(), fail))
+	require.Equal(breaker.StateClosed, b.State())
+	require.Error(b.Do(t.Context(), fail))
+	require.Equal(breaker.StateOpen, b.State())
+
+	err := b.Do(t.Context(), ok)
+	require.ErrorIs(err, breaker.ErrOpen)
+	require.Equal(breaker.StateClosed, group.Get("other").State())
+
+	time.Sleep(150 * time.Millisecond)
+	require.Equal(breaker.StateHalfOpen, b.State())
+
+	done1, err := b.Allow(t.Context())
+	require.NoError(err)
+	done2, err := b.Allow(t.Context())
+	require.NoError(err)
+	_, err = b.Allow(t.Context())
+	require.ErrorIs(err, breaker.ErrOpen)
+
+	done1(breaker.ResultSuccess)
+	require.Equal(breaker.StateHalfOpen, b.State())
+	done2(breaker.ResultSuccess)
+	require.Equal(breaker.StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenFailure(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := breaker.NewGroup("test",
+		breaker.WithMinRequests(1),
+		breaker.WithOpenTimeout(50*time.Millisecond),
+		breaker.WithMetrics(metrics.NewRegistry()),
+	).Get("host")
+
+	done, err := b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultFailure)
+	require.Equal(breaker.StateOpen, b.State())
+
+	time.Sleep(70 * time.Millisecond)
+	done, err = b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultIgnored)
+	require.Equal(breaker.StateHalfOpen, b.State())
+
+	done, err = b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultFailure)
+	require.Equal(breaker.StateOpen, b.State())
+}
+
+func TestBreaker_Window(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	b := breaker.NewGroup("test",
+		breaker.WithMinRequests(2),
+		breaker.WithWindow(100*time.Millisecond, 2),
+		breaker.WithMetrics(metrics.NewRegistry()),
+	).Get("host")
+
+	done, err := b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultFailure)
+
+	time.Sleep(200 * time.Millisecond)
+	done, err = b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultFailure)
+	require.Equal(breaker.StateClosed, b.State())
+
+	done, err = b.Allow(t.Context())
+	require.NoError(err)
+	done(breaker.ResultFailure)
+	require.Equal(breaker.StateOpen, b.State())
+}
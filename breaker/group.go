@@ -0,0 +1,42 @@
+package breaker
+
+import (
+	"sync"
+)
+
+// Group holds independent breakers sharing the same configuration, one per key.
+//
+// Group is safe for concurrent use by multiple goroutines.
+type Group struct {
+	name     string
+	cfg      *config
+	lock     sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewGroup creates a new Group with the specified name and options.
+// The name is used in logs and as the "breaker" metric label.
+//
+// Example:
+//
+//	group := breaker.NewGroup("payments", breaker.WithFailureRateThreshold(0.3), breaker.WithLogger(logger))
+func NewGroup(name string, opts ...Option) *Group {
+	return &Group{
+		name:     name,
+		cfg:      newConfig(opts...),
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get returns the breaker for the key, creating a closed one on the first call.
+func (g *Group) Get(key string) *Breaker {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	b, ok := g.breakers[key]
+	if !ok {
+		b = newBreaker(g.name, key, g.cfg)
+		g.breakers[key] = b
+	}
+	return b
+}
@@ -0,0 +1,111 @@
+package breaker
+
+import (
+	"time"
+
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/circuit_breaker_metrics"
+)
+
+const (
+	defaultWindow               = 10 * time.Second
+	defaultWindowBuckets        = 10
+	defaultFailureRateThreshold = 0.5
+	defaultMinRequests          = 10
+	defaultOpenTimeout          = 30 * time.Second
+	defaultHalfOpenMaxRequests  = 1
+)
+
+// Option is a function that configures a Group.
+type Option func(c *config)
+
+// config holds the settings shared by all breakers of a group.
+type config struct {
+	window               time.Duration
+	windowBuckets        int
+	failureRateThreshold float64
+	minRequests          int
+	openTimeout          time.Duration
+	halfOpenMaxRequests  int
+	logger               log.Logger
+	metricsRegistry      *metrics.Registry
+	metrics              *circuit_breaker_metrics.Storage
+}
+
+// newConfig creates a config with default settings and applies the options.
+func newConfig(opts ...Option) *config {
+	c := &config{
+		window:               defaultWindow,
+		windowBuckets:        defaultWindowBuckets,
+		failureRateThreshold: defaultFailureRateThreshold,
+		minRequests:          defaultMinRequests,
+		openTimeout:          defaultOpenTimeout,
+		halfOpenMaxRequests:  defaultHalfOpenMaxRequests,
+		metricsRegistry:      metrics.DefaultRegistry,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.metrics = circuit_breaker_metrics.NewStorage(c.metricsRegistry)
+	return c
+}
+
+// WithWindow sets the sliding window used to calculate the failure rate in the closed state.
+// The window is divided into the specified number of buckets, the oldest bucket is dropped as time goes.
+// By default, the window is 10 seconds with 10 buckets.
+func WithWindow(window time.Duration, buckets int) Option {
+	return func(c *config) {
+		c.window = window
+		c.windowBuckets = max(buckets, 1)
+	}
+}
+
+// WithFailureRateThreshold sets the failure rate in range (0, 1] which opens the breaker.
+// By default, the threshold is 0.5.
+func WithFailureRateThreshold(threshold float64) Option {
+	return func(c *config) {
+		c.failureRateThreshold = threshold
+	}
+}
+
+// WithMinRequests sets the minimum number of calls in the window before the failure rate is evaluated.
+// By default, it is 10.
+func WithMinRequests(minRequests int) Option {
+	return func(c *config) {
+		c.minRequests = minRequests
+	}
+}
+
+// WithOpenTimeout sets how long the breaker stays open before switching to half-open.
+// By default, it is 30 seconds.
+func WithOpenTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.openTimeout = timeout
+	}
+}
+
+// WithHalfOpenMaxRequests sets the number of trial calls allowed in the half-open state.
+// The breaker closes when all of them succeed and opens again on the first failure.
+// By default, it is 1.
+func WithHalfOpenMaxRequests(maxRequests int) Option {
+	return func(c *config) {
+		c.halfOpenMaxRequests = max(maxRequests, 1)
+	}
+}
+
+// WithLogger sets the logger for state transitions.
+// By default, transitions are not logged.
+func WithLogger(logger log.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithMetrics sets the metrics registry for the breaker metrics.
+// By default, metrics.DefaultRegistry is used.
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(c *config) {
+		c.metricsRegistry = registry
+	}
+}
@@ -0,0 +1,64 @@
+package breaker
+
+import (
+	"time"
+)
+
+// bucket holds call counters of a single time slice of the window.
+type bucket struct {
+	epoch     int64
+	successes int
+	failures  int
+}
+
+// window counts calls in a sliding time window divided into buckets.
+type window struct {
+	bucketSize time.Duration
+	buckets    []bucket
+}
+
+// newWindow creates a window of the specified size and number of buckets.
+func newWindow(size time.Duration, buckets int) *window {
+	return &window{
+		bucketSize: max(size/time.Duration(buckets), 1),
+		buckets:    make([]bucket, buckets),
+	}
+}
+
+// record adds a call result to the bucket of the current time.
+func (w *window) record(now time.Time, success bool) {
+	epoch := w.epoch(now)
+	b := &w.buckets[epoch%int64(len(w.buckets))]
+	if b.epoch != epoch {
+		*b = bucket{epoch: epoch}
+	}
+	if success {
+		b.successes++
+	} else {
+		b.failures++
+	}
+}
+
+// counts returns the number of all and failed calls within the window.
+func (w *window) counts(now time.Time) (int, int) {
+	epoch := w.epoch(now)
+	total, failures := 0, 0
+	for _, b := range w.buckets {
+		if epoch-b.epoch >= int64(len(w.buckets)) {
+			continue
+		}
+		total += b.successes + b.failures
+		failures += b.failures
+	}
+	return total, failures
+}
+
+// reset drops all counters.
+func (w *window) reset() {
+	clear(w.buckets)
+}
+
+// epoch returns the sequence number of the bucket for the time.
+func (w *window) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(w.bucketSize)
+}
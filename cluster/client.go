@@ -19,12 +19,14 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/txix-open/etp/v4"
+	"github.com/txix-open/isp-kit/healthcheck"
 	"github.com/txix-open/isp-kit/json"
 	"github.com/txix-open/isp-kit/lb"
 	"github.com/txix-open/isp-kit/log"
@@ -48,7 +50,8 @@ const (
 type Client struct {
 	moduleInfo          ModuleInfo
 	configData          ConfigData
-	lb                  *lb.RoundRobin
+	hosts               []string
+	lb                  lb.Balancer
 	eventHandler        *EventHandler
 	handleConfigTimeout time.Duration
 	logger              log.Logger
@@ -56,28 +59,81 @@ type Client struct {
 	closed              *atomic.Bool
 	applyConfigLock     sync.Mutex
 
+	configCache          *ConfigCache
+	cacheFallbackTimeout time.Duration
+	configState          *atomic.Pointer[ConfigState]
+
 	cli *atomic.Pointer[clientWrapper]
 }
 
+// ClientOption configures a Client.
+type ClientOption func(c *Client)
+
+// nolint:gochecknoglobals
+var (
+	// ownedBalancers holds the balancers passed to WithBalancer, each of them is owned by a single Client.
+	ownedBalancers = &sync.Map{}
+)
+
+// WithConfigCache enables persistence of accepted remote configurations.
+// Every configuration successfully applied from the isp-config-service is saved to the cache.
+// If no configuration is received within fallbackTimeout after Run is called,
+// the last-known-good configuration is loaded from the cache and applied.
+func WithConfigCache(cache ConfigCache, fallbackTimeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.configCache = &cache
+		c.cacheFallbackTimeout = fallbackTimeout
+	}
+}
+
+// WithBalancer sets the strategy choosing the isp-config-service host for every session,
+// round-robin by default. The hosts passed to NewClient are set to the balancer.
+// A session failed with an error is reported to the balancer as a failure of the host,
+// which drives outlier ejection if it is enabled.
+//
+// The Client takes ownership of the balancer: its hosts and state are modified by the Client,
+// so the balancer must not be shared. It panics if the balancer is already owned by another Client.
+func WithBalancer(balancer lb.Balancer) ClientOption {
+	return func(c *Client) {
+		if reflect.TypeOf(balancer).Comparable() {
+			_, owned := ownedBalancers.LoadOrStore(balancer, struct{}{})
+			if owned {
+				panic(errors.New("balancer is already owned by another cluster client"))
+			}
+		}
+		c.lb = balancer
+		if len(c.hosts) > 0 {
+			balancer.Upgrade(c.hosts)
+		}
+	}
+}
+
 // NewClient creates a new Client with the provided module information, configuration data,
-// list of hosts, timeout duration, and logger.
+// list of hosts, timeout duration, logger and options.
 func NewClient(
 	moduleInfo ModuleInfo,
 	configData ConfigData,
 	hosts []string,
 	handleConfigTimeout time.Duration,
 	logger log.Logger,
+	opts ...ClientOption,
 ) *Client {
-	return &Client{
+	c := &Client{
 		moduleInfo:          moduleInfo,
 		configData:          configData,
+		hosts:               hosts,
 		lb:                  lb.NewRoundRobin(hosts),
 		handleConfigTimeout: handleConfigTimeout,
 		sessionIsActive:     &atomic.Bool{},
 		closed:              &atomic.Bool{},
+		configState:         &atomic.Pointer[ConfigState]{},
 		cli:                 &atomic.Pointer[clientWrapper]{},
 		logger:              logger,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Run starts the client's main loop, establishing a connection to the isp-config-service
@@ -90,12 +146,16 @@ func (c *Client) Run(ctx context.Context, eventHandler *EventHandler) error {
 	c.eventHandler = eventHandler
 	c.closed.Store(false)
 
+	if c.configCache != nil && eventHandler.remoteConfigReceiver != nil && c.configState.Load() == nil {
+		go c.fallbackToCache(ctx)
+	}
+
 	for {
 		if c.closed.Load() {
 			return nil
 		}
 
-		host, err := c.lb.Next()
+		host, done, err := c.lb.Acquire("")
 		if err != nil {
 			return errors.WithMessage(err, "peek config service host")
 		}
@@ -106,6 +166,7 @@ func (c *Client) Run(ctx context.Context, eventHandler *EventHandler) error {
 
 		err = c.runSession(sessionCtx, host)
 		if errors.Is(err, context.Canceled) {
+			done(nil)
 			return nil
 		}
 		cli := c.cli.Load()
@@ -113,7 +174,10 @@ func (c *Client) Run(ctx context.Context, eventHandler *EventHandler) error {
 			_ = cli.Close()
 		}
 		if err != nil && !etp.IsNormalClose(err) {
+			done(err)
 			c.logger.Error(sessionCtx, "run config service session", log.String("error", err.Error()))
+		} else {
+			done(nil)
 		}
 		c.logger.Info(sessionCtx, "config service session stopped")
 
@@ -136,13 +200,34 @@ func (c *Client) Close() error {
 }
 
 // Healthcheck returns an error if the session is inactive, otherwise returns nil.
+// If the module is served by the last-known-good configuration, the error wraps
+// healthcheck.ErrDegraded and reports the configuration version, so the check gets
+// the "warn" status instead of failing the readiness of the module.
 func (c *Client) Healthcheck(ctx context.Context) error {
 	if c.sessionIsActive.Load() {
 		return nil
 	}
+	state := c.configState.Load()
+	if state != nil && state.Source == ConfigSourceCache {
+		return errors.WithMessagef(
+			healthcheck.ErrDegraded,
+			"session inactive; serving last-known-good config version %s",
+			state.Version,
+		)
+	}
 	return errors.New("session inactive")
 }
 
+// ConfigState returns the state of the currently applied remote configuration.
+// Returns false if no configuration has been applied yet.
+func (c *Client) ConfigState() (ConfigState, bool) {
+	state := c.configState.Load()
+	if state == nil {
+		return ConfigState{}, false
+	}
+	return *state, true
+}
+
 // runSession establishes and manages a single session with the isp-config-service.
 func (c *Client) runSession(ctx context.Context, host string) error {
 	defer c.sessionIsActive.Store(false)
@@ -257,9 +342,63 @@ func (c *Client) remoteConfigEventHandler(eventId string, data []byte) error {
 		return errors.WithMessage(err, "apply remote config")
 	}
 	c.logger.Info(ctx, "remote config successfully applied")
+
+	version := configVersion(data)
+	if c.configCache != nil {
+		_, err := c.configCache.Save(data)
+		if err != nil {
+			c.logger.Error(ctx, "save remote config to cache", log.String("error", err.Error()))
+		}
+	}
+	c.configState.Store(&ConfigState{
+		Source:    ConfigSourceRemote,
+		Version:   version,
+		AppliedAt: time.Now(),
+	})
 	return nil
 }
 
+// fallbackToCache applies the last-known-good configuration from the cache
+// if no configuration has been received from the isp-config-service within the fallback timeout.
+func (c *Client) fallbackToCache(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(c.cacheFallbackTimeout):
+	}
+
+	c.applyConfigLock.Lock()
+	defer c.applyConfigLock.Unlock()
+
+	if c.configState.Load() != nil {
+		return
+	}
+
+	snapshot, err := c.configCache.Load()
+	if err != nil {
+		c.logger.Error(ctx, "load last-known-good remote config", log.String("error", err.Error()))
+		return
+	}
+
+	ctx = log.ToContext(ctx, log.String("configVersion", snapshot.Version))
+	c.logger.Warn(
+		ctx,
+		"config service is unavailable, applying last-known-good remote config",
+		log.String("savedAt", snapshot.SavedAt.Format(time.RFC3339)),
+	)
+	err = c.applyRemoteConfig(ctx, snapshot.Config)
+	if err != nil {
+		c.logger.Error(ctx, "apply last-known-good remote config", log.String("error", err.Error()))
+		return
+	}
+	c.configState.Store(&ConfigState{
+		Source:    ConfigSourceCache,
+		Version:   snapshot.Version,
+		AppliedAt: time.Now(),
+	})
+	c.logger.Info(ctx, "last-known-good remote config successfully applied")
+}
+
 // routesEventHandler processes incoming routing configuration updates.
 func (c *Client) routesEventHandler(eventId string, data []byte) error {
 	cli := c.cli.Load()
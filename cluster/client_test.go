@@ -0,0 +1,27 @@
+package cluster_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/cluster"
+	"github.com/txix-open/isp-kit/lb"
+	"github.com/txix-open/isp-kit/log"
+)
+
+func TestWithBalancerOwnership(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	logger, err := log.New()
+	require.NoError(err)
+	balancer := lb.NewRoundRobin(nil)
+	cluster.NewClient(cluster.ModuleInfo{}, cluster.ConfigData{}, []string{"a:1"}, time.Second, logger, cluster.WithBalancer(balancer))
+	require.EqualValues(1, balancer.Size())
+
+	require.Panics(func() {
+		cluster.NewClient(cluster.ModuleInfo{}, cluster.ConfigData{}, []string{"b:1", "c:1"}, time.Second, logger, cluster.WithBalancer(balancer))
+	})
+	require.EqualValues(1, balancer.Size())
+}
@@ -0,0 +1,127 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/json"
+)
+
+// ConfigSource identifies where the currently applied remote configuration came from.
+type ConfigSource string
+
+const (
+	// ConfigSourceRemote means the configuration was received from the isp-config-service.
+	ConfigSourceRemote ConfigSource = "remote"
+	// ConfigSourceCache means the configuration was loaded from the last-known-good snapshot.
+	ConfigSourceCache ConfigSource = "cache"
+)
+
+// ConfigState describes the currently applied remote configuration.
+type ConfigState struct {
+	Source ConfigSource
+	// Version is the SHA-256 checksum of the configuration.
+	Version   string
+	AppliedAt time.Time
+}
+
+// ConfigSnapshot is a persisted remote configuration.
+type ConfigSnapshot struct {
+	// Version is the SHA-256 checksum of Config.
+	Version string
+	SavedAt time.Time
+	Config  []byte
+}
+
+// ConfigCache persists accepted remote configurations to a local file
+// and loads the last-known-good one.
+type ConfigCache struct {
+	path string
+}
+
+// NewConfigCache creates a ConfigCache stored at the specified file path.
+func NewConfigCache(path string) ConfigCache {
+	return ConfigCache{
+		path: path,
+	}
+}
+
+// Save atomically writes the configuration with its checksum to the cache file.
+// The file is replaced only after the new content is fully written and synced.
+func (c ConfigCache) Save(config []byte) (*ConfigSnapshot, error) {
+	snapshot := &ConfigSnapshot{
+		Version: configVersion(config),
+		SavedAt: time.Now().UTC(),
+		Config:  config,
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.WithMessage(err, "marshal snapshot")
+	}
+
+	dir := filepath.Dir(c.path)
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "create dir %s", dir)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.path)+".tmp*")
+	if err != nil {
+		return nil, errors.WithMessage(err, "create temp file")
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return nil, errors.WithMessage(err, "write temp file")
+	}
+	err = tmp.Sync()
+	if err != nil {
+		_ = tmp.Close()
+		return nil, errors.WithMessage(err, "sync temp file")
+	}
+	err = tmp.Close()
+	if err != nil {
+		return nil, errors.WithMessage(err, "close temp file")
+	}
+	err = os.Rename(tmp.Name(), c.path)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "rename temp file to %s", c.path)
+	}
+
+	return snapshot, nil
+}
+
+// Load reads the last saved configuration.
+// Returns an error if the file is missing, malformed or its checksum does not match.
+func (c ConfigCache) Load() (*ConfigSnapshot, error) {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "read file %s", c.path)
+	}
+	snapshot := &ConfigSnapshot{}
+	err = json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unmarshal snapshot")
+	}
+	if len(snapshot.Config) == 0 {
+		return nil, errors.New("empty config in snapshot")
+	}
+	checksum := configVersion(snapshot.Config)
+	if checksum != snapshot.Version {
+		return nil, errors.Errorf("checksum mismatch: expected %s, got %s", snapshot.Version, checksum)
+	}
+	return snapshot, nil
+}
+
+// configVersion returns the hex-encoded SHA-256 checksum of the configuration.
+func configVersion(config []byte) string {
+	hash := sha256.Sum256(config)
+	return hex.EncodeToString(hash[:])
+}
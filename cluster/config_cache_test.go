@@ -0,0 +1,96 @@
+package cluster_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/cluster"
+	"github.com/txix-open/isp-kit/healthcheck"
+	"github.com/txix-open/isp-kit/log"
+)
+
+type configReceiver struct {
+	received atomic.Pointer[[]byte]
+}
+
+func (r *configReceiver) ReceiveConfig(ctx context.Context, remoteConfig []byte) error {
+	r.received.Store(&remoteConfig)
+	return nil
+}
+
+func TestConfigCache(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "cache", "remote_config.json")
+	cache := cluster.NewConfigCache(path)
+	_, err := cache.Load()
+	require.Error(err)
+
+	saved, err := cache.Save([]byte(`{"a": 1}`))
+	require.NoError(err)
+	loaded, err := cache.Load()
+	require.NoError(err)
+	require.Equal(saved.Version, loaded.Version)
+	require.Equal([]byte(`{"a": 1}`), loaded.Config)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(err)
+	require.Len(entries, 1)
+
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	data[len(data)/2] ^= 0xFF
+	err = os.WriteFile(path, data, 0600)
+	require.NoError(err)
+	_, err = cache.Load()
+	require.Error(err)
+}
+
+func TestClient_FallbackToCache(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	cache := cluster.NewConfigCache(filepath.Join(t.TempDir(), "remote_config.json"))
+	snapshot, err := cache.Save([]byte(`{"a": 1}`))
+	require.NoError(err)
+
+	logger, err := log.New()
+	require.NoError(err)
+	cli := cluster.NewClient(
+		cluster.ModuleInfo{ModuleName: "test"},
+		cluster.ConfigData{},
+		[]string{"127.0.0.1:1"},
+		time.Second,
+		logger,
+		cluster.WithConfigCache(cache, 100*time.Millisecond),
+	)
+	receiver := &configReceiver{}
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go func() {
+		_ = cli.Run(ctx, cluster.NewEventHandler().RemoteConfigReceiver(receiver))
+	}()
+
+	require.Eventually(func() bool {
+		return receiver.received.Load() != nil
+	}, 3*time.Second, 10*time.Millisecond)
+	require.Equal([]byte(`{"a": 1}`), *receiver.received.Load())
+
+	state, ok := cli.ConfigState()
+	require.True(ok)
+	require.Equal(cluster.ConfigSourceCache, state.Source)
+	require.Equal(snapshot.Version, state.Version)
+
+	err = cli.Healthcheck(ctx)
+	require.ErrorIs(err, healthcheck.ErrDegraded)
+	require.ErrorContains(err, snapshot.Version)
+
+	cancel()
+	_ = cli.Close()
+}
@@ -8,6 +8,11 @@ import (
 	"github.com/txix-open/isp-kit/json"
 )
 
+const (
+	// SecretMask replaces values of secret fields.
+	SecretMask = "***"
+)
+
 var (
 	// secretFieldSubstrings contains field name substrings that indicate sensitive data.
 	secretFieldSubstrings = map[string]bool{
@@ -39,10 +44,8 @@ func HideSecrets(data []byte) ([]byte, error) {
 		if flattenConf[key] == "" {
 			continue
 		}
-		for tag := range secretFieldSubstrings {
-			if strings.Contains(strings.ToLower(key), tag) {
-				flattenConf[key] = "***"
-			}
+		if IsSecretKey(key) {
+			flattenConf[key] = SecretMask
 		}
 	}
 
@@ -64,6 +67,18 @@ func HideSecrets(data []byte) ([]byte, error) {
 	return data, nil
 }
 
+// IsSecretKey reports whether the configuration key contains any of the registered
+// secret substrings (case-insensitive).
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for tag := range secretFieldSubstrings {
+		if strings.Contains(key, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterSecretSubstrings adds custom field name substrings to the list of patterns
 // that indicate sensitive data.
 func RegisterSecretSubstrings(substrings []string) {
@@ -4,18 +4,21 @@ import (
 	"net/http"
 
 	"github.com/txix-open/isp-kit/cluster"
+	"github.com/txix-open/isp-kit/http/openapi"
 	"github.com/txix-open/isp-kit/json"
 )
 
 // commonEndpointsCfg holds configuration for common endpoints.
 type commonEndpointsCfg struct {
 	swagger []byte
+	openApi *openapi.Document
 }
 
 // CommonEndpoints creates a slice of cluster.EndpointDescriptor for common service endpoints.
 // It accepts a basePath for URL path prefix and variadic CommonEndpointOption functions to configure endpoints.
 // Returns a slice of endpoint descriptors that can be registered with a cluster router.
-// Currently supports Swagger documentation endpoint when configured via WithSwaggerEndpoint.
+// Supports Swagger and OpenAPI documentation endpoints when configured via WithSwaggerEndpoint
+// and WithOpenApiEndpoint.
 func CommonEndpoints(basePath string, opts ...CommonEndpointOption) []cluster.EndpointDescriptor {
 	cfg := &commonEndpointsCfg{}
 	for _, opt := range opts {
@@ -26,6 +29,9 @@ func CommonEndpoints(basePath string, opts ...CommonEndpointOption) []cluster.En
 	if len(cfg.swagger) > 0 {
 		endpoints = append(endpoints, swaggerEndpoint(basePath, cfg.swagger))
 	}
+	if cfg.openApi != nil {
+		endpoints = append(endpoints, openApiEndpoint(basePath, *cfg.openApi))
+	}
 
 	return endpoints
 }
@@ -44,3 +50,18 @@ func swaggerEndpoint(basePath string, swaggerJson json.RawMessage) cluster.Endpo
 		},
 	}
 }
+
+// openApiEndpoint creates an endpoint descriptor for serving the generated OpenAPI document.
+// The endpoint is registered at basePath+"/openapi" and returns the document on HTTP GET.
+// User authentication is not required for this endpoint.
+func openApiEndpoint(basePath string, doc openapi.Document) cluster.EndpointDescriptor {
+	return cluster.EndpointDescriptor{
+		Path:             basePath + "/openapi",
+		Inner:            false,
+		UserAuthRequired: false,
+		HttpMethod:       http.MethodGet,
+		Handler: func() openapi.Document {
+			return doc
+		},
+	}
+}
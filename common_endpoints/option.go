@@ -2,6 +2,10 @@
 // It offers a simple way to register standard endpoints like Swagger documentation.
 package common_endpoints
 
+import (
+	"github.com/txix-open/isp-kit/http/openapi"
+)
+
 // CommonEndpointOption is a functional option for configuring commonEndpointsCfg.
 type CommonEndpointOption func(cfg *commonEndpointsCfg)
 
@@ -13,3 +17,12 @@ func WithSwaggerEndpoint(swagger []byte) CommonEndpointOption {
 		cfg.swagger = swagger
 	}
 }
+
+// WithOpenApiEndpoint configures the CommonEndpoints builder to include an OpenAPI endpoint.
+// The doc parameter is typically produced by openapi.Generator from the service router.
+// The endpoint is exposed at {basePath}/openapi and is accessible via HTTP GET.
+func WithOpenApiEndpoint(doc openapi.Document) CommonEndpointOption {
+	return func(cfg *commonEndpointsCfg) {
+		cfg.openApi = &doc
+	}
+}
@@ -0,0 +1,159 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/db"
+	"github.com/txix-open/isp-kit/test"
+	"github.com/txix-open/isp-kit/test/dbt"
+)
+
+type baseRow struct {
+	Id int64
+}
+
+type userRow struct {
+	baseRow
+	FullName string
+	Age      int    `db:"years"`
+	Ignored  string `db:"-"`
+}
+
+type execMock struct {
+	db.DB
+
+	queries []string
+	args    [][]any
+}
+
+func (e *execMock) Exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return driverResult(len(args)), nil
+}
+
+type driverResult int64
+
+func (r driverResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r driverResult) RowsAffected() (int64, error) {
+	return int64(r) / 3, nil // nolint:mnd
+}
+
+func TestUpsertQuery(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	rows := []userRow{
+		{baseRow: baseRow{Id: 1}, FullName: "a", Age: 10},
+		{baseRow: baseRow{Id: 2}, FullName: "b", Age: 20},
+	}
+
+	mock := &execMock{}
+	count, err := db.Upsert(t.Context(), mock, "users", rows, []string{"id"})
+	require.NoError(err)
+	require.EqualValues(2, count)
+	require.EqualValues(
+		"INSERT INTO users (id,full_name,years) VALUES ($1,$2,$3),($4,$5,$6) "+
+			"ON CONFLICT (id) DO UPDATE SET full_name = EXCLUDED.full_name, years = EXCLUDED.years",
+		mock.queries[0],
+	)
+	require.EqualValues([]any{int64(1), "a", 10, int64(2), "b", 20}, mock.args[0])
+
+	mock = &execMock{}
+	_, err = db.Upsert(t.Context(), mock, "users", rows, []string{"id"}, db.UpdateColumns("years"))
+	require.NoError(err)
+	require.Contains(mock.queries[0], "ON CONFLICT (id) DO UPDATE SET years = EXCLUDED.years")
+
+	mock = &execMock{}
+	_, err = db.Upsert(t.Context(), mock, "users", rows, []string{"id"}, db.DoNothing())
+	require.NoError(err)
+	require.Contains(mock.queries[0], "ON CONFLICT (id) DO NOTHING")
+
+	mock = &execMock{}
+	count, err = db.Upsert(t.Context(), mock, "users", []*userRow{
+		{baseRow: baseRow{Id: 1}, FullName: "a", Age: 10},
+		{baseRow: baseRow{Id: 2}, FullName: "b", Age: 20},
+		{baseRow: baseRow{Id: 1}, FullName: "c", Age: 30},
+	}, []string{"id"})
+	require.NoError(err)
+	require.EqualValues(2, count)
+	require.EqualValues([]any{int64(1), "c", 30, int64(2), "b", 20}, mock.args[0])
+
+	nilRows := []*userRow{{baseRow: baseRow{Id: 1}, FullName: "a", Age: 10}, nil}
+	mock = &execMock{}
+	_, err = db.Upsert(t.Context(), mock, "users", nilRows, []string{"id"})
+	require.ErrorContains(err, "row 1 is nil")
+	_, err = db.Upsert(t.Context(), mock, "users", nilRows, []string{"id"}, db.DoNothing())
+	require.ErrorContains(err, "row 1 is nil")
+	require.Empty(mock.queries)
+
+	mock = &execMock{}
+	_, err = db.Upsert(t.Context(), mock, "users", rows, []string{"name"})
+	require.Error(err)
+
+	mock = &execMock{}
+	count, err = db.Upsert(t.Context(), mock, "users", []userRow{}, []string{"id"})
+	require.NoError(err)
+	require.EqualValues(0, count)
+	require.Empty(mock.queries)
+
+	_, err = db.Upsert(t.Context(), mock, "users", rows, nil)
+	require.Error(err)
+	_, err = db.Upsert(t.Context(), mock, "users", []int{1}, []string{"id"})
+	require.Error(err)
+}
+
+func TestBulk(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	testDb := dbt.New(test)
+	testDb.Must().Exec("create table users (id int8 primary key, full_name text not null, years int4 not null)")
+
+	rows := make([]*userRow, 0)
+	for i := range 2500 {
+		rows = append(rows, &userRow{baseRow: baseRow{Id: int64(i)}, FullName: "user", Age: i % 100})
+	}
+	count, err := testDb.CopyFrom(t.Context(), "users", rows)
+	require.NoError(err)
+	require.EqualValues(2500, count)
+
+	_, err = testDb.CopyFrom(t.Context(), "users", []*userRow{{baseRow: baseRow{Id: 3000}}, nil})
+	require.ErrorContains(err, "row 1 is nil")
+
+	count, err = db.Upsert(t.Context(), testDb, "users", []userRow{
+		{baseRow: baseRow{Id: 0}, FullName: "updated", Age: 1},
+		{baseRow: baseRow{Id: 2500}, FullName: "inserted", Age: 2},
+	}, []string{"id"})
+	require.NoError(err)
+	require.EqualValues(2, count)
+
+	err = testDb.RunInTransaction(t.Context(), func(ctx context.Context, tx *db.Tx) error {
+		count, err := tx.CopyFrom(ctx, testDb.Schema()+".users", []userRow{{baseRow: baseRow{Id: 2501}, FullName: "tx", Age: 3}})
+		require.NoError(err)
+		require.EqualValues(1, count)
+
+		read := 0
+		for row, err := range db.SelectStream[userRow](ctx, tx, 1000, "select * from users where id >= $1 order by id", 1) {
+			require.NoError(err)
+			read++
+			require.EqualValues(read, row.Id)
+		}
+		require.EqualValues(2501, read)
+
+		names := make([]string, 0)
+		for name, err := range db.SelectStream[string](ctx, tx, 0, "select full_name from users where id in (0, 2500)") {
+			require.NoError(err)
+			names = append(names, name)
+		}
+		require.ElementsMatch([]string{"updated", "inserted"}, names)
+		return nil
+	})
+	require.NoError(err)
+}
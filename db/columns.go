@@ -0,0 +1,64 @@
+package db
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/pkg/errors"
+)
+
+// mapper maps struct fields to columns the same way as the client does for scanning.
+var mapper = reflectx.NewMapperFunc("db", ToSnakeCase)
+
+// structColumns describes the columns of a struct type.
+type structColumns struct {
+	names   []string
+	indexes [][]int
+}
+
+// columnsOf returns the columns of the struct type T or of the struct T points to.
+// Fields of embedded structs are flattened, fields of nested structs are mapped to a single column.
+func columnsOf(t reflect.Type) (structColumns, error) {
+	t = reflectx.Deref(t)
+	if t.Kind() != reflect.Struct {
+		return structColumns{}, errors.Errorf("expected struct, got %s", t)
+	}
+
+	fields := make([]*reflectx.FieldInfo, 0)
+	for _, field := range mapper.TypeMap(t).Index {
+		if field.Embedded || field.Name == "" || strings.Contains(field.Path, ".") {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	// fields are indexed breadth-first, columns follow the order of declaration
+	slices.SortFunc(fields, func(a *reflectx.FieldInfo, b *reflectx.FieldInfo) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+
+	columns := structColumns{}
+	for _, field := range fields {
+		columns.names = append(columns.names, field.Name)
+		columns.indexes = append(columns.indexes, field.Index)
+	}
+	if len(columns.names) == 0 {
+		return structColumns{}, errors.Errorf("struct %s has no columns", t)
+	}
+	return columns, nil
+}
+
+// values returns the column values of the i-th row, which is a struct value or a pointer to a struct.
+// Returns an error if the row is a nil pointer.
+func (c structColumns) values(row reflect.Value, i int) ([]any, error) {
+	if row.Kind() == reflect.Pointer && row.IsNil() {
+		return nil, errors.Errorf("row %d is nil", i)
+	}
+	row = reflect.Indirect(row)
+	values := make([]any, 0, len(c.indexes))
+	for _, index := range c.indexes {
+		values = append(values, reflectx.FieldByIndexesReadOnly(row, index).Interface())
+	}
+	return values, nil
+}
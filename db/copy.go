@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	// copyFromQuery is the query carrying copyFromRewriter, it is never sent to the database.
+	copyFromQuery = "-- copy from"
+)
+
+// nolint:gochecknoglobals
+var (
+	errCopyFromDone = errors.New("copy from is done")
+)
+
+// CopyFrom inserts rows into the table using the PostgreSQL COPY protocol.
+// It is the fastest way to import a large number of rows.
+// The rows must be a slice of structs or of non-nil pointers to structs, the columns are named
+// the same way as for scanning: by the "db" tag or by the field name in snake_case.
+// The table may be qualified with a schema, e.g. "public.users".
+// Returns the number of copied rows.
+func (db *Client) CopyFrom(ctx context.Context, table string, rows any) (int64, error) {
+	return copyFrom(ctx, db.DB, table, rows)
+}
+
+// CopyFrom inserts rows into the table using the PostgreSQL COPY protocol within the transaction.
+// See Client.CopyFrom for details.
+func (t *Tx) CopyFrom(ctx context.Context, table string, rows any) (int64, error) {
+	return copyFrom(ctx, t.Tx, table, rows)
+}
+
+// copyFrom copies the rows through the pgx connection the execer runs queries on.
+// database/sql does not expose the connection of a transaction, so the pgx connection
+// is acquired by a query carrying copyFromRewriter only when rows are copied.
+func copyFrom(ctx context.Context, execer sqlx.ExecerContext, table string, rows any) (int64, error) {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice {
+		return 0, errors.Errorf("expected slice of structs, got %T", rows)
+	}
+	columns, err := columnsOf(value.Type().Elem())
+	if err != nil {
+		return 0, err
+	}
+	if value.Len() == 0 {
+		return 0, nil
+	}
+
+	rewriter := &copyFromRewriter{
+		table:   pgx.Identifier(strings.Split(table, ".")),
+		columns: columns.names,
+		rows: pgx.CopyFromSlice(value.Len(), func(i int) ([]any, error) {
+			return columns.values(value.Index(i), i)
+		}),
+	}
+	_, err = execer.ExecContext(ctx, copyFromQuery, rewriter)
+	if !rewriter.done {
+		return 0, errors.WithMessagef(err, "copy into %s", table)
+	}
+	if rewriter.err != nil {
+		return 0, errors.WithMessagef(rewriter.err, "copy into %s", table)
+	}
+	return rewriter.count, nil
+}
+
+// copyFromRewriter is a pgx.QueryRewriter copying rows on the pgx connection of the query
+// it is passed to as the first argument. The query itself is aborted and is not traced.
+type copyFromRewriter struct {
+	table   pgx.Identifier
+	columns []string
+	rows    pgx.CopyFromSource
+
+	done  bool
+	count int64
+	err   error
+}
+
+func (r *copyFromRewriter) RewriteQuery(ctx context.Context, conn *pgx.Conn, _ string, _ []any) (string, []any, error) {
+	r.count, r.err = conn.CopyFrom(ctx, r.table, r.columns, r.rows)
+	r.done = true
+	return "", nil, errCopyFromDone
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+)
+
+const (
+	defaultFetchSize = 1000
+)
+
+// cursorSeq generates unique cursor names within the process.
+var cursorSeq = atomic.NewUint64(0)
+
+// SelectStream executes the query with a server-side cursor within the transaction and
+// iterates over the rows without loading all of them into memory. Rows are fetched from
+// the cursor in batches of fetchSize rows, a non-positive fetchSize means 1000.
+// T is scanned the same way as for Select: a struct or a single column value.
+// The iteration stops after the first error, the cursor is closed when the iteration ends.
+//
+// Example:
+//
+//	err := client.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+//		for user, err := range db.SelectStream[User](ctx, tx, 500, "SELECT * FROM users") {
+//			if err != nil {
+//				return err
+//			}
+//			/* process user */
+//		}
+//		return nil
+//	})
+func SelectStream[T any](ctx context.Context, tx *Tx, fetchSize int, query string, args ...any) iter.Seq2[T, error] {
+	if fetchSize <= 0 {
+		fetchSize = defaultFetchSize
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := fmt.Sprintf("isp_kit_cursor_%d", cursorSeq.Inc())
+		_, err := tx.Exec(ctx, fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", cursor, query), args...)
+		if err != nil {
+			yield(zero, errors.WithMessage(err, "declare cursor"))
+			return
+		}
+		defer func() {
+			_, _ = tx.Exec(context.WithoutCancel(ctx), "CLOSE "+cursor)
+		}()
+
+		fetchQuery := fmt.Sprintf("FETCH %d FROM %s", fetchSize, cursor)
+		for {
+			batch := make([]T, 0, fetchSize)
+			err := tx.Select(ctx, &batch, fetchQuery)
+			if err != nil {
+				yield(zero, errors.WithMessage(err, "fetch from cursor"))
+				return
+			}
+			for _, row := range batch {
+				if !yield(row, nil) {
+					return
+				}
+			}
+			if len(batch) < fetchSize {
+				return
+			}
+		}
+	}
+}
@@ -6,13 +6,27 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+type untracedQueryKey struct{}
+
+// nolint:gochecknoglobals
+var (
+	untracedQueryKeyValue = untracedQueryKey{}
+)
+
 // tracers is a slice of pgx QueryTracers that are invoked sequentially.
 type tracers []pgx.QueryTracer
 
 // TraceQueryStart invokes all registered tracers when a query starts.
 // It passes the context through each tracer and returns the final context.
+// Queries carrying copyFromRewriter are not traced, the copy itself is traced by TraceCopyFromStart.
 // nolint:fatcontext
 func (t tracers) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if len(data.Args) > 0 {
+		_, isCopyFrom := data.Args[0].(*copyFromRewriter)
+		if isCopyFrom {
+			return context.WithValue(ctx, untracedQueryKeyValue, true)
+		}
+	}
 	for _, tracer := range t {
 		ctx = tracer.TraceQueryStart(ctx, conn, data)
 	}
@@ -21,7 +35,34 @@ func (t tracers) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.T
 
 // TraceQueryEnd invokes all registered tracers when a query completes.
 func (t tracers) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if ctx.Value(untracedQueryKeyValue) != nil {
+		return
+	}
 	for _, tracer := range t {
 		tracer.TraceQueryEnd(ctx, conn, data)
 	}
 }
+
+// TraceCopyFromStart invokes all registered tracers implementing pgx.CopyFromTracer
+// when a CopyFrom starts.
+// nolint:fatcontext
+func (t tracers) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	for _, tracer := range t {
+		copyFromTracer, ok := tracer.(pgx.CopyFromTracer)
+		if ok {
+			ctx = copyFromTracer.TraceCopyFromStart(ctx, conn, data)
+		}
+	}
+	return ctx
+}
+
+// TraceCopyFromEnd invokes all registered tracers implementing pgx.CopyFromTracer
+// when a CopyFrom completes.
+func (t tracers) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	for _, tracer := range t {
+		copyFromTracer, ok := tracer.(pgx.CopyFromTracer)
+		if ok {
+			copyFromTracer.TraceCopyFromEnd(ctx, conn, data)
+		}
+	}
+}
@@ -43,6 +43,16 @@ func MetricsLabel(name string) TxOption {
 	}
 }
 
+// IsReadOnlyTx reports whether the options mark the transaction as read-only.
+// It allows wrappers to route read-only transactions to replicas.
+func IsReadOnlyTx(opts ...TxOption) bool {
+	options := &txOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options.nativeOpts != nil && options.nativeOpts.ReadOnly
+}
+
 // TxFunc is a function that executes within a transaction.
 type TxFunc func(ctx context.Context, tx *Tx) error
 
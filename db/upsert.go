@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/db/query"
+)
+
+const (
+	// maxQueryParameters is the maximum number of parameters of a PostgreSQL query.
+	maxQueryParameters = 65535
+)
+
+// upsertOptions holds configuration for Upsert.
+type upsertOptions struct {
+	updateColumns []string
+	doNothing     bool
+}
+
+// UpsertOption is a function that configures Upsert.
+type UpsertOption func(options *upsertOptions)
+
+// UpdateColumns sets the columns updated on conflict. By default, all columns except
+// the conflict columns are updated.
+func UpdateColumns(columns ...string) UpsertOption {
+	return func(options *upsertOptions) {
+		options.updateColumns = columns
+	}
+}
+
+// DoNothing makes Upsert skip conflicting rows instead of updating them.
+func DoNothing() UpsertOption {
+	return func(options *upsertOptions) {
+		options.doNothing = true
+	}
+}
+
+// Upsert inserts rows into the table and updates the existing ones on conflict
+// on the conflictColumns with INSERT ... ON CONFLICT.
+// The rows are structs or non-nil pointers to structs, the columns are named the same way as for scanning.
+// Rows are inserted in batches so that each query fits the PostgreSQL limit of parameters.
+// As a single INSERT ... ON CONFLICT DO UPDATE can not affect a row twice, rows with the same values
+// of the conflict columns are deduplicated: the last of them is upserted.
+// Returns the number of inserted and updated rows.
+//
+// Example:
+//
+//	count, err := db.Upsert(ctx, client, "users", users, []string{"id"}, db.UpdateColumns("name"))
+func Upsert[T any](ctx context.Context, db DB, table string, rows []T, conflictColumns []string, opts ...UpsertOption) (int64, error) {
+	options := &upsertOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if len(conflictColumns) == 0 {
+		return 0, errors.New("conflict columns are required")
+	}
+
+	columns, err := columnsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return 0, err
+	}
+	suffix, err := onConflictClause(columns.names, conflictColumns, options)
+	if err != nil {
+		return 0, err
+	}
+
+	if !options.doNothing {
+		rows, err = uniqueRows(rows, columns, conflictColumns)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	batchSize := maxQueryParameters / len(columns.names)
+	var count int64
+	offset := 0
+	for batch := range slices.Chunk(rows, batchSize) {
+		insert := query.New().
+			Insert(table).
+			Columns(columns.names...).
+			Suffix(suffix)
+		for i, row := range batch {
+			values, err := columns.values(reflect.ValueOf(row), offset+i)
+			if err != nil {
+				return count, err
+			}
+			insert = insert.Values(values...)
+		}
+		offset += len(batch)
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return count, errors.WithMessage(err, "build upsert query")
+		}
+
+		result, err := db.Exec(ctx, sql, args...)
+		if err != nil {
+			return count, errors.WithMessagef(err, "upsert into %s", table)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return count, errors.WithMessage(err, "get rows affected")
+		}
+		count += affected
+	}
+	return count, nil
+}
+
+// uniqueRows returns the rows with distinct values of the conflict columns keeping the last row
+// for each value at the position of the first one. Pointer values are compared by the values they point to.
+func uniqueRows[T any](rows []T, columns structColumns, conflictColumns []string) ([]T, error) {
+	conflictIndexes := make([]int, 0, len(conflictColumns))
+	for _, column := range conflictColumns {
+		index := slices.Index(columns.names, column)
+		if index < 0 {
+			return nil, errors.Errorf("conflict column %s is not a column of the rows", column)
+		}
+		conflictIndexes = append(conflictIndexes, index)
+	}
+
+	result := make([]T, 0, len(rows))
+	positions := make(map[string]int, len(rows))
+	for i, row := range rows {
+		values, err := columns.values(reflect.ValueOf(row), i)
+		if err != nil {
+			return nil, err
+		}
+		key := make([]any, 0, len(conflictIndexes))
+		for _, index := range conflictIndexes {
+			key = append(key, derefValue(values[index]))
+		}
+		keyString := fmt.Sprintf("%#v", key)
+
+		position, ok := positions[keyString]
+		if ok {
+			result[position] = row
+			continue
+		}
+		positions[keyString] = len(result)
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// derefValue returns the value the pointer points to or nil for a nil pointer.
+func derefValue(value any) any {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// onConflictClause builds the ON CONFLICT clause for the columns.
+func onConflictClause(columns []string, conflictColumns []string, options *upsertOptions) (string, error) {
+	target := fmt.Sprintf("ON CONFLICT (%s)", strings.Join(conflictColumns, ", "))
+	if options.doNothing {
+		return target + " DO NOTHING", nil
+	}
+
+	updateColumns := options.updateColumns
+	if len(updateColumns) == 0 {
+		for _, column := range columns {
+			if !slices.Contains(conflictColumns, column) {
+				updateColumns = append(updateColumns, column)
+			}
+		}
+	}
+	if len(updateColumns) == 0 {
+		return "", errors.New("no columns to update, use DoNothing option")
+	}
+
+	set := make([]string, 0, len(updateColumns))
+	for _, column := range updateColumns {
+		set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	return fmt.Sprintf("%s DO UPDATE SET %s", target, strings.Join(set, ", ")), nil
+}
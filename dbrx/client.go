@@ -1,6 +1,7 @@
 // Package dbrx provides a dynamic database client that supports runtime
 // configuration updates. It wraps dbx.Client with atomic pointer access,
 // automatic metrics and tracing integration, and hot-reload capability.
+// Reads can be routed to healthy read replicas, writes always go to the primary.
 package dbrx
 
 import (
@@ -32,12 +33,13 @@ const (
 )
 
 // Client provides dynamic database client management with hot-reload support.
-// It wraps a dbx.Client and allows configuration updates at runtime.
+// It wraps a dbx.Client of the primary and optional read replicas and allows
+// configuration updates at runtime.
 // It is safe for concurrent use.
 type Client struct {
 	options []dbx.Option
 	prevCfg *atomic.Value
-	cli     *atomic.Pointer[dbx.Client]
+	cli     *atomic.Pointer[cluster]
 	logger  log.Logger
 }
 
@@ -45,11 +47,11 @@ type Client struct {
 // The client is not initialized until Upgrade is called.
 func New(logger log.Logger, opts ...dbx.Option) *Client {
 	prevCfg := &atomic.Value{}
-	prevCfg.Store(dbx.Config{})
+	prevCfg.Store(Config{})
 	return &Client{
 		options: opts,
 		prevCfg: prevCfg,
-		cli:     &atomic.Pointer[dbx.Client]{},
+		cli:     &atomic.Pointer[cluster]{},
 		logger:  logger,
 	}
 }
@@ -58,7 +60,17 @@ func New(logger log.Logger, opts ...dbx.Option) *Client {
 // If the new configuration is identical to the previous one, initialization is skipped.
 // It automatically adds metrics tracing, schema creation, and application name options.
 // Returns an error if the connection fails or if the client is in read-only mode.
+// Read replicas configured earlier by UpgradeWithReplicas are dropped.
 func (c *Client) Upgrade(ctx context.Context, config dbx.Config) error {
+	return c.UpgradeWithReplicas(ctx, Config{Primary: config})
+}
+
+// UpgradeWithReplicas initializes or reinitializes the database client with the primary
+// and read replicas from the provided configuration.
+// Replicas are opened and checked in the background: a replica which is unavailable,
+// not in read-only mode or lags behind the primary more than allowed is ejected from
+// the balancer until the next successful check. Only the primary connection failure is returned as an error.
+func (c *Client) UpgradeWithReplicas(ctx context.Context, config Config) error {
 	c.logger.Debug(ctx, "db client: received new config")
 
 	if reflect.DeepEqual(c.prevCfg.Load(), config) {
@@ -77,7 +89,7 @@ func (c *Client) Upgrade(ctx context.Context, config dbx.Config) error {
 		dbx.WithApplicationName(os.Args[0]),
 	}, c.options...)
 
-	newCli, err := dbx.Open(ctx, config, opts...)
+	newCli, err := dbx.Open(ctx, config.Primary, opts...)
 	if err != nil {
 		return errors.WithMessage(err, "open new client")
 	}
@@ -90,30 +102,34 @@ func (c *Client) Upgrade(ctx context.Context, config dbx.Config) error {
 		c.logger.Info(ctx, "db client: connection is in read-only mode")
 	}
 
-	oldCli := c.cli.Swap(newCli)
-	if oldCli != nil {
-		_ = oldCli.Close()
+	newCluster := newCluster(newCli, config, opts, c.logger)
+	newCluster.start()
+
+	oldCluster := c.cli.Swap(newCluster)
+	if oldCluster != nil {
+		_ = oldCluster.close()
 	}
 
 	c.logger.Debug(ctx, "db client: initialization done")
 
 	c.prevCfg.Store(config)
 
-	db_metrics.Register(metrics.DefaultRegistry, newCli.Client.DB.DB, config.Database)
+	db_metrics.Register(metrics.DefaultRegistry, newCli.Client.DB.DB, config.Primary.Database)
 
 	return nil
 }
 
-// DB returns the underlying database client.
+// DB returns the underlying database client of the primary.
 // Returns an error if the client has not been initialized.
 func (c *Client) DB() (*dbx.Client, error) {
 	return c.db()
 }
 
 // Select executes a query that returns multiple rows and scans them into the provided pointer.
+// The query goes to a healthy replica if any, unless the context is marked by ForcePrimaryToContext.
 // Returns an error if the client is not initialized or if the query fails.
 func (c *Client) Select(ctx context.Context, ptr any, query string, args ...any) error {
-	cli, err := c.db()
+	cli, err := c.reader(ctx)
 	if err != nil {
 		return err
 	}
@@ -121,9 +137,10 @@ func (c *Client) Select(ctx context.Context, ptr any, query string, args ...any)
 }
 
 // SelectRow executes a query that returns a single row and scans it into the provided pointer.
+// The query goes to a healthy replica if any, unless the context is marked by ForcePrimaryToContext.
 // Returns an error if the client is not initialized or if the query fails.
 func (c *Client) SelectRow(ctx context.Context, ptr any, query string, args ...any) error {
-	cli, err := c.db()
+	cli, err := c.reader(ctx)
 	if err != nil {
 		return err
 	}
@@ -151,9 +168,16 @@ func (c *Client) ExecNamed(ctx context.Context, query string, arg any) (sql.Resu
 }
 
 // RunInTransaction executes the provided function within a database transaction.
+// Transactions marked by db.ReadOnly are routed the same way as Select.
 // Returns an error if the client is not initialized or if the transaction fails.
 func (c *Client) RunInTransaction(ctx context.Context, txFunc db.TxFunc, opts ...db.TxOption) error {
-	cli, err := c.db()
+	var cli *dbx.Client
+	var err error
+	if db.IsReadOnlyTx(opts...) {
+		cli, err = c.reader(ctx)
+	} else {
+		cli, err = c.db()
+	}
 	if err != nil {
 		return err
 	}
@@ -164,15 +188,16 @@ func (c *Client) RunInTransaction(ctx context.Context, txFunc db.TxFunc, opts ..
 // Returns an error if the underlying client fails to close.
 func (c *Client) Close() error {
 	c.logger.Debug(context.Background(), "db client: call close")
-	c.prevCfg.Store(dbx.Config{})
-	oldCli := c.cli.Swap(nil)
-	if oldCli != nil {
-		return oldCli.Close()
+	c.prevCfg.Store(Config{})
+	oldCluster := c.cli.Swap(nil)
+	if oldCluster != nil {
+		return oldCluster.close()
 	}
 	return nil
 }
 
-// Healthcheck verifies that the database connection is alive.
+// Healthcheck verifies that the primary database connection is alive.
+// Unavailable replicas do not fail the check, reads fall back to the primary.
 // Executes a simple query with a 500ms timeout.
 // Returns an error if the client is not initialized or if the query fails.
 func (c *Client) Healthcheck(ctx context.Context) error {
@@ -190,11 +215,20 @@ func (c *Client) Healthcheck(ctx context.Context) error {
 	return nil
 }
 
-// db returns the current database client or an error if not initialized.
+// db returns the current primary database client or an error if not initialized.
 func (c *Client) db() (*dbx.Client, error) {
-	oldCli := c.cli.Load()
-	if oldCli == nil {
+	current := c.cli.Load()
+	if current == nil {
+		return nil, ErrClientIsNotInitialized
+	}
+	return current.primary, nil
+}
+
+// reader returns the current database client for reads or an error if not initialized.
+func (c *Client) reader(ctx context.Context) (*dbx.Client, error) {
+	current := c.cli.Load()
+	if current == nil {
 		return nil, ErrClientIsNotInitialized
 	}
-	return oldCli, nil
+	return current.reader(ctx), nil
 }
@@ -0,0 +1,239 @@
+package dbrx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/dbx"
+	"github.com/txix-open/isp-kit/lb"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/db_metrics"
+)
+
+const (
+	replicaCheckTimeout = 3 * time.Second
+	// replicationStateQuery returns whether the WAL receiver is streaming and the replication lag in seconds.
+	// A streaming replica which has replayed all received WAL is considered up to date
+	// even if the primary has no recent transactions. The status of the WAL receiver is hidden
+	// from roles without pg_read_all_stats, for them a running WAL receiver is considered streaming.
+	replicationStateQuery = `SELECT
+	EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE COALESCE(status, 'streaming') = 'streaming') AS streaming,
+	(CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END)::float8 AS lag_seconds`
+)
+
+// replicationState is the result of replicationStateQuery.
+type replicationState struct {
+	Streaming  bool
+	LagSeconds float64
+}
+
+// replica holds a read replica connection, which is opened lazily by the health monitor.
+type replica struct {
+	config  dbx.Config
+	address string
+	cli     *dbx.Client
+}
+
+// cluster routes queries between the primary and healthy read replicas.
+// Replicas are checked in the background, lagging or failed replicas are ejected from the balancer.
+type cluster struct {
+	primary  *dbx.Client
+	replicas map[string]*replica
+	balancer *lb.RoundRobin
+	options  []dbx.Option
+	maxLag   time.Duration
+	interval time.Duration
+	logger   log.Logger
+
+	lock      sync.RWMutex
+	available map[string]bool
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// newCluster creates a cluster for the primary and replicas from the config.
+func newCluster(primary *dbx.Client, config Config, options []dbx.Option, logger log.Logger) *cluster {
+	replicas := make(map[string]*replica, len(config.Replicas))
+	for _, replicaConfig := range config.Replicas {
+		replicas[replicaConfig.Address()] = &replica{
+			config:  replicaConfig.dbConfig(config.Primary),
+			address: replicaConfig.Address(),
+		}
+	}
+	return &cluster{
+		primary:   primary,
+		replicas:  replicas,
+		balancer:  lb.NewRoundRobin(nil),
+		options:   slices.Concat(options, []dbx.Option{dbx.WithCreateSchema(false)}),
+		maxLag:    config.maxReplicationLag(),
+		interval:  config.replicaCheckInterval(),
+		logger:    logger,
+		available: make(map[string]bool),
+		done:      make(chan struct{}),
+	}
+}
+
+// start checks replicas in the background immediately and then every interval until close is called.
+// Until the first check of a replica completes, reads go to the primary.
+func (c *cluster) start() {
+	if len(c.replicas) == 0 {
+		close(c.done)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			c.refresh(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reader returns a client for read queries: a healthy replica if any, the primary otherwise.
+func (c *cluster) reader(ctx context.Context) *dbx.Client {
+	if len(c.replicas) == 0 || IsPrimaryForced(ctx) {
+		return c.primary
+	}
+
+	address, err := c.balancer.Next()
+	if err != nil {
+		return c.primary
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cli := c.replicas[address].cli
+	if cli == nil || !c.available[address] {
+		return c.primary
+	}
+	return cli
+}
+
+// refresh checks all replicas in parallel and upgrades the balancer with the healthy ones.
+func (c *cluster) refresh(ctx context.Context) {
+	errs := make(map[string]error, len(c.replicas))
+	errsLock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for address, r := range c.replicas {
+		wg.Go(func() {
+			err := c.check(ctx, r)
+			errsLock.Lock()
+			errs[address] = err
+			errsLock.Unlock()
+		})
+	}
+	wg.Wait()
+
+	healthy := make([]string, 0, len(c.replicas))
+	for address, err := range errs {
+		c.lock.Lock()
+		wasAvailable := c.available[address]
+		c.available[address] = err == nil
+		c.lock.Unlock()
+
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return
+		case err != nil && wasAvailable:
+			c.logger.Warn(ctx, "db client: replica ejected",
+				log.String("replica", address),
+				log.String("error", err.Error()),
+			)
+		case err != nil:
+			c.logger.Debug(ctx, "db client: replica is unavailable",
+				log.String("replica", address),
+				log.String("error", err.Error()),
+			)
+		case !wasAvailable:
+			c.logger.Info(ctx, "db client: replica is available", log.String("replica", address))
+		}
+		if err == nil {
+			healthy = append(healthy, address)
+		}
+	}
+	c.balancer.Upgrade(healthy)
+}
+
+// check opens the replica connection if needed and verifies that the replica
+// is read-only and its replication lag does not exceed the limit.
+func (c *cluster) check(ctx context.Context, r *replica) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	c.lock.RLock()
+	cli := r.cli
+	c.lock.RUnlock()
+
+	if cli == nil {
+		newCli, err := dbx.Open(ctx, r.config, c.options...)
+		if err != nil {
+			return errors.WithMessage(err, "open replica")
+		}
+		c.lock.Lock()
+		r.cli = newCli
+		c.lock.Unlock()
+		cli = newCli
+
+		dbName := fmt.Sprintf("%s@%s", r.config.Database, r.address)
+		db_metrics.Register(metrics.DefaultRegistry, cli.Client.DB.DB, dbName)
+	}
+
+	readOnly, err := cli.IsReadOnly(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "check is replica read only")
+	}
+	if !readOnly {
+		return errors.New("replica is not in read-only mode")
+	}
+
+	state := replicationState{}
+	err = cli.SelectRow(ctx, &state, replicationStateQuery)
+	if err != nil {
+		return errors.WithMessage(err, "select replication state")
+	}
+	if !state.Streaming {
+		return errors.New("wal receiver is not streaming")
+	}
+	lag := time.Duration(state.LagSeconds * float64(time.Second))
+	if lag > c.maxLag {
+		return errors.Errorf("replication lag %s exceeds %s", lag, c.maxLag)
+	}
+	return nil
+}
+
+// close stops the background checks and closes all connections.
+func (c *cluster) close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	<-c.done
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, r := range c.replicas {
+		if r.cli != nil {
+			_ = r.cli.Close()
+			r.cli = nil
+		}
+	}
+	return c.primary.Close()
+}
@@ -0,0 +1,64 @@
+package dbrx
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/txix-open/isp-kit/dbx"
+)
+
+const (
+	defaultMaxReplicationLag    = 10 * time.Second
+	defaultReplicaCheckInterval = 5 * time.Second
+)
+
+// Config holds the configuration of the primary database and its read replicas.
+type Config struct {
+	Primary                  dbx.Config      `validate:"required" schema:"Основная база данных"`
+	Replicas                 []ReplicaConfig `schema:"Реплики для чтения"`
+	MaxReplicationLagInMs    int             `schema:"Максимальное отставание реплики в миллисекундах,если <=0 - используется значение по умолчанию равное 10 секундам"`
+	ReplicaCheckIntervalInMs int             `schema:"Интервал проверки реплик в миллисекундах,если <=0 - используется значение по умолчанию равное 5 секундам"`
+}
+
+// ReplicaConfig holds the address of a read replica.
+// Database, credentials and schema are inherited from the primary configuration.
+type ReplicaConfig struct {
+	Host   string            `validate:"required" schema:"Хост"`
+	Port   int               `validate:"required" schema:"Порт"`
+	Params map[string]string `schema:"Дополнительные параметры подключения,дополняют параметры основной базы"`
+}
+
+// Address returns the replica address in host:port format.
+func (c ReplicaConfig) Address() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
+// dbConfig builds the connection configuration of the replica from the primary one.
+func (c ReplicaConfig) dbConfig(primary dbx.Config) dbx.Config {
+	cfg := primary
+	cfg.Host = c.Host
+	cfg.Port = c.Port
+	cfg.Params = maps.Clone(primary.Params)
+	if len(c.Params) > 0 && cfg.Params == nil {
+		cfg.Params = make(map[string]string, len(c.Params))
+	}
+	maps.Copy(cfg.Params, c.Params)
+	return cfg
+}
+
+// maxReplicationLag returns the configured replication lag limit or the default one.
+func (c Config) maxReplicationLag() time.Duration {
+	if c.MaxReplicationLagInMs <= 0 {
+		return defaultMaxReplicationLag
+	}
+	return time.Duration(c.MaxReplicationLagInMs) * time.Millisecond
+}
+
+// replicaCheckInterval returns the configured replica check interval or the default one.
+func (c Config) replicaCheckInterval() time.Duration {
+	if c.ReplicaCheckIntervalInMs <= 0 {
+		return defaultReplicaCheckInterval
+	}
+	return time.Duration(c.ReplicaCheckIntervalInMs) * time.Millisecond
+}
@@ -0,0 +1,20 @@
+package dbrx
+
+import (
+	"context"
+)
+
+// forcePrimaryKey is the context key of the flag forcing reads onto the primary.
+type forcePrimaryKey struct{}
+
+// ForcePrimaryToContext marks the context so that reads go to the primary instead of replicas.
+// Use it right after a write to read the data back without replication lag.
+func ForcePrimaryToContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forcePrimaryKey{}, true)
+}
+
+// IsPrimaryForced reports whether the context forces reads onto the primary.
+func IsPrimaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(forcePrimaryKey{}).(bool)
+	return forced
+}
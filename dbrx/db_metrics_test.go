@@ -105,3 +105,49 @@ func TestCompareConfig(t *testing.T) {
 	require.NoError(err)
 	require.NotEqualValues(db1, db3)
 }
+
+func TestReplicas(t *testing.T) {
+	t.Parallel()
+
+	test, require := test2.New(t)
+
+	primary := dbt.Config(test)
+	cli := dbrx.New(test.Logger())
+	t.Cleanup(func() {
+		_ = cli.Close()
+	})
+
+	err := cli.UpgradeWithReplicas(t.Context(), dbrx.Config{
+		Primary: primary,
+		Replicas: []dbrx.ReplicaConfig{{
+			Host:   primary.Host,
+			Port:   primary.Port,
+			Params: map[string]string{"default_transaction_read_only": "on"},
+		}, {
+			Host: "127.0.0.1",
+			Port: 1,
+		}},
+	})
+	require.NoError(err)
+
+	readOnly := ""
+	err = cli.SelectRow(t.Context(), &readOnly, "SHOW transaction_read_only")
+	require.NoError(err)
+	require.Equal("on", readOnly)
+
+	err = cli.SelectRow(dbrx.ForcePrimaryToContext(t.Context()), &readOnly, "SHOW transaction_read_only")
+	require.NoError(err)
+	require.Equal("off", readOnly)
+
+	err = cli.RunInTransaction(t.Context(), func(ctx context.Context, tx *db.Tx) error {
+		return tx.SelectRow(ctx, &readOnly, "SHOW default_transaction_read_only")
+	}, db.ReadOnly())
+	require.NoError(err)
+	require.Equal("on", readOnly)
+
+	err = cli.RunInTransaction(t.Context(), func(ctx context.Context, tx *db.Tx) error {
+		return tx.SelectRow(ctx, &readOnly, "SHOW transaction_read_only")
+	})
+	require.NoError(err)
+	require.Equal("off", readOnly)
+}
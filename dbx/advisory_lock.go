@@ -0,0 +1,48 @@
+package dbx
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/db"
+)
+
+// AdvisoryLock is a distributed lock based on PostgreSQL transaction-level advisory locks.
+// It implements worker.Locker, so only one replica runs a guarded job at a time.
+type AdvisoryLock struct {
+	db  db.Transactional
+	key string
+}
+
+// NewAdvisoryLock creates a new AdvisoryLock identified by the key.
+// The key is hashed by PostgreSQL hashtext() to get the lock id.
+func NewAdvisoryLock(db db.Transactional, key string) AdvisoryLock {
+	return AdvisoryLock{
+		db:  db,
+		key: key,
+	}
+}
+
+// RunLocked tries to acquire the lock in a new transaction and runs fn while holding it.
+// It returns false without running fn if the lock is held by another session.
+// The lock is released when the transaction ends. An error returned by fn does not roll back the lock
+// transaction and is returned as is.
+func (l AdvisoryLock) RunLocked(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	var fnErr error
+	err := l.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		err := tx.SelectRow(ctx, &acquired, "SELECT pg_try_advisory_xact_lock(hashtext($1))", l.key)
+		if err != nil {
+			return errors.WithMessage(err, "try advisory lock")
+		}
+		if !acquired {
+			return nil
+		}
+		fnErr = fn(ctx)
+		return nil
+	}, db.MetricsLabel("advisory_lock"))
+	if err != nil {
+		return acquired, errors.WithMessagef(err, "advisory lock '%s'", l.key)
+	}
+	return acquired, fnErr
+}
@@ -1,6 +1,7 @@
 package dbx_test
 
 import (
+	"context"
 	"os"
 	"strconv"
 	"testing"
@@ -40,3 +41,32 @@ func envOrDefault(name string, defValue string) string {
 	}
 	return defValue
 }
+
+func TestAdvisoryLock(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	port, err := strconv.Atoi(envOrDefault("PG_PORT", "5432"))
+	require.NoError(err)
+	cfg := dbx.Config{
+		Host:     envOrDefault("PG_HOST", "127.0.0.1"),
+		Port:     port,
+		Database: envOrDefault("PG_DB", "test"),
+		Username: envOrDefault("PG_USER", "test"),
+		Password: envOrDefault("PG_PASS", "test"),
+	}
+	db, err := dbx.Open(t.Context(), cfg)
+	require.NoError(err)
+
+	lock := dbx.NewAdvisoryLock(db, "test_advisory_lock")
+	acquired, err := lock.RunLocked(t.Context(), func(ctx context.Context) error {
+		innerAcquired, err := lock.RunLocked(ctx, func(ctx context.Context) error {
+			return nil
+		})
+		require.NoError(err)
+		require.False(innerAcquired)
+		return nil
+	})
+	require.NoError(err)
+	require.True(acquired)
+}
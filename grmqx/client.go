@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rabbitmq/amqp091-go"
 	"github.com/txix-open/grmq"
+	"github.com/txix-open/isp-kit/infra/consumer_control"
 	"github.com/txix-open/isp-kit/log"
 )
 
@@ -27,15 +28,19 @@ const (
 var (
 	// ErrNotExistQueue returned when trying to operate a queue that doesn't exist.
 	ErrNotExistQueue = errors.New("queue does not exist")
+	// ErrConsumerNotFound returned when no consumer reads the requested queue.
+	ErrConsumerNotFound = consumer_control.ErrConsumerNotFound
 )
 
 // Client manages RabbitMQ connections and the lifecycle of consumers and publishers.
 // It supports dynamic configuration updates and is safe for concurrent use.
 type Client struct {
-	cli     *grmq.Client
-	prevCfg Config
-	lock    sync.Locker
-	logger  log.Logger
+	cli       *grmq.Client
+	consumers *consumerSet
+	prevCfg   Config
+	paused    map[string]bool
+	lock      sync.Locker
+	logger    log.Logger
 }
 
 // New creates a new RabbitMQ client instance.
@@ -43,6 +48,7 @@ func New(logger log.Logger) *Client {
 	return &Client{
 		cli:     nil,
 		prevCfg: Config{},
+		paused:  make(map[string]bool),
 		lock:    &sync.Mutex{},
 		logger:  logger,
 	}
@@ -83,11 +89,56 @@ func (c *Client) Close() {
 	defer c.lock.Unlock()
 
 	c.prevCfg = Config{}
-	cli := c.cli
-	c.cli = nil
-	if cli != nil {
-		cli.Shutdown()
+	c.shutdown()
+}
+
+// Pause stops consumers of the queue until Resume is called. Only channels of these consumers
+// are closed: in-flight messages are processed and unacknowledged prefetched ones are returned
+// to the queue, other consumers and publishers keep working. The pause is kept when the
+// configuration is upgraded or the session is reestablished.
+// Returns ErrConsumerNotFound if no consumer reads the queue.
+func (c *Client) Pause(queue string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.hasConsumer(queue) {
+		return errors.WithMessagef(ErrConsumerNotFound, "queue '%s'", queue)
+	}
+	c.paused[queue] = true
+	if c.consumers != nil {
+		c.consumers.pause(queue)
+	}
+	return nil
+}
+
+// Resume runs consumers of the queue paused by Pause on the current session.
+// Returns ErrConsumerNotFound if no consumer reads the queue.
+func (c *Client) Resume(queue string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.hasConsumer(queue) {
+		return errors.WithMessagef(ErrConsumerNotFound, "queue '%s'", queue)
 	}
+	delete(c.paused, queue)
+	if c.consumers == nil {
+		return nil
+	}
+	err := c.consumers.resume(queue)
+	if err != nil {
+		return errors.WithMessage(err, "resume consumers")
+	}
+	return nil
+}
+
+// Channel opens a new channel on the connection of the current session.
+// The channel is closed when the session is lost and must be closed by the caller.
+// It is used by rpc.Client to receive replies.
+func (c *Client) Channel() (*amqp091.Channel, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.getChannel()
 }
 
 // QueueInspect To get information about a queue named `name'. Provides data on the number of messages in the queue
@@ -219,11 +270,35 @@ func (c *Client) upgrade(ctx context.Context, config Config, justServe bool) err
 
 	c.logger.Debug(ctx, "rmq client: initialization began")
 
-	if c.cli != nil {
-		c.cli.Shutdown()
-		c.cli = nil
+	c.shutdown()
+
+	cli, consumers := c.newClient(ctx, config)
+
+	if justServe {
+		cli.Serve(ctx)
+	} else {
+		err := cli.Run(ctx)
+		if err == nil {
+			err = consumers.awaitReady(ctx)
+		}
+		if err != nil {
+			consumers.close()
+			cli.Shutdown()
+			return err
+		}
 	}
 
+	c.logger.Debug(ctx, "rmq client: initialization done")
+
+	c.cli = cli
+	c.consumers = consumers
+	c.prevCfg = config
+	return nil
+}
+
+// newClient creates a client for the configuration. Consumers are run by the consumer set
+// on each established session, consumers of paused queues are not run.
+func (c *Client) newClient(ctx context.Context, config Config) (*grmq.Client, *consumerSet) {
 	var observer grmq.Observer
 
 	observer = NewLogObserver(ctx, c.logger)
@@ -232,6 +307,12 @@ func (c *Client) upgrade(ctx context.Context, config Config, justServe bool) err
 		observer = config.NewObserver(ctx, c.logger)
 	}
 
+	consumers := newConsumerSet(config.Consumers, observer, c.paused)
+	sessionObserver := &sessionObserver{
+		Observer:  observer,
+		consumers: consumers,
+	}
+
 	cli := grmq.New(
 		config.Url,
 		grmq.WithDialConfig(grmq.DialConfig{
@@ -242,25 +323,35 @@ func (c *Client) upgrade(ctx context.Context, config Config, justServe bool) err
 			DialTimeout: DefaultDialTimeout,
 		}),
 		grmq.WithPublishers(config.Publishers...),
-		grmq.WithConsumers(config.Consumers...),
 		grmq.WithDeclarations(config.Declarations),
-		grmq.WithObserver(observer),
+		grmq.WithObserver(sessionObserver),
 	)
+	sessionObserver.connection = cli.UnsafeConnection
+	return cli, consumers
+}
 
-	if justServe {
-		cli.Serve(ctx)
-	} else {
-		err := cli.Run(ctx)
-		if err != nil {
-			return err
-		}
+// shutdown stops consumers and then the session of the current client.
+func (c *Client) shutdown() {
+	consumers := c.consumers
+	cli := c.cli
+	c.consumers = nil
+	c.cli = nil
+	if consumers != nil {
+		consumers.close()
 	}
+	if cli != nil {
+		cli.Shutdown()
+	}
+}
 
-	c.logger.Debug(ctx, "rmq client: initialization done")
-
-	c.cli = cli
-	c.prevCfg = config
-	return nil
+// hasConsumer reports whether any consumer of the current configuration reads the queue.
+func (c *Client) hasConsumer(queue string) bool {
+	for _, consumerCfg := range c.prevCfg.Consumers {
+		if consumerCfg.Queue == queue {
+			return true
+		}
+	}
+	return false
 }
 
 // queueInspect Using the transmitted channel "ch", it searches for a queue named "name" and returns it.
@@ -13,10 +13,12 @@ import (
 	"github.com/txix-open/grmq/retry"
 	"github.com/txix-open/grmq/topology"
 	"github.com/txix-open/isp-kit/grmqx/batch_handler"
+	"github.com/txix-open/isp-kit/grmqx/rpc"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/rabbitmq_metrics"
 	"github.com/txix-open/isp-kit/observability/tracing/rabbitmq/publisher_tracing"
+	"github.com/txix-open/isp-kit/ratelimit"
 )
 
 // Connection represents RabbitMQ connection parameters.
@@ -75,6 +77,41 @@ func (p Publisher) DefaultPublisher(restMiddlewares ...publisher.Middleware) *pu
 	)
 }
 
+// RpcClient represents RPC client configuration.
+type RpcClient struct {
+	Exchange            string `schema:"Точка обмена"`
+	RoutingKey          string `validate:"required" schema:"Ключ маршрутизации,для отправки запроса напрямую в очередь указывается название очереди"`
+	TimeoutMs           int    `schema:"Таймаут ожидания ответа,в миллисекундах, по умолчанию 15000"`
+	ExclusiveReplyQueue bool   `schema:"Эксклюзивная очередь ответов,по умолчанию ответы принимаются через direct reply-to"`
+}
+
+// DefaultRpcClient creates an RPC client with pre-configured middleware and settings:
+// - Request ID generation and header injection
+// - Publisher and call metrics, tracing integration
+//
+// The channels are usually provided by the Client the request queue is declared with.
+func (r RpcClient) DefaultRpcClient(channels rpc.ChannelProvider, restMiddlewares ...publisher.Middleware) *rpc.Client {
+	middlewares := append(
+		[]publisher.Middleware{
+			PublisherRequestId(),
+			PublisherMetrics(rabbitmq_metrics.NewPublisherStorage(metrics.DefaultRegistry)),
+			publisher_tracing.NewConfig().Middleware(),
+		},
+		restMiddlewares...,
+	)
+	opts := []rpc.Option{
+		rpc.WithMiddlewares(middlewares...),
+		rpc.WithMetrics(rabbitmq_metrics.NewRpcClientStorage(metrics.DefaultRegistry)),
+	}
+	if r.TimeoutMs > 0 {
+		opts = append(opts, rpc.WithTimeout(time.Duration(r.TimeoutMs)*time.Millisecond))
+	}
+	if r.ExclusiveReplyQueue {
+		opts = append(opts, rpc.WithExclusiveReplyQueue())
+	}
+	return rpc.New(channels, r.Exchange, r.RoutingKey, opts...)
+}
+
 // RetryConfig represents retry configuration for message processing.
 type RetryConfig struct {
 	DelayInMs   int `validate:"required" schema:"Задержка в миллисекундах"`
@@ -96,19 +133,20 @@ type Binding struct {
 
 // Consumer represents consumer configuration.
 type Consumer struct {
-	Queue              string         `validate:"required" schema:"Наименование очереди"`
-	Dlq                bool           `schema:"Создать очередь DLQ"`
-	PrefetchCount      int            `schema:"Количество предзагруженных сообщений,по умолчанию - 1"`
-	Concurrency        int            `schema:"Количество обработчиков,по умолчанию - 1, рекомендовано использовать значение = prefetchCount"`
-	DisableAutoDeclare bool           `schema:"Отключить автоматическое объявление,по умолчанию  exchange, queue и binding будут созданы автоматически"`
-	Binding            *Binding       `schema:"Настройки топологии"`
-	RetryPolicy        *RetryPolicy   `schema:"Политика повторной обработки"`
-	QueueArgs          map[string]any `schema:"Аргументы очереди"`
+	Queue              string            `validate:"required" schema:"Наименование очереди"`
+	Dlq                bool              `schema:"Создать очередь DLQ"`
+	PrefetchCount      int               `schema:"Количество предзагруженных сообщений,по умолчанию - 1"`
+	Concurrency        int               `schema:"Количество обработчиков,по умолчанию - 1, рекомендовано использовать значение = prefetchCount"`
+	DisableAutoDeclare bool              `schema:"Отключить автоматическое объявление,по умолчанию  exchange, queue и binding будут созданы автоматически"`
+	Binding            *Binding          `schema:"Настройки топологии"`
+	RetryPolicy        *RetryPolicy      `schema:"Политика повторной обработки"`
+	QueueArgs          map[string]any    `schema:"Аргументы очереди"`
+	RateLimit          *ratelimit.Config `schema:"Ограничение скорости обработки сообщений,по умолчанию не ограничена"`
 }
 
 // DefaultConsumer creates a consumer with the specified handler and default settings.
 // PrefetchCount and Concurrency default to 1 if not set or less than 1.
-// Applies ConsumerRequestId middleware by default.
+// Applies ConsumerRequestId middleware by default and ConsumerRateLimit if RateLimit is set.
 func (c Consumer) DefaultConsumer(handler consumer.Handler, restMiddlewares ...consumer.Middleware) consumer.Consumer {
 	prefetchCount := c.PrefetchCount
 	if prefetchCount <= 0 {
@@ -118,12 +156,13 @@ func (c Consumer) DefaultConsumer(handler consumer.Handler, restMiddlewares ...c
 	if concurrency <= 0 {
 		concurrency = 1
 	}
-	middlewares := append(
-		[]consumer.Middleware{
-			ConsumerRequestId(),
-		},
-		restMiddlewares...,
-	)
+	middlewares := []consumer.Middleware{
+		ConsumerRequestId(),
+	}
+	if c.RateLimit != nil {
+		middlewares = append(middlewares, ConsumerRateLimit(c.RateLimit.Limiter()))
+	}
+	middlewares = append(middlewares, restMiddlewares...)
 	opts := []consumer.Option{
 		consumer.WithPrefetchCount(prefetchCount),
 		consumer.WithConcurrency(concurrency),
@@ -142,14 +181,15 @@ func (c Consumer) DefaultConsumer(handler consumer.Handler, restMiddlewares ...c
 
 // BatchConsumer represents batch consumer configuration.
 type BatchConsumer struct {
-	Queue              string         `validate:"required" schema:"Наименование очереди"`
-	Dlq                bool           `schema:"Создать очередь DLQ"`
-	BatchSize          int            `validate:"required" schema:"Количество сообщений в пачке"`
-	PurgeIntervalInMs  int            `validate:"required" schema:"Интервал обработки"`
-	DisableAutoDeclare bool           `schema:"Отключить автоматическое объявление,по умолчанию  exchange, queue и binding будут созданы автоматически"`
-	Binding            *Binding       `schema:"Настройки топологии"`
-	RetryPolicy        *RetryPolicy   `schema:"Политика повторной обработки"`
-	QueueArgs          map[string]any `schema:"Аргументы очереди"`
+	Queue              string            `validate:"required" schema:"Наименование очереди"`
+	Dlq                bool              `schema:"Создать очередь DLQ"`
+	BatchSize          int               `validate:"required" schema:"Количество сообщений в пачке"`
+	PurgeIntervalInMs  int               `validate:"required" schema:"Интервал обработки"`
+	DisableAutoDeclare bool              `schema:"Отключить автоматическое объявление,по умолчанию  exchange, queue и binding будут созданы автоматически"`
+	Binding            *Binding          `schema:"Настройки топологии"`
+	RetryPolicy        *RetryPolicy      `schema:"Политика повторной обработки"`
+	QueueArgs          map[string]any    `schema:"Аргументы очереди"`
+	RateLimit          *ratelimit.Config `schema:"Ограничение скорости обработки сообщений,по умолчанию не ограничена"`
 }
 
 // ConsumerConfig converts BatchConsumer to a standard Consumer configuration.
@@ -164,6 +204,7 @@ func (b BatchConsumer) ConsumerConfig() Consumer {
 		Binding:            b.Binding,
 		RetryPolicy:        b.RetryPolicy,
 		QueueArgs:          b.QueueArgs,
+		RateLimit:          b.RateLimit,
 	}
 }
 
@@ -0,0 +1,242 @@
+package grmqx
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/txix-open/grmq"
+	"github.com/txix-open/grmq/consumer"
+	"github.com/txix-open/grmq/publisher"
+)
+
+// consumerSet runs consumers of the configuration on the connection of the current session,
+// so consumers of a single queue can be stopped and started again without restarting the session.
+// Consumers are started each time a session is established.
+type consumerSet struct {
+	consumers []consumer.Consumer
+	observer  grmq.Observer
+	paused    map[string]bool
+
+	lock    sync.Locker
+	conn    *amqp091.Connection
+	running map[int]*runningConsumer
+	closed  bool
+	ready   chan error
+}
+
+// runningConsumer is a consumer running on a channel of the current session.
+type runningConsumer struct {
+	cfg      consumer.Consumer
+	unit     *grmq.Consumer
+	retryPub *grmq.Publisher
+}
+
+func newConsumerSet(consumers []consumer.Consumer, observer grmq.Observer, paused map[string]bool) *consumerSet {
+	pausedCopy := make(map[string]bool, len(paused))
+	for queue := range paused {
+		pausedCopy[queue] = true
+	}
+	return &consumerSet{
+		consumers: consumers,
+		observer:  observer,
+		paused:    pausedCopy,
+		lock:      &sync.Mutex{},
+		running:   make(map[int]*runningConsumer),
+		ready:     make(chan error, 1),
+	}
+}
+
+// start runs consumers of queues which are not paused on the connection of a new session.
+// Consumers of the previous session are dropped, as they are stopped along with its connection.
+// If a consumer can not be run, the connection is closed, so the session is established again.
+func (s *consumerSet) start(conn *amqp091.Connection) (err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	defer func() {
+		select {
+		case s.ready <- err:
+		default:
+		}
+	}()
+
+	if s.closed {
+		return errors.New("client is closed")
+	}
+	if conn == nil {
+		return errors.New("rabbit mq is not connected")
+	}
+
+	s.conn = conn
+	s.running = make(map[int]*runningConsumer)
+	err = s.runAll(func(cfg consumer.Consumer) bool {
+		return !s.paused[cfg.Queue]
+	})
+	if err != nil {
+		s.stopAll()
+		_ = conn.Close()
+		return err
+	}
+	return nil
+}
+
+// awaitReady waits for the result of starting consumers on the first session.
+func (s *consumerSet) awaitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-s.ready:
+		return err
+	}
+}
+
+// pause stops consumers of the queue. In-flight messages are processed, unacknowledged
+// prefetched ones are returned to the queue by the broker.
+func (s *consumerSet) pause(queue string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.paused[queue] = true
+	for i, running := range s.running {
+		if running.cfg.Queue == queue {
+			delete(s.running, i)
+			s.stop(running)
+		}
+	}
+}
+
+// resume runs consumers of the queue paused by pause if a session is established.
+// Otherwise, they are run with the next session.
+func (s *consumerSet) resume(queue string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.paused, queue)
+	if s.closed || s.conn == nil || s.conn.IsClosed() {
+		return nil
+	}
+	return s.runAll(func(cfg consumer.Consumer) bool {
+		return cfg.Queue == queue
+	})
+}
+
+// close stops all consumers and releases resources of their handlers.
+func (s *consumerSet) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+
+	for _, cfg := range s.consumers {
+		if cfg.Closer != nil {
+			cfg.Closer.Close()
+		}
+	}
+	s.stopAll()
+}
+
+// runAll runs consumers matching the filter which are not running yet. The lock must be held.
+func (s *consumerSet) runAll(filter func(cfg consumer.Consumer) bool) error {
+	for i, cfg := range s.consumers {
+		_, isRunning := s.running[i]
+		if isRunning || !filter(cfg) {
+			continue
+		}
+		running, err := s.run(cfg)
+		if err != nil {
+			return errors.WithMessagef(err, "run consumer '%s'", cfg.Queue)
+		}
+		s.running[i] = running
+	}
+	return nil
+}
+
+// run runs the consumer on a new channel of the current connection. The lock must be held.
+// The closer of the consumer is called only on close, so its handler survives pauses and reconnections.
+func (s *consumerSet) run(cfg consumer.Consumer) (*runningConsumer, error) {
+	unitCfg := cfg
+	unitCfg.Closer = nil
+
+	var retryPub *grmq.Publisher
+	if cfg.RetryPolicy != nil {
+		ch, err := s.conn.Channel()
+		if err != nil {
+			return nil, errors.WithMessage(err, "create channel for retry publisher")
+		}
+		retryPub = grmq.NewPublisher(publisher.New("", ""), ch, s.observer, s.conn)
+		err = retryPub.Run()
+		if err != nil {
+			_ = retryPub.Close()
+			return nil, errors.WithMessage(err, "run retry publisher")
+		}
+	}
+
+	ch, err := s.conn.Channel()
+	if err != nil {
+		if retryPub != nil {
+			_ = retryPub.Close()
+		}
+		return nil, errors.WithMessage(err, "create channel")
+	}
+	unit := grmq.NewConsumer(unitCfg, ch, retryPub, s.observer)
+	err = unit.Run()
+	if err != nil {
+		_ = ch.Close()
+		if retryPub != nil {
+			_ = retryPub.Close()
+		}
+		return nil, err
+	}
+
+	return &runningConsumer{
+		cfg:      cfg,
+		unit:     unit,
+		retryPub: retryPub,
+	}, nil
+}
+
+// stopAll stops all running consumers. The lock must be held.
+func (s *consumerSet) stopAll() {
+	for i, running := range s.running {
+		delete(s.running, i)
+		s.stop(running)
+	}
+}
+
+// stop cancels the consumer and waits for its in-flight messages. The lock must be held.
+// Consumers of a closed connection are already stopped.
+func (s *consumerSet) stop(running *runningConsumer) {
+	if s.conn == nil || s.conn.IsClosed() {
+		return
+	}
+	err := running.unit.Close()
+	if err != nil {
+		s.observer.ConsumerError(running.cfg, errors.WithMessage(err, "close consumer"))
+	}
+	if running.retryPub != nil {
+		_ = running.retryPub.Close()
+	}
+}
+
+// sessionObserver starts consumers of the set when a session is established.
+type sessionObserver struct {
+	grmq.Observer
+
+	consumers  *consumerSet
+	connection func() *amqp091.Connection
+}
+
+// ClientReady starts consumers on the connection of the established session.
+func (o sessionObserver) ClientReady() {
+	err := o.consumers.start(o.connection())
+	if err != nil {
+		o.Observer.ClientError(errors.WithMessage(err, "run consumers"))
+		return
+	}
+	o.Observer.ClientReady()
+}
@@ -303,3 +303,76 @@ func TestQueuesDeleteWithInspect(t *testing.T) {
 	require.Error(results[""])
 	require.NoError(results[existingQueue])
 }
+
+func TestPauseResume(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	newConsumer := func(queue string, received chan<- string) (grmqx.Consumer, consumer.Consumer) {
+		consumerCfg := grmqx.Consumer{
+			Queue: queue,
+		}
+		handler := grmqx.NewResultHandler(
+			test.Logger(),
+			handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+				received <- queue
+				return handler.Ack()
+			}),
+		)
+		return consumerCfg, consumerCfg.DefaultConsumer(handler)
+	}
+	received := make(chan string, 10)
+	pausedCfg, pausedConsumer := newConsumer("test_pause_paused", received)
+	activeCfg, activeConsumer := newConsumer("test_pause_active", received)
+	pub := grmqx.Publisher{}.DefaultPublisher()
+
+	testCli := grmqt.New(test)
+	cli := grmqx.New(test.Logger())
+	t.Cleanup(func() {
+		cli.Close()
+	})
+	err := cli.Upgrade(t.Context(), grmqx.NewConfig(
+		testCli.ConnectionConfig().Url(),
+		grmqx.WithPublishers(pub),
+		grmqx.WithConsumers(pausedConsumer, activeConsumer),
+		grmqx.WithDeclarations(grmqx.TopologyFromConsumers(pausedCfg, activeCfg)),
+	))
+	require.NoError(err)
+
+	err = cli.Pause("unknown")
+	require.ErrorIs(err, grmqx.ErrConsumerNotFound)
+	err = cli.Resume("unknown")
+	require.ErrorIs(err, grmqx.ErrConsumerNotFound)
+
+	err = cli.Pause(pausedCfg.Queue)
+	require.NoError(err)
+	queue, err := cli.QueueInspect(pausedCfg.Queue)
+	require.NoError(err)
+	require.EqualValues(0, queue.Consumers)
+
+	err = pub.PublishTo(t.Context(), "", pausedCfg.Queue, &amqp091.Publishing{})
+	require.NoError(err)
+	err = pub.PublishTo(t.Context(), "", activeCfg.Queue, &amqp091.Publishing{})
+	require.NoError(err)
+
+	select {
+	case queue := <-received:
+		require.EqualValues(activeCfg.Queue, queue)
+	case <-time.After(5 * time.Second):
+		require.Fail("consumer of the active queue wasn't called")
+	}
+	select {
+	case queue := <-received:
+		require.Fail("consumer of the paused queue was called", queue)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	err = cli.Resume(pausedCfg.Queue)
+	require.NoError(err)
+	select {
+	case queue := <-received:
+		require.EqualValues(pausedCfg.Queue, queue)
+	case <-time.After(5 * time.Second):
+		require.Fail("consumer of the resumed queue wasn't called")
+	}
+}
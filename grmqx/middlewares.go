@@ -9,6 +9,7 @@ import (
 	"github.com/txix-open/grmq/publisher"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/requestid"
+	"golang.org/x/time/rate"
 )
 
 // PublisherLog creates a publisher middleware that logs published messages.
@@ -141,3 +142,18 @@ func ConsumerRequestId() consumer.Middleware {
 		})
 	}
 }
+
+// ConsumerRateLimit creates a consumer middleware that limits the rate of message processing
+// with the token bucket limiter. If a token can not be obtained, the message is requeued.
+func ConsumerRateLimit(limiter *rate.Limiter) consumer.Middleware {
+	return func(next consumer.Handler) consumer.Handler {
+		return consumer.HandlerFunc(func(ctx context.Context, delivery *consumer.Delivery) {
+			err := limiter.Wait(ctx)
+			if err != nil {
+				_ = delivery.Nack(true)
+				return
+			}
+			next.Handle(ctx, delivery)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Package rpc provides the request-reply pattern over RabbitMQ.
+// Client publishes requests with ReplyTo and CorrelationId properties and waits for replies
+// on the direct reply-to pseudo queue or on an exclusive callback queue.
+// Handler turns a typed function into a handler.SyncHandlerAdapter which publishes replies.
+package rpc
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/txix-open/grmq/publisher"
+	"github.com/txix-open/isp-kit/requestid"
+)
+
+const (
+	// DirectReplyTo is the pseudo queue used by RabbitMQ for direct replies.
+	DirectReplyTo = "amq.rabbitmq.reply-to"
+	// HeaderError is the header with the error message of a failed request.
+	HeaderError = "x-rpc-error"
+
+	defaultTimeout = 15 * time.Second
+)
+
+var (
+	// ErrTimeout is returned when no reply is received within the timeout.
+	ErrTimeout = errors.New("rpc reply timeout")
+	// ErrClientClosed is returned when the client is closed.
+	ErrClientClosed = errors.New("rpc client is closed")
+	// ErrReplyChannelClosed is returned when the channel is closed while waiting for the reply.
+	ErrReplyChannelClosed = errors.New("reply channel is closed")
+)
+
+// RemoteError is returned when the server replied with an error.
+type RemoteError struct {
+	Message string
+}
+
+// Error returns the error message of the server.
+func (e RemoteError) Error() string {
+	return "rpc server error: " + e.Message
+}
+
+// ChannelProvider opens channels on the current broker connection.
+// It is implemented by grmqx.Client.
+type ChannelProvider interface {
+	Channel() (*amqp091.Channel, error)
+}
+
+// MetricStorage defines an interface for RPC client metrics storage.
+type MetricStorage interface {
+	ObserveCallDuration(exchange string, routingKey string, t time.Duration)
+	IncCallError(exchange string, routingKey string)
+	IncCallTimeout(exchange string, routingKey string)
+}
+
+// Client publishes requests and waits for replies.
+// A channel for replies is opened on the first call and reopened after the connection is lost,
+// calls waiting for replies on the lost channel fail with ErrReplyChannelClosed.
+//
+// Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	Exchange   string
+	RoutingKey string
+
+	channels       ChannelProvider
+	timeout        time.Duration
+	exclusiveQueue bool
+	metrics        MetricStorage
+	middlewares    []publisher.Middleware
+	roundTripper   publisher.RoundTripper
+
+	lock    sync.Locker
+	session *session
+	closed  bool
+}
+
+// New creates a new RPC client sending requests to the exchange with the routing key.
+//
+// Example:
+//
+//	cli := rpc.New(rmqCli, "", "calculator", rpc.WithTimeout(5*time.Second))
+//	reply, err := cli.Call(ctx, &amqp091.Publishing{Body: []byte(`{"a":1,"b":2}`)})
+func New(channels ChannelProvider, exchange string, routingKey string, opts ...Option) *Client {
+	c := &Client{
+		Exchange:   exchange,
+		RoutingKey: routingKey,
+		channels:   channels,
+		timeout:    defaultTimeout,
+		lock:       &sync.Mutex{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	var roundTripper publisher.RoundTripper = publisher.RoundTripperFunc(c.publish)
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		roundTripper = c.middlewares[i](roundTripper)
+	}
+	c.roundTripper = roundTripper
+	return c
+}
+
+// Call publishes the request to the exchange and routing key of the client and waits for the reply.
+func (c *Client) Call(ctx context.Context, msg *amqp091.Publishing) (*amqp091.Delivery, error) {
+	return c.CallTo(ctx, c.Exchange, c.RoutingKey, msg)
+}
+
+// CallTo publishes the request to the exchange with the routing key and waits for the reply.
+// ReplyTo and CorrelationId properties are set on a copy of the message, the message of the caller
+// is not modified. The CorrelationId is generated if it is empty. If the message has no expiration, it expires after the timeout.
+// Returns ErrTimeout if no reply is received within the timeout and RemoteError if
+// the server replied with an error.
+func (c *Client) CallTo(ctx context.Context, exchange string, routingKey string, msg *amqp091.Publishing) (*amqp091.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	reply, err := c.call(ctx, exchange, routingKey, msg)
+	if c.metrics != nil {
+		c.metrics.ObserveCallDuration(exchange, routingKey, time.Since(start))
+		switch {
+		case errors.Is(err, ErrTimeout):
+			c.metrics.IncCallTimeout(exchange, routingKey)
+		case err != nil:
+			c.metrics.IncCallError(exchange, routingKey)
+		}
+	}
+	return reply, err
+}
+
+// Close closes the reply channel. Calls waiting for replies fail with ErrReplyChannelClosed.
+func (c *Client) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.closed = true
+	if c.session == nil {
+		return nil
+	}
+	err := c.session.ch.Close()
+	c.session = nil
+	if err != nil && !errors.Is(err, amqp091.ErrClosed) {
+		return errors.WithMessage(err, "close channel")
+	}
+	return nil
+}
+
+func (c *Client) call(ctx context.Context, exchange string, routingKey string, msg *amqp091.Publishing) (*amqp091.Delivery, error) {
+	s, err := c.getSession()
+	if err != nil {
+		return nil, err
+	}
+
+	msg = copyPublishing(msg)
+	if msg.CorrelationId == "" {
+		msg.CorrelationId = requestid.Next()
+	}
+	if msg.Expiration == "" {
+		msg.Expiration = strconv.FormatInt(c.timeout.Milliseconds(), 10)
+	}
+	msg.ReplyTo = s.replyTo
+
+	replies := s.register(msg.CorrelationId)
+	defer s.unregister(msg.CorrelationId)
+
+	err = c.roundTripper.Publish(withSession(ctx, s), exchange, routingKey, msg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "publish request")
+	}
+
+	select {
+	case reply, isOpen := <-replies:
+		if !isOpen {
+			return nil, ErrReplyChannelClosed
+		}
+		errMessage, ok := reply.Headers[HeaderError].(string)
+		if ok {
+			return nil, RemoteError{Message: errMessage}
+		}
+		return &reply, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// copyPublishing returns a copy of the message with its own headers,
+// so the client and middlewares do not modify the message of the caller.
+func copyPublishing(msg *amqp091.Publishing) *amqp091.Publishing {
+	msgCopy := *msg
+	msgCopy.Headers = maps.Clone(msg.Headers)
+	return &msgCopy
+}
+
+// publish is the root round tripper publishing the request on the channel of the session.
+func (c *Client) publish(ctx context.Context, exchange string, routingKey string, msg *amqp091.Publishing) error {
+	s := sessionFromContext(ctx)
+	return s.ch.PublishWithContext(ctx, exchange, routingKey, false, false, *msg)
+}
+
+// getSession returns the current session or opens a new one if the channel is closed.
+func (c *Client) getSession() (*session, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	if c.session != nil && !c.session.ch.IsClosed() {
+		return c.session, nil
+	}
+
+	ch, err := c.channels.Channel()
+	if err != nil {
+		return nil, errors.WithMessage(err, "open channel")
+	}
+	s, err := newSession(ch, c.exclusiveQueue)
+	if err != nil {
+		_ = ch.Close()
+		return nil, err
+	}
+	c.session = s
+	return s, nil
+}
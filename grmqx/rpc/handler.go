@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/txix-open/grmq/consumer"
+	"github.com/txix-open/isp-kit/grmqx/handler"
+	"github.com/txix-open/isp-kit/json"
+)
+
+const (
+	contentTypeJson = "application/json"
+)
+
+// Replier publishes replies to the default exchange. It is implemented by *publisher.Publisher.
+type Replier interface {
+	PublishTo(ctx context.Context, exchange string, routingKey string, msg *amqp091.Publishing) error
+}
+
+// HandlerFunc handles a decoded request and returns the response.
+type HandlerFunc[Req any, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// Handler decodes JSON requests, calls the handler function and publishes JSON replies
+// to the ReplyTo queue of the request with its CorrelationId.
+// Errors of decoding and of the handler function are replied to the client in the HeaderError header
+// and the request is acknowledged. If the reply can not be published, the request is retried.
+// Requests without ReplyTo are moved to DLQ.
+//
+// Handler implements handler.SyncHandlerAdapter.
+type Handler[Req any, Resp any] struct {
+	replier Replier
+	handler HandlerFunc[Req, Resp]
+}
+
+// NewHandler creates a new Handler publishing replies with the replier.
+// The replier should use the default exchange, e.g. grmqx.Publisher{}.DefaultPublisher(),
+// and must be added to the client configuration.
+//
+// Example:
+//
+//	replier := grmqx.Publisher{}.DefaultPublisher()
+//	adapter := rpc.NewHandler(replier, func(ctx context.Context, req SumRequest) (SumResponse, error) {
+//		return SumResponse{Sum: req.A + req.B}, nil
+//	})
+//	consumer := consumerCfg.DefaultConsumer(grmqx.NewResultHandler(logger, adapter))
+func NewHandler[Req any, Resp any](replier Replier, handler HandlerFunc[Req, Resp]) Handler[Req, Resp] {
+	return Handler[Req, Resp]{
+		replier: replier,
+		handler: handler,
+	}
+}
+
+// Handle processes the request and publishes the reply.
+func (h Handler[Req, Resp]) Handle(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+	source := delivery.Source()
+	if source.ReplyTo == "" {
+		return handler.MoveToDlq(errors.New("reply to is not specified"))
+	}
+
+	var req Req
+	err := json.Unmarshal(source.Body, &req)
+	if err != nil {
+		return h.replyError(ctx, source, errors.WithMessage(err, "unmarshal request"))
+	}
+
+	resp, err := h.handler(ctx, req)
+	if err != nil {
+		return h.replyError(ctx, source, err)
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return h.replyError(ctx, source, errors.WithMessage(err, "marshal response"))
+	}
+	return h.reply(ctx, source, &amqp091.Publishing{
+		ContentType: contentTypeJson,
+		Body:        body,
+	})
+}
+
+// replyError publishes the error to the client.
+func (h Handler[Req, Resp]) replyError(ctx context.Context, source *amqp091.Delivery, err error) handler.Result {
+	result := h.reply(ctx, source, &amqp091.Publishing{
+		Headers: amqp091.Table{
+			HeaderError: err.Error(),
+		},
+	})
+	if result.Ack {
+		result.Err = err
+	}
+	return result
+}
+
+// reply publishes the reply to the ReplyTo queue of the request.
+func (h Handler[Req, Resp]) reply(ctx context.Context, source *amqp091.Delivery, msg *amqp091.Publishing) handler.Result {
+	msg.CorrelationId = source.CorrelationId
+	err := h.replier.PublishTo(ctx, "", source.ReplyTo, msg)
+	if err != nil {
+		return handler.Retry(errors.WithMessage(err, "publish reply"))
+	}
+	return handler.Ack()
+}
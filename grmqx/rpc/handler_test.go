@@ -0,0 +1,67 @@
+package rpc_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/grmq/consumer"
+	"github.com/txix-open/isp-kit/grmqx/rpc"
+)
+
+type replierMock struct {
+	err        error
+	routingKey string
+	msg        *amqp091.Publishing
+}
+
+func (r *replierMock) PublishTo(ctx context.Context, exchange string, routingKey string, msg *amqp091.Publishing) error {
+	r.routingKey = routingKey
+	r.msg = msg
+	return r.err
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	replier := &replierMock{}
+	h := rpc.NewHandler(replier, func(ctx context.Context, req int) (int, error) {
+		if req < 0 {
+			return 0, errors.New("negative value")
+		}
+		return req * 2, nil
+	})
+	newDelivery := func(body string, replyTo string) *consumer.Delivery {
+		return consumer.NewDelivery(&sync.WaitGroup{}, &amqp091.Delivery{
+			Body:          []byte(body),
+			ReplyTo:       replyTo,
+			CorrelationId: "correlation",
+		}, nil)
+	}
+
+	result := h.Handle(t.Context(), newDelivery("21", "reply"))
+	require.True(result.Ack)
+	require.EqualValues("reply", replier.routingKey)
+	require.EqualValues("correlation", replier.msg.CorrelationId)
+	require.EqualValues("42", string(replier.msg.Body))
+
+	result = h.Handle(t.Context(), newDelivery("-1", "reply"))
+	require.True(result.Ack)
+	require.EqualValues("negative value", replier.msg.Headers[rpc.HeaderError])
+
+	result = h.Handle(t.Context(), newDelivery("not a number", "reply"))
+	require.True(result.Ack)
+	require.Contains(replier.msg.Headers[rpc.HeaderError], "unmarshal request")
+
+	result = h.Handle(t.Context(), newDelivery("21", ""))
+	require.True(result.MoveToDlq)
+
+	replier.err = errors.New("connection closed")
+	result = h.Handle(t.Context(), newDelivery("21", "reply"))
+	require.True(result.Retry)
+	require.ErrorIs(result.Err, replier.err)
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"time"
+
+	"github.com/txix-open/grmq/publisher"
+)
+
+// Option is a function that configures the Client.
+type Option func(c *Client)
+
+// WithTimeout sets the maximum time to wait for a reply. Default is 15 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithExclusiveReplyQueue makes the client receive replies on a server-named exclusive queue
+// instead of the direct reply-to pseudo queue.
+func WithExclusiveReplyQueue() Option {
+	return func(c *Client) {
+		c.exclusiveQueue = true
+	}
+}
+
+// WithMiddlewares sets publisher middlewares applied to requests.
+func WithMiddlewares(middlewares ...publisher.Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = middlewares
+	}
+}
+
+// WithMetrics sets the storage for call metrics.
+func WithMetrics(storage MetricStorage) Option {
+	return func(c *Client) {
+		c.metrics = storage
+	}
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+type sessionKey struct{}
+
+// session is a channel consuming replies and the calls waiting for them.
+type session struct {
+	ch      *amqp091.Channel
+	replyTo string
+	lock    sync.Locker
+	pending map[string]chan amqp091.Delivery
+	closed  bool
+}
+
+// newSession starts consuming replies on the channel from the direct reply-to pseudo queue
+// or from a new exclusive queue.
+func newSession(ch *amqp091.Channel, exclusiveQueue bool) (*session, error) {
+	replyTo := DirectReplyTo
+	if exclusiveQueue {
+		queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+		if err != nil {
+			return nil, errors.WithMessage(err, "declare reply queue")
+		}
+		replyTo = queue.Name
+	}
+
+	deliveries, err := ch.Consume(replyTo, "", true, true, false, false, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "consume replies")
+	}
+
+	s := &session{
+		ch:      ch,
+		replyTo: replyTo,
+		lock:    &sync.Mutex{},
+		pending: make(map[string]chan amqp091.Delivery),
+	}
+	go s.dispatch(deliveries)
+	return s, nil
+}
+
+// register adds a call waiting for the reply with the correlation id.
+func (s *session) register(correlationId string) <-chan amqp091.Delivery {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	replies := make(chan amqp091.Delivery, 1)
+	if s.closed {
+		close(replies)
+		return replies
+	}
+	s.pending[correlationId] = replies
+	return replies
+}
+
+// unregister removes the call waiting for the reply with the correlation id.
+func (s *session) unregister(correlationId string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pending, correlationId)
+}
+
+// dispatch passes replies to the waiting calls until the channel is closed.
+// Replies without a waiting call, e.g. received after the timeout, are dropped.
+func (s *session) dispatch(deliveries <-chan amqp091.Delivery) {
+	for delivery := range deliveries {
+		s.lock.Lock()
+		replies, ok := s.pending[delivery.CorrelationId]
+		if ok {
+			delete(s.pending, delivery.CorrelationId)
+			replies <- delivery
+		}
+		s.lock.Unlock()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	for correlationId, replies := range s.pending {
+		delete(s.pending, correlationId)
+		close(replies)
+	}
+}
+
+func withSession(ctx context.Context, s *session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, s)
+}
+
+func sessionFromContext(ctx context.Context) *session {
+	s, _ := ctx.Value(sessionKey{}).(*session)
+	return s
+}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/txix-open/isp-kit/json"
+)
+
+// TypedClient sends JSON requests of type Req and decodes JSON replies of type Resp.
+type TypedClient[Req any, Resp any] struct {
+	client *Client
+}
+
+// NewTypedClient creates a new TypedClient on top of the client.
+//
+// Example:
+//
+//	sum := rpc.NewTypedClient[SumRequest, SumResponse](cli)
+//	resp, err := sum.Call(ctx, SumRequest{A: 1, B: 2})
+func NewTypedClient[Req any, Resp any](client *Client) TypedClient[Req, Resp] {
+	return TypedClient[Req, Resp]{
+		client: client,
+	}
+}
+
+// Call encodes the request, sends it and decodes the reply.
+func (c TypedClient[Req, Resp]) Call(ctx context.Context, req Req) (Resp, error) {
+	var resp Resp
+	body, err := json.Marshal(req)
+	if err != nil {
+		return resp, errors.WithMessage(err, "marshal request")
+	}
+
+	reply, err := c.client.Call(ctx, &amqp091.Publishing{
+		ContentType: contentTypeJson,
+		Body:        body,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	err = json.Unmarshal(reply.Body, &resp)
+	if err != nil {
+		return resp, errors.WithMessage(err, "unmarshal response")
+	}
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+package grmqx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/grmqx"
+	"github.com/txix-open/isp-kit/grmqx/rpc"
+	"github.com/txix-open/isp-kit/requestid"
+	"github.com/txix-open/isp-kit/test"
+	"github.com/txix-open/isp-kit/test/grmqt"
+)
+
+type sumRequest struct {
+	A int
+	B int
+}
+
+type sumResponse struct {
+	Sum       int
+	RequestId string
+}
+
+func TestRpc(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	replier := grmqx.Publisher{}.DefaultPublisher()
+	adapter := rpc.NewHandler(replier, func(ctx context.Context, req sumRequest) (sumResponse, error) {
+		if req.A < 0 {
+			return sumResponse{}, errors.New("negative value")
+		}
+		return sumResponse{Sum: req.A + req.B, RequestId: requestid.FromContext(ctx)}, nil
+	})
+	consumerCfg := grmqx.Consumer{
+		Queue: "rpc_sum",
+	}
+	consumer := consumerCfg.DefaultConsumer(grmqx.NewResultHandler(test.Logger(), adapter))
+
+	testCli := grmqt.New(test)
+	cli := grmqx.New(test.Logger())
+	t.Cleanup(func() {
+		cli.Close()
+	})
+	err := cli.Upgrade(t.Context(), grmqx.NewConfig(
+		testCli.ConnectionConfig().Url(),
+		grmqx.WithPublishers(replier),
+		grmqx.WithConsumers(consumer),
+		grmqx.WithDeclarations(grmqx.TopologyFromConsumers(consumerCfg)),
+	))
+	require.NoError(err)
+
+	for _, exclusive := range []bool{false, true} {
+		rpcCli := grmqx.RpcClient{
+			RoutingKey:          "rpc_sum",
+			ExclusiveReplyQueue: exclusive,
+		}.DefaultRpcClient(cli)
+		sum := rpc.NewTypedClient[sumRequest, sumResponse](rpcCli)
+
+		expectedRequestId := requestid.Next()
+		ctx := requestid.ToContext(t.Context(), expectedRequestId)
+		resp, err := sum.Call(ctx, sumRequest{A: 1, B: 2})
+		require.NoError(err)
+		require.EqualValues(sumResponse{Sum: 3, RequestId: expectedRequestId}, resp)
+
+		_, err = sum.Call(t.Context(), sumRequest{A: -1, B: 2})
+		remoteErr := rpc.RemoteError{}
+		require.ErrorAs(err, &remoteErr)
+		require.EqualValues("negative value", remoteErr.Message)
+
+		require.NoError(rpcCli.Close())
+		_, err = sum.Call(t.Context(), sumRequest{A: 1, B: 2})
+		require.ErrorIs(err, rpc.ErrClientClosed)
+	}
+
+	rpcCli := grmqx.RpcClient{
+		RoutingKey: "rpc_unknown",
+		TimeoutMs:  300,
+	}.DefaultRpcClient(cli)
+	t.Cleanup(func() {
+		_ = rpcCli.Close()
+	})
+	start := time.Now()
+	_, err = rpc.NewTypedClient[sumRequest, sumResponse](rpcCli).Call(t.Context(), sumRequest{})
+	require.ErrorIs(err, rpc.ErrTimeout)
+	require.Less(time.Since(start), 2*time.Second)
+}
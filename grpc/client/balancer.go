@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/lb"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/serviceconfig"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// balancerName is the name of the gRPC load balancing policy delegating to lb.Balancer.
+	balancerName = "isp_balancer"
+)
+
+// nolint:gochecknoglobals
+var (
+	balancers      = sync.Map{}
+	nextBalancerId = atomic.Int64{}
+)
+
+// nolint:gochecknoinits
+func init() {
+	balancer.Register(balancerBuilder{})
+}
+
+// balancerConfig is the load balancing config referencing the lb.Balancer of a client.
+type balancerConfig struct {
+	serviceconfig.LoadBalancingConfig `json:"-"`
+
+	Id int64 `json:"id"`
+}
+
+// registerBalancer stores the lb.Balancer of a client and returns the service config selecting it.
+func registerBalancer(hostManager lb.Balancer) (int64, string) {
+	id := nextBalancerId.Add(1)
+	balancers.Store(id, hostManager)
+	return id, fmt.Sprintf(`{"loadBalancingConfig": [{"%s": {"id": %d}}]}`, balancerName, id)
+}
+
+// unregisterBalancer removes the lb.Balancer of a closed client.
+func unregisterBalancer(id int64) {
+	balancers.Delete(id)
+}
+
+// balancerBuilder builds gRPC balancers delegating the choice of a host to lb.Balancer.
+type balancerBuilder struct{}
+
+func (balancerBuilder) Name() string {
+	return balancerName
+}
+
+func (balancerBuilder) ParseConfig(data json.RawMessage) (serviceconfig.LoadBalancingConfig, error) {
+	cfg := &balancerConfig{}
+	err := json.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unmarshal balancer config")
+	}
+	return cfg, nil
+}
+
+func (balancerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pickerBuilder := &pickerBuilder{}
+	return &hostManagerBalancer{
+		Balancer:      base.NewBalancerBuilder(balancerName, pickerBuilder, base.Config{}).Build(cc, opts),
+		pickerBuilder: pickerBuilder,
+	}
+}
+
+// hostManagerBalancer is the base gRPC balancer with a picker using lb.Balancer.
+type hostManagerBalancer struct {
+	balancer.Balancer
+
+	pickerBuilder *pickerBuilder
+}
+
+func (b *hostManagerBalancer) UpdateClientConnState(state balancer.ClientConnState) error {
+	cfg, ok := state.BalancerConfig.(*balancerConfig)
+	if ok {
+		value, ok := balancers.Load(cfg.Id)
+		if ok {
+			b.pickerBuilder.hostManager.Store(value.(lb.Balancer)) // nolint:forcetypeassert
+		}
+	}
+	return b.Balancer.UpdateClientConnState(state)
+}
+
+// pickerBuilder builds pickers over the ready connections.
+type pickerBuilder struct {
+	hostManager atomic.Value
+}
+
+func (b *pickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	hostManager, ok := b.hostManager.Load().(lb.Balancer)
+	if !ok || len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	subConns := make(map[string]balancer.SubConn, len(info.ReadySCs))
+	hosts := make([]string, 0, len(info.ReadySCs))
+	for subConn, subConnInfo := range info.ReadySCs {
+		subConns[subConnInfo.Address.Addr] = subConn
+		hosts = append(hosts, subConnInfo.Address.Addr)
+	}
+	hostManager.Upgrade(hosts)
+
+	return &picker{
+		hostManager: hostManager,
+		subConns:    subConns,
+	}
+}
+
+// picker picks the connection to the host acquired from lb.Balancer.
+type picker struct {
+	hostManager lb.Balancer
+	subConns    map[string]balancer.SubConn
+}
+
+func (p *picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	host, done, err := p.hostManager.Acquire(lb.KeyFromContext(info.Ctx))
+	if err != nil {
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+	subConn, ok := p.subConns[host]
+	if !ok {
+		done(nil)
+		return balancer.PickResult{}, balancer.ErrNoSubConnAvailable
+	}
+
+	ctx := info.Ctx
+	return balancer.PickResult{
+		SubConn: subConn,
+		Done: func(info balancer.DoneInfo) {
+			done(balancerResult(ctx, info.Err))
+		},
+	}, nil
+}
+
+// balancerResult returns the error reported to lb.Balancer as a failure of the host.
+// As for CircuitBreaker, only errors meaning that the host is unhealthy are failures.
+func balancerResult(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() != nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return err
+	default:
+		return nil
+	}
+}
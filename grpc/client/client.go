@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/grpc/client/request"
 	"github.com/txix-open/isp-kit/grpc/isp"
+	"github.com/txix-open/isp-kit/lb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
@@ -28,13 +29,17 @@ const (
 // It provides a fluent API for building requests and automatically handles connection management.
 // Client is safe for concurrent use by multiple goroutines.
 type Client struct {
-	middlewares []request.Middleware
-	dialOptions []grpc.DialOption
-
-	roundTripper  request.RoundTripper
-	hostsResolver *manual.Resolver
-	grpcCli       *grpc.ClientConn
-	backendCli    isp.BackendServiceClient
+	middlewares       []request.Middleware
+	streamMiddlewares []request.StreamMiddleware
+	dialOptions       []grpc.DialOption
+	balancer          lb.Balancer
+	balancerId        int64
+
+	roundTripper       request.RoundTripper
+	streamRoundTripper request.StreamRoundTripper
+	hostsResolver      *manual.Resolver
+	grpcCli            *grpc.ClientConn
+	backendCli         isp.BackendServiceClient
 
 	currentHosts atomic.Value
 }
@@ -52,15 +57,20 @@ func New(initialHosts []string, opts ...Option) (*Client, error) {
 	hostsResolver.InitialState(resolver.State{
 		Addresses: toAddresses(initialHosts),
 	})
+	serviceConfig := `{"loadBalancingPolicy": "round_robin"}`
+	if cli.balancer != nil {
+		cli.balancerId, serviceConfig = registerBalancer(cli.balancer)
+	}
 	dialOptions := cli.dialOptions
 	dialOptions = append(
 		dialOptions,
 		grpc.WithResolvers(hostsResolver),
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy": "round_robin"}`),
+		grpc.WithDefaultServiceConfig(serviceConfig),
 	)
 
 	grpcCli, err := grpc.NewClient(resolverUrl, dialOptions...)
 	if err != nil {
+		unregisterBalancer(cli.balancerId)
 		return nil, errors.WithMessage(err, "new grpc client")
 	}
 	grpcCli.Connect()
@@ -78,6 +88,12 @@ func New(initialHosts []string, opts ...Option) (*Client, error) {
 	}
 	cli.roundTripper = roundTripper
 
+	streamRoundTripper := cli.openStream
+	for i := len(cli.streamMiddlewares) - 1; i >= 0; i-- {
+		streamRoundTripper = cli.streamMiddlewares[i](streamRoundTripper)
+	}
+	cli.streamRoundTripper = streamRoundTripper
+
 	return cli, nil
 }
 
@@ -87,6 +103,12 @@ func (cli *Client) Invoke(endpoint string) *request.Builder {
 	return request.NewBuilder(cli.roundTripper, endpoint)
 }
 
+// InvokeStream creates a stream builder for the specified endpoint.
+// The endpoint must be registered on the server with grpc.Mux.HandleStream.
+func (cli *Client) InvokeStream(endpoint string) *request.StreamBuilder {
+	return request.NewStreamBuilder(cli.streamRoundTripper, endpoint)
+}
+
 // Upgrade updates the list of backend hosts for load balancing.
 // This enables dynamic service discovery without restarting the client.
 // Thread-safe for concurrent use.
@@ -100,6 +122,7 @@ func (cli *Client) Upgrade(hosts []string) {
 // Close closes the gRPC client connection.
 // Should be called when the client is no longer needed.
 func (cli *Client) Close() error {
+	defer unregisterBalancer(cli.balancerId)
 	return cli.grpcCli.Close()
 }
 
@@ -119,6 +142,16 @@ func (cli *Client) do(ctx context.Context, _ *request.Builder, message *isp.Mess
 	return cli.backendCli.Request(ctx, message)
 }
 
+// openStream opens the actual gRPC stream at the end of the stream middleware chain.
+// Returns an error if the client is not properly initialized or the stream cannot be opened.
+func (cli *Client) openStream(ctx context.Context, _ *request.StreamBuilder) (isp.BackendService_RequestStreamClient, error) {
+	currentHosts := cli.currentHosts.Load().([]string) // nolint:forcetypeassert
+	if len(currentHosts) == 0 {
+		return nil, errors.New("grpc client: client is not initialized properly: empty hosts array")
+	}
+	return cli.backendCli.RequestStream(ctx)
+}
+
 // toAddresses converts a slice of host strings to gRPC resolver addresses.
 func toAddresses(hosts []string) []resolver.Address {
 	addresses := make([]resolver.Address, len(hosts))
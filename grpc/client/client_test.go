@@ -1,7 +1,10 @@
 package client_test
 
 import (
+	"context"
+	"io"
 	"net"
+	"strconv"
 	"sync/atomic"
 	"testing"
 
@@ -10,7 +13,19 @@ import (
 	"github.com/txix-open/isp-kit/grpc"
 	"github.com/txix-open/isp-kit/grpc/client"
 	"github.com/txix-open/isp-kit/grpc/endpoint"
+	"github.com/txix-open/isp-kit/lb"
 	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/grpc_metrics"
+	"github.com/txix-open/isp-kit/observability/tracing/grpc/client_tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpcx "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestBalancing(t *testing.T) {
@@ -48,7 +63,157 @@ func TestBalancing(t *testing.T) {
 	}
 }
 
+func TestBalancer_ConsistentHash(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	servers := 3
+	hosts := make([]string, 0)
+	callCounter := make([]atomic.Int32, servers)
+	for i := range servers {
+		counter := &callCounter[i]
+		host := prepareServer(t, require, "test", func() {
+			counter.Add(1)
+		})
+		hosts = append(hosts, host)
+	}
+
+	cli, err := client.New(
+		hosts,
+		client.WithBalancer(lb.NewConsistentHash(nil)),
+		client.WithDialOptions(
+			grpcx.WithTransportCredentials(insecure.NewCredentials()),
+			grpcx.WithDefaultCallOptions(grpcx.WaitForReady(true)),
+		),
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = cli.Close()
+	})
+
+	// wait until every host is ready and receives requests
+	allHostsCalled := func() bool {
+		for i := range callCounter {
+			if callCounter[i].Load() == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	for i := 0; !allHostsCalled(); i++ {
+		ctx := lb.KeyToContext(t.Context(), strconv.Itoa(i))
+		err = cli.Invoke("test").Do(ctx)
+		require.NoError(err)
+	}
+	for i := range callCounter {
+		callCounter[i].Store(0)
+	}
+
+	calls := int32(100)
+	ctx := lb.KeyToContext(t.Context(), "some-key")
+	for range calls {
+		err = cli.Invoke("test").Do(ctx)
+		require.NoError(err)
+	}
+
+	values := make([]int32, 0, servers)
+	for i := range callCounter {
+		values = append(values, callCounter[i].Load())
+	}
+	require.ElementsMatch([]int32{calls, 0, 0}, values)
+}
+
+func TestStreamObservability(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	logger, err := log.New()
+	require.NoError(err)
+	wrapper := endpoint.DefaultWrapper(logger)
+	traceParents := make(chan string, 2)
+	mux := grpc.NewMux().
+		Handle("unary", wrapper.Endpoint(func(ctx context.Context) {
+			traceParents <- traceParent(ctx)
+		})).
+		HandleStream("stream", wrapper.StreamEndpoint(func(ctx context.Context, stream *grpc.Stream) error {
+			traceParents <- traceParent(ctx)
+			return stream.SendJson(true)
+		}))
+	host := serve(t, require, mux)
+
+	registry := metrics.NewRegistry()
+	storage := grpc_metrics.NewClientStorage(registry)
+	recorder := tracetest.NewSpanRecorder()
+	tracingConfig := client_tracing.Config{
+		Provider:   sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		Propagator: propagation.TraceContext{},
+	}
+	cli, err := client.New(
+		[]string{host},
+		client.WithDialOptions(grpcx.WithTransportCredentials(insecure.NewCredentials())),
+		client.WithMiddlewares(client.Metrics(storage), tracingConfig.Middleware()),
+		client.WithStreamMiddlewares(client.StreamMetrics(storage), tracingConfig.StreamMiddleware()),
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = cli.Close()
+	})
+
+	err = cli.Invoke("unary").Do(t.Context())
+	require.NoError(err)
+	require.NotEmpty(<-traceParents)
+
+	stream, err := cli.InvokeStream("stream").Open(t.Context())
+	require.NoError(err)
+	defer stream.Close()
+	require.NotEmpty(<-traceParents)
+	require.Len(recorder.Ended(), 1)
+	ok := false
+	err = stream.RecvJson(&ok)
+	require.NoError(err)
+	require.True(ok)
+	err = stream.RecvJson(&ok)
+	require.ErrorIs(err, io.EOF)
+
+	spans := recorder.Ended()
+	require.Len(spans, 2)
+	require.Equal("GRPC call unary", spans[0].Name())
+	require.Equal("GRPC stream stream", spans[1].Name())
+	require.Equal(trace.SpanKindClient, spans[1].SpanKind())
+	require.Equal(codes.Unset, spans[1].Status().Code)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+	counts := make(map[string]uint64)
+	for _, family := range families {
+		if family.GetName() != "grpc_client_request_duration_ms" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			counts[metric.GetLabel()[0].GetValue()] = metric.GetSummary().GetSampleCount()
+		}
+	}
+	require.Equal(map[string]uint64{"unary": 1, "stream": 1}, counts)
+}
+
+func traceParent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("traceparent")
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 func prepareServer(t *testing.T, require *require.Assertions, endpointName string, handler any) string {
+	t.Helper()
+	logger, err := log.New()
+	require.NoError(err)
+	wrapper := endpoint.DefaultWrapper(logger)
+	return serve(t, require, grpc.NewMux().Handle(endpointName, wrapper.Endpoint(handler)))
+}
+
+func serve(t *testing.T, require *require.Assertions, mux *grpc.Mux) string {
 	t.Helper()
 	var lc net.ListenConfig
 	listener, err := lc.Listen(t.Context(), "tcp", "127.0.0.1:")
@@ -57,10 +222,7 @@ func prepareServer(t *testing.T, require *require.Assertions, endpointName strin
 	t.Cleanup(func() {
 		srv.Shutdown()
 	})
-	logger, err := log.New()
-	require.NoError(err)
-	wrapper := endpoint.DefaultWrapper(logger)
-	srv.Upgrade(grpc.NewMux().Handle(endpointName, wrapper.Endpoint(handler)))
+	srv.Upgrade(mux)
 	go func() {
 		err := srv.Serve(listener)
 		assert.NoError(t, err)
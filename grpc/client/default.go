@@ -12,15 +12,18 @@ import (
 
 // Default creates a Client with pre-configured middleware for observability.
 // Includes request ID propagation, metrics collection, and distributed tracing.
+// Streams opened with InvokeStream get the same request ID propagation, metrics collection and tracing.
 // Uses insecure transport by default (suitable for development and testing).
 // Accepts additional middleware to be appended after the default ones.
 // Returns an error if the client cannot be initialized.
 func Default(restMiddlewares ...request.Middleware) (*Client, error) {
+	metricStorage := grpc_metrics.NewClientStorage(metrics.DefaultRegistry)
+	tracingConfig := client_tracing.NewConfig()
 	middlewares := append(
 		[]request.Middleware{
 			RequestId(),
-			Metrics(grpc_metrics.NewClientStorage(metrics.DefaultRegistry)),
-			client_tracing.NewConfig().Middleware(),
+			Metrics(metricStorage),
+			tracingConfig.Middleware(),
 		},
 		restMiddlewares...,
 	)
@@ -35,5 +38,10 @@ func Default(restMiddlewares ...request.Middleware) (*Client, error) {
 			),
 		),
 		WithMiddlewares(middlewares...),
+		WithStreamMiddlewares(
+			StreamRequestId(),
+			StreamMetrics(metricStorage),
+			tracingConfig.StreamMiddleware(),
+		),
 	)
 }
@@ -4,11 +4,15 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/breaker"
 	"github.com/txix-open/isp-kit/grpc/client/request"
 	"github.com/txix-open/isp-kit/grpc/isp"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/requestid"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // logConfig holds logging configuration for client middleware.
@@ -35,6 +39,22 @@ func RequestId() request.Middleware {
 	}
 }
 
+// StreamRequestId is a stream middleware that propagates request IDs across service boundaries.
+// If no request ID is present in the context, it generates a new one.
+func StreamRequestId() request.StreamMiddleware {
+	return func(next request.StreamRoundTripper) request.StreamRoundTripper {
+		return func(ctx context.Context, builder *request.StreamBuilder) (isp.BackendService_RequestStreamClient, error) {
+			requestId := requestid.FromContext(ctx)
+			if requestId == "" {
+				requestId = requestid.Next()
+			}
+
+			ctx = metadata.AppendToOutgoingContext(ctx, requestid.Header, requestId)
+			return next(ctx, builder)
+		}
+	}
+}
+
 // Log creates a middleware that logs gRPC client requests and responses.
 // When logBody is true, request and response bodies are included in the logs.
 // Logs at Debug level for requests and responses.
@@ -159,3 +179,56 @@ func Metrics(storage MetricStorage) request.Middleware {
 		}
 	}
 }
+
+// StreamMetrics creates a stream middleware that collects metrics for gRPC client streams.
+// The duration is observed from opening the stream until it completes, see request.OnStreamDone.
+func StreamMetrics(storage MetricStorage) request.StreamMiddleware {
+	return func(next request.StreamRoundTripper) request.StreamRoundTripper {
+		return func(ctx context.Context, builder *request.StreamBuilder) (isp.BackendService_RequestStreamClient, error) {
+			start := time.Now()
+			stream, err := next(ctx, builder)
+			if err != nil {
+				storage.ObserveDuration(builder.Endpoint, time.Since(start))
+				return nil, err
+			}
+			return request.OnStreamDone(ctx, stream, func(err error) {
+				storage.ObserveDuration(builder.Endpoint, time.Since(start))
+			}), nil
+		}
+	}
+}
+
+// CircuitBreaker creates a middleware that guards each endpoint with its own breaker from the group.
+// Errors with codes Unavailable, DeadlineExceeded, ResourceExhausted and Internal are counted as failures,
+// other errors mean the downstream is alive. Calls cancelled by the caller are not counted.
+// When the breaker is open, the request is not sent and an error wrapping breaker.ErrOpen is returned.
+func CircuitBreaker(group *breaker.Group) request.Middleware {
+	return func(next request.RoundTripper) request.RoundTripper {
+		return func(ctx context.Context, builder *request.Builder, message *isp.Message) (*isp.Message, error) {
+			done, err := group.Get(builder.Endpoint).Allow(ctx)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "endpoint '%s'", builder.Endpoint)
+			}
+
+			resp, err := next(ctx, builder, message)
+			done(breakerResult(ctx, err))
+			return resp, err
+		}
+	}
+}
+
+// breakerResult classifies the result of a call for the circuit breaker.
+func breakerResult(ctx context.Context, err error) breaker.Result {
+	if err == nil {
+		return breaker.ResultSuccess
+	}
+	if ctx.Err() != nil {
+		return breaker.ResultIgnored
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return breaker.ResultFailure
+	default:
+		return breaker.ResultSuccess
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/txix-open/isp-kit/grpc/client/request"
+	"github.com/txix-open/isp-kit/lb"
 	"google.golang.org/grpc"
 )
 
@@ -16,6 +17,14 @@ func WithMiddlewares(middlewares ...request.Middleware) Option {
 	}
 }
 
+// WithStreamMiddlewares adds one or more stream middleware to the client.
+// Middleware are executed in the order they are provided.
+func WithStreamMiddlewares(middlewares ...request.StreamMiddleware) Option {
+	return func(cli *Client) {
+		cli.streamMiddlewares = append(cli.streamMiddlewares, middlewares...)
+	}
+}
+
 // WithDialOptions sets custom gRPC dial options for the client.
 // Note: This replaces any previously set dial options.
 func WithDialOptions(dialOptions ...grpc.DialOption) Option {
@@ -24,6 +33,15 @@ func WithDialOptions(dialOptions ...grpc.DialOption) Option {
 	}
 }
 
+// WithBalancer sets the strategy distributing requests across the ready hosts, round-robin by default.
+// Calls failed with codes Unavailable, DeadlineExceeded, ResourceExhausted and Internal
+// are reported to the balancer as failures, which drives outlier ejection if it is enabled.
+func WithBalancer(balancer lb.Balancer) Option {
+	return func(cli *Client) {
+		cli.balancer = balancer
+	}
+}
+
 // LogOption configures logging behavior for request middleware.
 type LogOption func(cfg *logConfig)
 
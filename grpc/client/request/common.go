@@ -2,7 +2,10 @@ package request
 
 import (
 	"context"
+	"io"
+	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/grpc/isp"
 )
 
@@ -15,3 +18,58 @@ type RoundTripper func(ctx context.Context, builder *Builder, message *isp.Messa
 // metrics, authentication, or error handling.
 // Middleware functions are typically chained in reverse order of execution.
 type Middleware func(next RoundTripper) RoundTripper
+
+// StreamRoundTripper defines the interface for opening streaming gRPC requests.
+// Receives a context with outgoing metadata and the stream builder; returns an opened raw stream.
+type StreamRoundTripper func(ctx context.Context, builder *StreamBuilder) (isp.BackendService_RequestStreamClient, error)
+
+// StreamMiddleware wraps a StreamRoundTripper to add cross-cutting concerns to streaming requests.
+type StreamMiddleware func(next StreamRoundTripper) StreamRoundTripper
+
+// OnStreamDone wraps the stream opened with ctx so that done is called once when the stream completes:
+// Recv returns an error or ctx is done. The error passed to done is nil if the server
+// has completed the stream successfully. Used by stream middlewares to observe the whole stream.
+func OnStreamDone(
+	ctx context.Context,
+	stream isp.BackendService_RequestStreamClient,
+	done func(err error),
+) isp.BackendService_RequestStreamClient {
+	s := &doneStream{
+		BackendService_RequestStreamClient: stream,
+		once:                               &sync.Once{},
+		done:                               done,
+	}
+	s.stop = context.AfterFunc(ctx, func() {
+		s.complete(ctx.Err())
+	})
+	return s
+}
+
+// doneStream calls done when the wrapped stream completes.
+type doneStream struct {
+	isp.BackendService_RequestStreamClient
+
+	once *sync.Once
+	stop func() bool
+	done func(err error)
+}
+
+// Recv receives a message and completes the stream on error.
+func (s *doneStream) Recv() (*isp.Message, error) {
+	message, err := s.BackendService_RequestStreamClient.Recv()
+	if err != nil {
+		s.stop()
+		s.complete(err)
+	}
+	return message, err
+}
+
+// complete calls done once, io.EOF is reported as a successful completion.
+func (s *doneStream) complete(err error) {
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		s.done(err)
+	})
+}
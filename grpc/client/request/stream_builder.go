@@ -0,0 +1,126 @@
+package request
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/grpc"
+	"github.com/txix-open/isp-kit/grpc/isp"
+	"github.com/txix-open/isp-kit/json"
+	"google.golang.org/grpc/metadata"
+)
+
+// StreamBuilder provides a fluent API for opening streaming gRPC requests.
+// StreamBuilder is not safe for concurrent use; create a new instance for each stream.
+type StreamBuilder struct {
+	Endpoint      string
+	MD            metadata.MD
+	applicationId int
+	roundTripper  StreamRoundTripper
+}
+
+// NewStreamBuilder creates a new StreamBuilder for the specified endpoint.
+func NewStreamBuilder(roundTripper StreamRoundTripper, endpoint string) *StreamBuilder {
+	return &StreamBuilder{
+		Endpoint:     endpoint,
+		MD:           metadata.New(make(map[string]string)),
+		roundTripper: roundTripper,
+	}
+}
+
+// ApplicationId sets the application identity header for the stream.
+// Returns the StreamBuilder for method chaining.
+func (req *StreamBuilder) ApplicationId(appId int) *StreamBuilder {
+	req.applicationId = appId
+	return req
+}
+
+// AppendMetadata adds one or more values to the metadata key.
+// Returns the StreamBuilder for method chaining.
+func (req *StreamBuilder) AppendMetadata(k string, v ...string) *StreamBuilder {
+	req.MD[k] = append(req.MD[k], v...)
+	return req
+}
+
+// Open opens the stream to the endpoint.
+// The stream lives until Close is called, ctx is canceled or the server completes the stream.
+// Returns an error if the stream cannot be opened.
+func (req *StreamBuilder) Open(ctx context.Context) (*Stream, error) {
+	req.MD.Set(grpc.ProxyMethodNameHeader, req.Endpoint)
+	if req.applicationId != 0 {
+		req.MD.Set(grpc.ApplicationIdHeader, strconv.Itoa(req.applicationId))
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	streamCtx = metadata.NewOutgoingContext(streamCtx, req.MD)
+	raw, err := req.roundTripper(streamCtx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Stream{
+		raw:    raw,
+		cancel: cancel,
+	}, nil
+}
+
+// Stream wraps a client-side bidirectional gRPC stream and provides
+// JSON helpers for sending and receiving messages.
+// Stream is not safe for concurrent Send or concurrent Recv calls,
+// but one goroutine may send while another receives.
+type Stream struct {
+	raw    isp.BackendService_RequestStreamClient
+	cancel context.CancelFunc
+}
+
+// Raw returns the underlying gRPC stream.
+func (s *Stream) Raw() isp.BackendService_RequestStreamClient {
+	return s.raw
+}
+
+// Send sends a raw message to the server.
+func (s *Stream) Send(message *isp.Message) error {
+	return s.raw.Send(message)
+}
+
+// Recv receives a raw message from the server.
+// Returns io.EOF when the server has completed the stream successfully.
+func (s *Stream) Recv() (*isp.Message, error) {
+	return s.raw.Recv()
+}
+
+// SendJson marshals the value to JSON and sends it to the server.
+func (s *Stream) SendJson(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.WithMessage(err, "marshal json")
+	}
+	return s.raw.Send(&isp.Message{Body: &isp.Message_BytesBody{BytesBody: data}})
+}
+
+// RecvJson receives a message from the server and unmarshals its JSON body into ptr.
+// Returns io.EOF unwrapped when the server has completed the stream successfully.
+func (s *Stream) RecvJson(ptr any) error {
+	message, err := s.raw.Recv()
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(message.GetBytesBody(), ptr)
+	if err != nil {
+		return errors.WithMessage(err, "unmarshal json")
+	}
+	return nil
+}
+
+// CloseSend signals the server that the client has finished sending.
+// Messages may still be received after CloseSend.
+func (s *Stream) CloseSend() error {
+	return s.raw.CloseSend()
+}
+
+// Close cancels the stream and releases its resources.
+// Safe to call after the stream has already completed.
+func (s *Stream) Close() {
+	s.cancel()
+}
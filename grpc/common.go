@@ -14,3 +14,12 @@ type HandlerFunc func(ctx context.Context, message *isp.Message) (*isp.Message,
 // metrics, authentication, or error handling.
 // Middleware functions are typically chained in reverse order of execution.
 type Middleware func(next HandlerFunc) HandlerFunc
+
+// StreamHandlerFunc defines the signature for streaming gRPC request handlers.
+// Receives a context and a Stream for exchanging messages with the client.
+// Returning from the handler completes the stream.
+type StreamHandlerFunc func(ctx context.Context, stream *Stream) error
+
+// StreamMiddleware wraps a StreamHandlerFunc to add cross-cutting concerns like logging,
+// metrics, authentication, or error handling for streaming RPCs.
+type StreamMiddleware func(next StreamHandlerFunc) StreamHandlerFunc
@@ -12,6 +12,10 @@ import (
 // DefaultWrapper creates a Wrapper with pre-configured middleware for observability.
 // Includes request ID propagation, metrics collection, distributed tracing, error handling,
 // and panic recovery. Uses JSON for request extraction and response mapping.
+// Stream endpoints get request ID propagation, metrics collection, distributed tracing,
+// error handling and panic recovery.
+// LogLevel and StreamLogLevel are not included, as they let a client enable debug logs,
+// add them to trusted endpoints only.
 // Accepts additional middleware to be appended after the default ones.
 func DefaultWrapper(logger log.Logger, restMiddlewares ...grpc.Middleware) Wrapper {
 	paramMappers := []ParamMapper{
@@ -33,5 +37,12 @@ func DefaultWrapper(logger log.Logger, restMiddlewares ...grpc.Middleware) Wrapp
 		paramMappers,
 		JsonRequestExtractor{Validator: validator.Default},
 		JsonResponseMapper{},
-	).WithMiddlewares(middlewares...)
+	).WithMiddlewares(middlewares...).
+		WithStreamMiddlewares(
+			StreamRequestId(),
+			StreamMetrics(metricStorage),
+			server_tracing.NewConfig().StreamMiddleware(),
+			StreamErrorHandler(logger),
+			StreamRecovery(),
+		)
 }
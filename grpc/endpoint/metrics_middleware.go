@@ -49,3 +49,24 @@ func Metrics(storage MetricStorage) grpc.Middleware {
 		}
 	}
 }
+
+// StreamMetrics creates a stream middleware that collects metrics for gRPC server streams.
+// Records the stream duration and the response status code, body sizes are not recorded.
+func StreamMetrics(storage MetricStorage) grpc.StreamMiddleware {
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) error {
+			md, _ := metadata.FromIncomingContext(ctx)
+			endpoint, err := grpc.StringFromMd(grpc.ProxyMethodNameHeader, md)
+			if err != nil {
+				return err
+			}
+
+			start := time.Now()
+			err = next(ctx, stream)
+			storage.ObserveDuration(endpoint, time.Since(start))
+			storage.CountStatusCode(endpoint, status.Code(err))
+
+			return err
+		}
+	}
+}
@@ -92,6 +92,33 @@ func RequestId() grpc.Middleware {
 	}
 }
 
+// LogLevel creates a middleware that overrides the log level for a single request.
+// The level is taken from the log.LevelHeader metadata, e.g. "debug",
+// and stored in the context with log.LevelToContext. Invalid levels are ignored.
+// The middleware lets a client enable debug logs of its requests, so it is not a part of DefaultWrapper
+// and should be used only for trusted endpoints, e.g. behind authentication.
+func LogLevel() grpc.Middleware {
+	return func(next grpc.HandlerFunc) grpc.HandlerFunc {
+		return func(ctx context.Context, message *isp.Message) (*isp.Message, error) {
+			return next(levelToContext(ctx), message)
+		}
+	}
+}
+
+// levelToContext stores the log level from the incoming metadata in the context.
+func levelToContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(log.LevelHeader)
+	if len(values) == 0 {
+		return ctx
+	}
+	level, err := log.ParseLevel(values[0])
+	if err != nil {
+		return ctx
+	}
+	return log.LevelToContext(ctx, level)
+}
+
 // sentryRequest creates a Sentry request object from the gRPC context.
 // Extracts endpoint and application ID from metadata for error tracking.
 func sentryRequest(ctx context.Context) *sentry.Request {
@@ -0,0 +1,98 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/grpc"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/log/logutil"
+	sentry2 "github.com/txix-open/isp-kit/observability/sentry"
+	"github.com/txix-open/isp-kit/panic_recovery"
+	"github.com/txix-open/isp-kit/requestid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// StreamRecovery creates a stream middleware that catches panics and converts them to errors.
+func StreamRecovery() grpc.StreamMiddleware {
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) (err error) {
+			defer panic_recovery.Recover(func(panicErr error) {
+				err = panicErr
+			})
+			return next(ctx, stream)
+		}
+	}
+}
+
+// StreamErrorHandler creates a stream middleware that handles errors from downstream handlers.
+// Behaves like ErrorHandler: logs the error, enriches it with Sentry context and
+// hides details of unknown errors behind codes.Internal.
+func StreamErrorHandler(logger log.Logger) grpc.StreamMiddleware {
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) error {
+			err := next(ctx, stream)
+			if err == nil {
+				return nil
+			}
+
+			logFunc := logutil.LogLevelFuncForError(err, logger)
+			logContext := sentry2.EnrichEvent(ctx, func(event *sentry.Event) {
+				event.Request = sentryRequest(ctx)
+			})
+			logFunc(logContext, err)
+
+			var grpcErr GrpcError
+			if errors.As(err, &grpcErr) {
+				return grpcErr.GrpcStatusError()
+			}
+
+			_, ok := status.FromError(err)
+			if ok {
+				return err
+			}
+
+			// hide error details to prevent potential security leaks
+			return status.Error(codes.Internal, "internal service error")
+		}
+	}
+}
+
+// StreamLogLevel creates a stream middleware that overrides the log level for a single stream.
+// Behaves like LogLevel.
+func StreamLogLevel() grpc.StreamMiddleware {
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) error {
+			return next(levelToContext(ctx), stream)
+		}
+	}
+}
+
+// StreamRequestId creates a stream middleware that manages request IDs for tracing.
+// Extracts the request ID from incoming metadata, generates a new one if absent,
+// and injects it into the context for downstream use.
+func StreamRequestId() grpc.StreamMiddleware {
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) error {
+			md, ok := metadata.FromIncomingContext(ctx)
+			if !ok {
+				return errors.New("metadata is expected in context")
+			}
+			values := md.Get(requestid.Header)
+			requestId := ""
+			if len(values) > 0 {
+				requestId = values[0]
+			}
+			if requestId == "" {
+				requestId = requestid.Next()
+			}
+			ctx = requestid.ToContext(ctx, requestId)
+			ctx = log.ToContext(ctx, log.String(requestid.LogKey, requestId))
+
+			return next(ctx, stream)
+		}
+	}
+}
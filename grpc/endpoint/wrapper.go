@@ -53,10 +53,11 @@ type ParamMapper struct {
 // automatic request/response handling. It uses reflection to analyze function signatures
 // and inject dependencies like context and auth data.
 type Wrapper struct {
-	ParamMappers  map[string]ParamMapper
-	BodyExtractor RequestBodyExtractor
-	BodyMapper    ResponseBodyMapper
-	Middlewares   []grpc.Middleware
+	ParamMappers      map[string]ParamMapper
+	BodyExtractor     RequestBodyExtractor
+	BodyMapper        ResponseBodyMapper
+	Middlewares       []grpc.Middleware
+	StreamMiddlewares []grpc.StreamMiddleware
 }
 
 // NewWrapper creates a new Wrapper with the specified configuration.
@@ -98,9 +99,31 @@ func (m Wrapper) Endpoint(f any) grpc.HandlerFunc {
 // The original Wrapper is not modified.
 func (m Wrapper) WithMiddlewares(middlewares ...grpc.Middleware) Wrapper {
 	return Wrapper{
-		ParamMappers:  m.ParamMappers,
-		BodyExtractor: m.BodyExtractor,
-		BodyMapper:    m.BodyMapper,
-		Middlewares:   append(m.Middlewares, middlewares...),
+		ParamMappers:      m.ParamMappers,
+		BodyExtractor:     m.BodyExtractor,
+		BodyMapper:        m.BodyMapper,
+		Middlewares:       append(m.Middlewares, middlewares...),
+		StreamMiddlewares: m.StreamMiddlewares,
+	}
+}
+
+// StreamEndpoint wraps a StreamHandlerFunc with the configured stream middleware.
+// Middleware is applied in reverse order (last added, first executed).
+func (m Wrapper) StreamEndpoint(handler grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+	for i := len(m.StreamMiddlewares) - 1; i >= 0; i-- {
+		handler = m.StreamMiddlewares[i](handler)
+	}
+	return handler
+}
+
+// WithStreamMiddlewares returns a new Wrapper with additional stream middleware appended.
+// The original Wrapper is not modified.
+func (m Wrapper) WithStreamMiddlewares(middlewares ...grpc.StreamMiddleware) Wrapper {
+	return Wrapper{
+		ParamMappers:      m.ParamMappers,
+		BodyExtractor:     m.BodyExtractor,
+		BodyMapper:        m.BodyMapper,
+		Middlewares:       m.Middlewares,
+		StreamMiddlewares: append(m.StreamMiddlewares, middlewares...),
 	}
 }
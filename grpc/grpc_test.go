@@ -2,6 +2,7 @@ package grpc_test
 
 import (
 	"context"
+	"io"
 	"net"
 	"sync/atomic"
 	"testing"
@@ -201,6 +202,90 @@ func TestGrpcRecovery(t *testing.T) {
 	require.False(resp.Ok)
 }
 
+func TestGrpcStream(t *testing.T) {
+	t.Parallel()
+
+	require, srv, cli := prepareTest(t)
+	reqId := requestid.Next()
+	ctx := requestid.ToContext(t.Context(), reqId)
+
+	logger, err := log.New()
+	require.NoError(err)
+	wrapper := endpoint.DefaultWrapper(logger)
+	receivedReqId := make(chan string, 1)
+	receivedAppId := make(chan int, 1)
+	handler := func(ctx context.Context, stream *grpc.Stream) error {
+		receivedReqId <- requestid.FromContext(ctx)
+		appId, err := stream.AuthData().ApplicationId()
+		if err != nil {
+			return err
+		}
+		receivedAppId <- appId
+
+		for {
+			req := reqBody{}
+			err := stream.RecvJson(&req)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			err = stream.SendJson(respBody{Ok: req.B})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	srv.Upgrade(grpc.NewMux().HandleStream("endpoint", wrapper.StreamEndpoint(handler)))
+
+	stream, err := cli.InvokeStream("endpoint").
+		ApplicationId(123).
+		Open(ctx)
+	require.NoError(err)
+	defer stream.Close()
+
+	for _, b := range []bool{true, false, true} {
+		err = stream.SendJson(reqBody{B: b})
+		require.NoError(err)
+		resp := respBody{}
+		err = stream.RecvJson(&resp)
+		require.NoError(err)
+		require.EqualValues(b, resp.Ok)
+	}
+	err = stream.CloseSend()
+	require.NoError(err)
+	err = stream.RecvJson(&respBody{})
+	require.ErrorIs(err, io.EOF)
+	require.EqualValues(reqId, <-receivedReqId)
+	require.EqualValues(123, <-receivedAppId)
+}
+
+func TestGrpcStreamError(t *testing.T) {
+	t.Parallel()
+
+	require, srv, cli := prepareTest(t)
+	logger, err := log.New()
+	require.NoError(err)
+	wrapper := endpoint.DefaultWrapper(logger)
+	handler := func(ctx context.Context, stream *grpc.Stream) error {
+		return errors.New("some internal error")
+	}
+	srv.Upgrade(grpc.NewMux().HandleStream("endpoint", wrapper.StreamEndpoint(handler)))
+
+	stream, err := cli.InvokeStream("endpoint").Open(t.Context())
+	require.NoError(err)
+	defer stream.Close()
+	_, err = stream.Recv()
+	require.EqualValues(codes.Internal, status.Code(err))
+
+	stream, err = cli.InvokeStream("unknown").Open(t.Context())
+	require.NoError(err)
+	defer stream.Close()
+	_, err = stream.Recv()
+	require.EqualValues(codes.NotFound, status.Code(err))
+}
+
 func prepareTest(t *testing.T) (*require.Assertions, *grpc.Server, *grpcCli.Client) {
 	t.Helper()
 	required := require.New(t)
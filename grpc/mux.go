@@ -17,18 +17,20 @@ const (
 )
 
 // Mux is a request router that dispatches gRPC requests to registered handlers based on endpoint name.
-// It extracts the endpoint from request metadata and invokes the corresponding HandlerFunc.
-// Mux implements the BackendServiceServer interface but does not support streaming RPCs.
+// It extracts the endpoint from request metadata and invokes the corresponding HandlerFunc
+// for unary RPCs or StreamHandlerFunc for streaming RPCs.
 type Mux struct {
 	isp.UnimplementedBackendServiceServer
 
-	unaryHandlers map[string]HandlerFunc
+	unaryHandlers  map[string]HandlerFunc
+	streamHandlers map[string]StreamHandlerFunc
 }
 
 // NewMux creates a new Mux with an empty handler registry.
 func NewMux() *Mux {
 	return &Mux{
-		unaryHandlers: make(map[string]HandlerFunc),
+		unaryHandlers:  make(map[string]HandlerFunc),
+		streamHandlers: make(map[string]StreamHandlerFunc),
 	}
 }
 
@@ -44,6 +46,20 @@ func (m *Mux) Handle(endpoint string, handler HandlerFunc) *Mux {
 	return m
 }
 
+// HandleStream registers a StreamHandlerFunc for the specified endpoint.
+// Streaming and unary handlers are kept in separate registries, so the same endpoint
+// may be served by both.
+// Panics if a stream handler is already registered for the endpoint.
+// Returns the Mux for method chaining.
+func (m *Mux) HandleStream(endpoint string, handler StreamHandlerFunc) *Mux {
+	_, ok := m.streamHandlers[endpoint]
+	if ok {
+		panic(errors.Errorf("stream handler for endpoint %v is already provided", endpoint))
+	}
+	m.streamHandlers[endpoint] = handler
+	return m
+}
+
 // Request handles unary gRPC requests by routing to the registered handler for the endpoint.
 // The endpoint is extracted from the ProxyMethodNameHeader in request metadata.
 // Returns an error if metadata is missing, endpoint is not found, or handler fails.
@@ -64,8 +80,23 @@ func (m *Mux) Request(ctx context.Context, message *isp.Message) (*isp.Message,
 	return handler(ctx, message)
 }
 
-// RequestStream returns an unimplemented error for streaming RPCs.
-// Mux does not support streaming; use a custom implementation for streaming support.
-func (m *Mux) RequestStream(_ isp.BackendService_RequestStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "service does not support streaming RPC")
+// RequestStream handles streaming gRPC requests by routing to the registered stream handler for the endpoint.
+// The endpoint is extracted from the ProxyMethodNameHeader in stream metadata.
+// Returns an error if metadata is missing, endpoint is not found, or handler fails.
+func (m *Mux) RequestStream(server isp.BackendService_RequestStreamServer) error {
+	ctx := server.Context()
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return errors.New("metadata is expected in context")
+	}
+	endpoint, err := StringFromMd(ProxyMethodNameHeader, md)
+	if err != nil {
+		return err
+	}
+	handler, ok := m.streamHandlers[endpoint]
+	if !ok {
+		return status.Errorf(codes.NotFound, "stream handler not found for endpoint %s", endpoint)
+	}
+	ctx = log.ToContext(ctx, log.String("endpoint", endpoint))
+	return handler(ctx, NewStream(server))
 }
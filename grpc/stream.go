@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/grpc/isp"
+	"github.com/txix-open/isp-kit/json"
+	"google.golang.org/grpc/metadata"
+)
+
+// Stream wraps a server-side bidirectional gRPC stream and provides
+// JSON helpers for sending and receiving messages.
+// Stream is not safe for concurrent Send or concurrent Recv calls,
+// but one goroutine may send while another receives.
+type Stream struct {
+	raw isp.BackendService_RequestStreamServer
+}
+
+// NewStream wraps the raw gRPC stream.
+func NewStream(raw isp.BackendService_RequestStreamServer) *Stream {
+	return &Stream{
+		raw: raw,
+	}
+}
+
+// Raw returns the underlying gRPC stream.
+func (s *Stream) Raw() isp.BackendService_RequestStreamServer {
+	return s.raw
+}
+
+// AuthData returns authentication metadata received with the stream.
+func (s *Stream) AuthData() AuthData {
+	md, _ := metadata.FromIncomingContext(s.raw.Context())
+	return AuthData(md)
+}
+
+// Send sends a raw message to the client.
+func (s *Stream) Send(message *isp.Message) error {
+	return s.raw.Send(message)
+}
+
+// Recv receives a raw message from the client.
+// Returns io.EOF when the client has finished sending.
+func (s *Stream) Recv() (*isp.Message, error) {
+	return s.raw.Recv()
+}
+
+// SendJson marshals the value to JSON and sends it to the client.
+func (s *Stream) SendJson(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.WithMessage(err, "marshal json")
+	}
+	return s.raw.Send(&isp.Message{Body: &isp.Message_BytesBody{BytesBody: data}})
+}
+
+// RecvJson receives a message from the client and unmarshals its JSON body into ptr.
+// Returns io.EOF unwrapped when the client has finished sending.
+func (s *Stream) RecvJson(ptr any) error {
+	message, err := s.raw.Recv()
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(message.GetBytesBody(), ptr)
+	if err != nil {
+		return errors.WithMessage(err, "unmarshal json")
+	}
+	return nil
+}
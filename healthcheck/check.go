@@ -0,0 +1,206 @@
+package healthcheck
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultCheckInterval is the default interval between background checks.
+	defaultCheckInterval = 1 * time.Second
+	// maxConcurrentRuns limits the runs of a checker ignoring its context,
+	// which are abandoned on timeout but still running.
+	maxConcurrentRuns = 1
+)
+
+var (
+	// defaultProbes are the probes a checker belongs to unless WithProbes is specified.
+	defaultProbes = []Probe{ProbeReadiness, ProbeStartup}
+)
+
+// CheckOption is a function that configures a registered checker.
+type CheckOption func(c *check)
+
+// WithProbes sets the probes the checker belongs to.
+// By default, a checker belongs to the readiness and startup probes.
+func WithProbes(probes ...Probe) CheckOption {
+	return func(c *check) {
+		c.probes = probes
+	}
+}
+
+// WithNonCritical marks the checker as non-critical.
+// A failed non-critical checker has the "warn" status and does not fail the overall result.
+func WithNonCritical() CheckOption {
+	return func(c *check) {
+		c.critical = false
+	}
+}
+
+// WithTimeout sets the timeout of a single check.
+// By default, it is equal to the handle timeout of the registry.
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(c *check) {
+		c.timeout = timeout
+	}
+}
+
+// WithInterval sets the interval between background checks.
+// By default, checks run every second.
+func WithInterval(interval time.Duration) CheckOption {
+	return func(c *check) {
+		c.interval = interval
+	}
+}
+
+// check polls a single checker in the background and keeps its latest result.
+type check struct {
+	name     string
+	checker  Checker
+	probes   []Probe
+	critical bool
+	timeout  time.Duration
+	interval time.Duration
+
+	lastDetail  atomic.Pointer[Detail]
+	firstResult chan struct{}
+	firstOnce   sync.Once
+	runs        chan struct{}
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// newCheck creates a check with default settings and applies the options.
+func newCheck(name string, checker Checker, timeout time.Duration, opts ...CheckOption) *check {
+	c := &check{
+		name:        name,
+		checker:     checker,
+		probes:      defaultProbes,
+		critical:    true,
+		timeout:     timeout,
+		interval:    defaultCheckInterval,
+		firstResult: make(chan struct{}),
+		runs:        make(chan struct{}, maxConcurrentRuns),
+		done:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// hasProbe reports whether the check belongs to the probe.
+func (c *check) hasProbe(probe Probe) bool {
+	return slices.Contains(c.probes, probe)
+}
+
+// start runs the check immediately and then every interval until stop is called or ctx is done.
+// Each result is passed to onResult.
+func (c *check) start(ctx context.Context, onResult func(c *check, detail Detail)) {
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			detail := c.execute(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			c.lastDetail.Store(&detail)
+			c.firstOnce.Do(func() {
+				close(c.firstResult)
+			})
+			onResult(c, detail)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// stop cancels background checks and waits for the polling goroutine to exit.
+func (c *check) stop() {
+	c.cancel()
+	<-c.done
+}
+
+// execute runs the checker once with the check timeout.
+// A checker ignoring its context is abandoned when the timeout expires.
+// While the abandoned run is still in progress, the checker is not run again and the check fails.
+func (c *check) execute(ctx context.Context) Detail {
+	var err error
+	select {
+	case c.runs <- struct{}{}:
+		err = c.run(ctx)
+	default:
+		err = errors.Errorf("previous check is still running after timeout %s", c.timeout)
+	}
+
+	detail := Detail{
+		ComponentName: c.name,
+		ComponentType: "component",
+		Status:        StatusPass,
+		Time:          time.Now().UTC(),
+	}
+	if err != nil {
+		detail.Status = StatusFail
+		if !c.critical || errors.Is(err, ErrDegraded) {
+			detail.Status = StatusWarn
+		}
+		detail.Output = err.Error()
+	}
+	return detail
+}
+
+// run calls the checker with the check timeout, the run slot is released when the checker returns.
+func (c *check) run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			<-c.runs
+		}()
+		errChan <- c.checker.Healthcheck(ctx)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return errors.WithMessagef(ctx.Err(), "check timeout %s", c.timeout)
+	}
+}
+
+// detail returns the latest result of the check.
+// If the check has not completed yet, it waits for the first result until ctx is done.
+func (c *check) detail(ctx context.Context) Detail {
+	select {
+	case <-c.firstResult:
+		return *c.lastDetail.Load()
+	case <-ctx.Done():
+		detail := Detail{
+			ComponentName: c.name,
+			ComponentType: "component",
+			Status:        StatusFail,
+			Output:        "no check result yet",
+			Time:          time.Now().UTC(),
+		}
+		if !c.critical {
+			detail.Status = StatusWarn
+		}
+		return detail
+	}
+}
@@ -6,6 +6,14 @@ package healthcheck
 
 import (
 	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrDegraded marks a checker error reporting that the component works in a degraded mode.
+	// Such a checker has the "warn" status even if it is critical, so the probes keep passing.
+	ErrDegraded = errors.New("degraded")
 )
 
 const (
@@ -13,6 +21,21 @@ const (
 	StatusPass = "pass"
 	// StatusFail indicates an unhealthy component.
 	StatusFail = "fail"
+	// StatusWarn indicates a failed non-critical component.
+	// The overall result is still considered healthy.
+	StatusWarn = "warn"
+)
+
+// Probe identifies a kind of health check endpoint a checker belongs to.
+type Probe string
+
+const (
+	// ProbeLiveness reports whether the process is alive and should not be restarted.
+	ProbeLiveness Probe = "liveness"
+	// ProbeReadiness reports whether the process is ready to accept traffic.
+	ProbeReadiness Probe = "readiness"
+	// ProbeStartup reports whether the process has finished starting up.
+	ProbeStartup Probe = "startup"
 )
 
 // Detail represents the health status of a single component.
@@ -23,7 +46,8 @@ type Detail struct {
 	ComponentName string
 	// componentType describes the type of the component.
 	ComponentType string
-	// status indicates whether the component is healthy ("pass") or unhealthy ("fail").
+	// status indicates whether the component is healthy ("pass"), unhealthy ("fail")
+	// or unhealthy but non-critical ("warn").
 	Status string
 	// output contains additional information about the health check, such as error messages.
 	Output string `json:",omitempty"`
@@ -33,7 +57,8 @@ type Detail struct {
 
 // Result represents the aggregate health status of all registered components.
 type Result struct {
-	// status is the overall health status ("pass" if all components are healthy, "fail" otherwise).
+	// status is the overall health status: "fail" if any critical component is unhealthy,
+	// "warn" if only non-critical components are unhealthy, "pass" otherwise.
 	Status string
 	// details maps component names to their individual health details.
 	Details map[string][]Detail
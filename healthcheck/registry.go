@@ -4,78 +4,216 @@ import (
 	"context"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/app_metrics"
 )
 
 const (
-	// cacheTime specifies how long to cache health check results before re-running them.
-	cacheTime = 1 * time.Second
 	// defaultHandleTimeout is the default timeout for the HTTP handler.
 	defaultHandleTimeout = 1 * time.Second
+	// firstResultWaitRatio limits how long a handler waits for checks without results
+	// as a fraction of the handle timeout.
+	firstResultWaitRatio = 2
+	// registrationComponent is the pseudo component failing the startup probe until registration is completed.
+	registrationComponent = "registration"
 )
 
-// Registry manages a collection of health check components and provides an HTTP
-// handler to expose their status. It caches results to avoid excessive checks.
+// Option is a function that configures a Registry.
+type Option func(r *Registry)
+
+// WithContext sets the context of background checks, e.g. the application context.
+// Background checks stop when the context is done or Close is called.
+// By default, context.Background is used.
+func WithContext(ctx context.Context) Option {
+	return func(r *Registry) {
+		r.ctx = ctx
+	}
+}
+
+// WithMetricsRegistry sets the metrics registry for the component status gauges.
+// By default, metrics.DefaultRegistry is used.
+func WithMetricsRegistry(registry *metrics.Registry) Option {
+	return func(r *Registry) {
+		r.metricsRegistry = registry
+	}
+}
+
+// Registry manages a collection of health check components and provides HTTP
+// handlers to expose their status. Every checker is polled in the background
+// with its own timeout and interval, so handlers only read the latest results.
 //
 // Registry is safe for concurrent use by multiple goroutines.
 type Registry struct {
-	checkers      map[string]Checker
-	lastResult    Result
-	lastCheckTime time.Time
-	lock          sync.Locker
-	handleTimeout time.Duration
+	checkers        map[string]*check
+	lock            sync.Locker
+	handleTimeout   time.Duration
+	metricsRegistry *metrics.Registry
+	statusMetric    app_metrics.HealthcheckStatus
+	ctx             context.Context
+	cancel          context.CancelFunc
+	registered      atomic.Bool
+	started         atomic.Bool
 }
 
 // NewRegistry creates a new Registry with the specified handle timeout.
 // If handleTimeout is zero, it defaults to 1 second.
-func NewRegistry(handleTimeout time.Duration) *Registry {
+func NewRegistry(handleTimeout time.Duration, opts ...Option) *Registry {
 	if handleTimeout == 0 {
 		handleTimeout = defaultHandleTimeout
 	}
 
-	return &Registry{
-		checkers:      make(map[string]Checker),
-		lock:          &sync.Mutex{},
-		handleTimeout: handleTimeout,
+	r := &Registry{
+		checkers:        make(map[string]*check),
+		lock:            &sync.Mutex{},
+		handleTimeout:   handleTimeout,
+		metricsRegistry: metrics.DefaultRegistry,
+		ctx:             context.Background(),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	r.ctx, r.cancel = context.WithCancel(r.ctx)
+	r.statusMetric = app_metrics.NewHealthcheckStatus(r.metricsRegistry)
+	return r
 }
 
-// Register adds a health check component to the registry.
+// Register adds a health check component to the registry and starts polling it in the background.
 // The name parameter is used as the identifier for the component in the results.
-// The checker parameter must implement the Checker interface.
-func (r *Registry) Register(name string, checker Checker) {
+// Registering a component with an existing name replaces the previous one.
+// By default, the component is critical and belongs to the readiness and startup probes.
+func (r *Registry) Register(name string, checker Checker, opts ...CheckOption) {
+	c := newCheck(name, checker, r.handleTimeout, opts...)
+
 	r.lock.Lock()
-	defer r.lock.Unlock()
+	prev := r.checkers[name]
+	r.checkers[name] = c
+	r.lock.Unlock()
+
+	if prev != nil {
+		prev.stop()
+	}
+	c.start(r.ctx, r.observe)
+}
 
-	r.checkers[name] = checker
+// CompleteRegistration reports that all components required for startup are registered.
+// Until it is called, the startup probe fails, so it does not pass before the components
+// are registered. Components may still be registered afterward.
+func (r *Registry) CompleteRegistration() {
+	r.registered.Store(true)
 }
 
-// Unregister removes a health check component from the registry.
+// Unregister removes a health check component from the registry and stops polling it.
 // If the component does not exist, this method has no effect.
 func (r *Registry) Unregister(name string) {
 	r.lock.Lock()
-	defer r.lock.Unlock()
-
+	c := r.checkers[name]
 	delete(r.checkers, name)
+	r.lock.Unlock()
+
+	if c != nil {
+		c.stop()
+		r.statusMetric.Delete(name)
+	}
+}
+
+// Close stops polling of all registered components.
+func (r *Registry) Close() {
+	r.cancel()
+
+	r.lock.Lock()
+	checks := make([]*check, 0, len(r.checkers))
+	for _, c := range r.checkers {
+		checks = append(checks, c)
+	}
+	r.lock.Unlock()
+
+	for _, c := range checks {
+		c.stop()
+	}
 }
 
 // Handler returns an HTTP handler that exposes the health status of all
-// registered components. The handler returns:
-//   - 200 OK with status "pass" if all components are healthy
-//   - 500 Internal Server Error with status "fail" if any component is unhealthy
+// registered components regardless of their probes. The handler returns:
+//   - 200 OK with status "pass" or "warn" if all critical components are healthy
+//   - 500 Internal Server Error with status "fail" if any critical component is unhealthy
 //
 // The response is encoded in application/health+json format according to
 // the draft-inadarei-api-health-check specification.
 func (r *Registry) Handler() http.Handler {
-	return http.TimeoutHandler(http.HandlerFunc(r.handle), r.handleTimeout, "timeout")
+	return r.handler(func(ctx context.Context) Result {
+		return r.result(ctx, func(c *check) bool {
+			return true
+		})
+	})
 }
 
-// handle processes HTTP requests and returns the health check results.
-func (r *Registry) handle(writer http.ResponseWriter, _ *http.Request) {
-	result := r.result()
+// LivenessHandler returns an HTTP handler that exposes the status of components
+// belonging to the liveness probe. Without such components it always passes.
+func (r *Registry) LivenessHandler() http.Handler {
+	return r.ProbeHandler(ProbeLiveness)
+}
+
+// ReadinessHandler returns an HTTP handler that exposes the status of components
+// belonging to the readiness probe.
+func (r *Registry) ReadinessHandler() http.Handler {
+	return r.ProbeHandler(ProbeReadiness)
+}
+
+// StartupHandler returns an HTTP handler that exposes the status of components
+// belonging to the startup probe. The probe fails until CompleteRegistration is called.
+// Once all of the components pass after that, the probe keeps passing.
+func (r *Registry) StartupHandler() http.Handler {
+	return r.ProbeHandler(ProbeStartup)
+}
+
+// ProbeHandler returns an HTTP handler that exposes the status of components
+// belonging to the specified probe. Response codes are the same as for Handler.
+func (r *Registry) ProbeHandler(probe Probe) http.Handler {
+	return r.handler(func(ctx context.Context) Result {
+		if probe == ProbeStartup && r.started.Load() {
+			return Result{Status: StatusPass, Details: map[string][]Detail{}}
+		}
+		if probe == ProbeStartup && !r.registered.Load() {
+			return Result{
+				Status: StatusFail,
+				Details: map[string][]Detail{
+					registrationComponent: {{
+						ComponentName: registrationComponent,
+						ComponentType: "component",
+						Status:        StatusFail,
+						Output:        "registration is not completed",
+						Time:          time.Now().UTC(),
+					}},
+				},
+			}
+		}
+
+		result := r.result(ctx, func(c *check) bool {
+			return c.hasProbe(probe)
+		})
+		if probe == ProbeStartup && result.Status != StatusFail {
+			r.started.Store(true)
+		}
+		return result
+	})
+}
 
+// handler wraps a result supplier into an HTTP handler with the registry timeout.
+func (r *Registry) handler(result func(ctx context.Context) Result) http.Handler {
+	return http.TimeoutHandler(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx, cancel := context.WithTimeout(request.Context(), r.handleTimeout/firstResultWaitRatio)
+		defer cancel()
+
+		r.write(writer, result(ctx))
+	}), r.handleTimeout, "timeout")
+}
+
+// write encodes the health check result to the response.
+func (r *Registry) write(writer http.ResponseWriter, result Result) {
 	statusCode := http.StatusOK
 	if result.Status == StatusFail {
 		statusCode = http.StatusInternalServerError
@@ -86,44 +224,45 @@ func (r *Registry) handle(writer http.ResponseWriter, _ *http.Request) {
 	_ = json.NewEncoder(writer).Encode(result)
 }
 
-// result computes and returns the current health check result.
-// Results are cached for cacheTime to avoid running checks on every request.
-func (r *Registry) result() Result {
+// result collects the latest results of the checks matching the filter.
+// Checks without a result yet are awaited until ctx is done.
+func (r *Registry) result(ctx context.Context, filter func(c *check) bool) Result {
 	r.lock.Lock()
-	defer r.lock.Unlock()
-
-	now := time.Now().UTC()
-	if now.Before(r.lastCheckTime.Add(cacheTime)) {
-		return r.lastResult
+	checks := make([]*check, 0, len(r.checkers))
+	for _, c := range r.checkers {
+		if filter(c) {
+			checks = append(checks, c)
+		}
 	}
+	r.lock.Unlock()
 
-	details := make(map[string][]Detail)
+	details := make(map[string][]Detail, len(checks))
 	resultStatus := StatusPass
-	for name, checker := range r.checkers {
-		resultErr := checker.Healthcheck(context.Background())
-		status := StatusPass
-		output := ""
-		if resultErr != nil {
+	for _, c := range checks {
+		detail := c.detail(ctx)
+		switch {
+		case detail.Status == StatusFail:
 			resultStatus = StatusFail
-			status = StatusFail
-			output = resultErr.Error()
+		case detail.Status == StatusWarn && resultStatus == StatusPass:
+			resultStatus = StatusWarn
 		}
-		detail := Detail{
-			Status:        status,
-			Output:        output,
-			ComponentName: name,
-			ComponentType: "component",
-			Time:          time.Now().UTC(),
-		}
-		details[name] = []Detail{detail}
+		details[c.name] = []Detail{detail}
 	}
-	result := Result{
+
+	return Result{
 		Status:  resultStatus,
 		Details: details,
 	}
+}
 
-	r.lastCheckTime = now
-	r.lastResult = result
-
-	return result
+// observe updates the status gauge of the component after each check.
+func (r *Registry) observe(c *check, detail Detail) {
+	value := 0.0
+	switch detail.Status {
+	case StatusPass:
+		value = 1
+	case StatusWarn:
+		value = 0.5 // nolint:mnd
+	}
+	r.statusMetric.Set(c.name, value)
 }
@@ -2,14 +2,20 @@ package healthcheck_test
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 	"github.com/txix-open/isp-kit/grmqx"
 	"github.com/txix-open/isp-kit/healthcheck"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/test"
 	"github.com/txix-open/isp-kit/test/grmqt"
 )
@@ -51,3 +57,139 @@ func TestRegistry(t *testing.T) {
 
 	require.False(bytes.Equal(firstResponse, thirdResponse))
 }
+
+func getHealth(t *testing.T, handler http.Handler) (int, healthcheck.Result) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	result := healthcheck.Result{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &result)
+	require.NoError(t, err)
+	return recorder.Code, result
+}
+
+func TestRegistry_Probes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var dbHealthy atomic.Bool
+	registry := healthcheck.NewRegistry(time.Second, healthcheck.WithMetricsRegistry(metrics.NewRegistry()))
+	t.Cleanup(registry.Close)
+	registry.Register("process", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		return nil
+	}), healthcheck.WithProbes(healthcheck.ProbeLiveness))
+	registry.Register("db", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		if dbHealthy.Load() {
+			return nil
+		}
+		return errors.New("db is unavailable")
+	}), healthcheck.WithInterval(10*time.Millisecond))
+
+	code, result := getHealth(t, registry.LivenessHandler())
+	require.Equal(http.StatusOK, code)
+	require.Equal(healthcheck.StatusPass, result.Status)
+	require.Contains(result.Details, "process")
+	require.NotContains(result.Details, "db")
+
+	code, result = getHealth(t, registry.ReadinessHandler())
+	require.Equal(http.StatusInternalServerError, code)
+	require.Equal(healthcheck.StatusFail, result.Status)
+	require.Equal("db is unavailable", result.Details["db"][0].Output)
+
+	code, _ = getHealth(t, registry.StartupHandler())
+	require.Equal(http.StatusInternalServerError, code)
+
+	dbHealthy.Store(true)
+	require.Eventually(func() bool {
+		code, _ := getHealth(t, registry.ReadinessHandler())
+		return code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+	code, result = getHealth(t, registry.StartupHandler())
+	require.Equal(http.StatusInternalServerError, code)
+	require.Equal("registration is not completed", result.Details["registration"][0].Output)
+
+	registry.CompleteRegistration()
+	require.Eventually(func() bool {
+		code, _ := getHealth(t, registry.StartupHandler())
+		return code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	dbHealthy.Store(false)
+	require.Eventually(func() bool {
+		code, _ := getHealth(t, registry.ReadinessHandler())
+		return code == http.StatusInternalServerError
+	}, time.Second, 10*time.Millisecond)
+	code, _ = getHealth(t, registry.StartupHandler())
+	require.Equal(http.StatusOK, code)
+}
+
+func TestRegistry_NonCriticalAndTimeout(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	registry := healthcheck.NewRegistry(time.Second, healthcheck.WithMetricsRegistry(metrics.NewRegistry()))
+	t.Cleanup(registry.Close)
+	registry.Register("cache", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("cache is unavailable")
+	}), healthcheck.WithNonCritical())
+	registry.Register("kafka", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		time.Sleep(time.Hour)
+		return nil
+	}), healthcheck.WithNonCritical(), healthcheck.WithTimeout(50*time.Millisecond))
+	registry.Register("config", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		return errors.WithMessage(healthcheck.ErrDegraded, "serving cached config")
+	}))
+
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	code, result := getHealth(t, registry.Handler())
+	require.Less(time.Since(start), 50*time.Millisecond)
+	require.Equal(http.StatusOK, code)
+	require.Equal(healthcheck.StatusWarn, result.Status)
+	require.Equal(healthcheck.StatusWarn, result.Details["cache"][0].Status)
+	require.Equal(healthcheck.StatusWarn, result.Details["kafka"][0].Status)
+	require.Contains(result.Details["kafka"][0].Output, "check timeout")
+	require.Equal(healthcheck.StatusWarn, result.Details["config"][0].Status)
+}
+
+func TestRegistry_HungChecker(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	registry := healthcheck.NewRegistry(
+		time.Second,
+		healthcheck.WithMetricsRegistry(metrics.NewRegistry()),
+		healthcheck.WithContext(ctx),
+	)
+	t.Cleanup(registry.Close)
+
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	registry.Register("hung", healthcheck.CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		<-release
+		return nil
+	}), healthcheck.WithTimeout(10*time.Millisecond), healthcheck.WithInterval(10*time.Millisecond))
+
+	require.Eventually(func() bool {
+		_, result := getHealth(t, registry.Handler())
+		details := result.Details["hung"]
+		return len(details) > 0 && details[0].Output == "previous check is still running after timeout 10ms"
+	}, time.Second, 10*time.Millisecond)
+	require.EqualValues(1, calls.Load())
+
+	close(release)
+	require.Eventually(func() bool {
+		code, _ := getHealth(t, registry.Handler())
+		return code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	stoppedCalls := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(stoppedCalls, calls.Load())
+}
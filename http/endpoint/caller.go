@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/pkg/errors"
+	http2 "github.com/txix-open/isp-kit/http"
 )
 
 // param represents a function parameter that can be built from the request context.
@@ -27,9 +28,10 @@ type Caller struct {
 	reqBodyIndex int
 	reqBodyType  reflect.Type
 
-	hasResult  bool
-	hasError   bool
-	errorIndex int
+	resBodyType reflect.Type
+	hasResult   bool
+	hasError    bool
+	errorIndex  int
 }
 
 // NewCaller creates a new Caller that wraps the provided function.
@@ -73,6 +75,10 @@ func NewCaller(
 	numOut := rt.NumOut()
 	hasResult := numOut > 0 && rt.Out(0) != reflect.TypeFor[*error]().Elem()
 	hasError := numOut > 0 && rt.Out(numOut-1) == reflect.TypeFor[*error]().Elem()
+	var resBodyType reflect.Type
+	if hasResult {
+		resBodyType = rt.Out(0)
+	}
 
 	return &Caller{
 		bodyExtractor: bodyExtractor,
@@ -82,6 +88,7 @@ func NewCaller(
 		params:        params,
 		reqBodyIndex:  reqBodyIndex,
 		reqBodyType:   reqBodyType,
+		resBodyType:   resBodyType,
 		hasResult:     hasResult,
 		hasError:      hasError,
 		errorIndex:    numOut - 1,
@@ -121,3 +128,16 @@ func (h *Caller) Handle(ctx context.Context, w http.ResponseWriter, r *http.Requ
 
 	return nil
 }
+
+// Wrap implements Wrappable, the Caller is already bound to the extractor and the mapper of the wrapper.
+func (h *Caller) Wrap(_ Wrapper) http2.HandlerFunc {
+	return h.Handle
+}
+
+// Describe implements Describer with the request body type and the first result type of the function.
+func (h *Caller) Describe() Descriptor {
+	return Descriptor{
+		RequestType:  h.reqBodyType,
+		ResponseType: h.resBodyType,
+	}
+}
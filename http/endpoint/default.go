@@ -12,6 +12,7 @@ import (
 // DefaultWrapper creates a pre-configured Wrapper with common middleware and settings.
 // It includes request logging, metrics collection, tracing, error handling, and recovery.
 // The default maximum request body size is 64MB.
+// LogLevel is not included, as it lets a client enable debug logs, add it to trusted endpoints only.
 func DefaultWrapper(logger log.Logger, logMiddleware LogMiddleware, restMiddlewares ...http.Middleware) Wrapper {
 	paramMappers := []ParamMapper{
 		ContextParam(),
@@ -0,0 +1,98 @@
+package endpoint
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// Descriptor describes the types an endpoint receives and returns.
+// Nil types mean the endpoint has no corresponding part.
+type Descriptor struct {
+	// ParamsType is the struct type filled from path, query and header parameters.
+	ParamsType reflect.Type
+	// RequestType is the type of the request body.
+	RequestType reflect.Type
+	// ResponseType is the type of the response body.
+	ResponseType reflect.Type
+}
+
+// Describer is implemented by Wrappable endpoints that know their request and response types.
+type Describer interface {
+	Describe() Descriptor
+}
+
+// DescribedHandler is an http.Handler that carries the Descriptor of the wrapped endpoint.
+// It is used to generate API documentation from registered routes.
+type DescribedHandler struct {
+	http.HandlerFunc
+
+	descriptor Descriptor
+}
+
+// Descriptor returns the description of the wrapped endpoint.
+func (h DescribedHandler) Descriptor() Descriptor {
+	return h.descriptor
+}
+
+// DescribedEndpoint wraps an endpoint and attaches its Descriptor to the returned handler.
+// A Wrappable implementation is wrapped like EndpointV2, any other function like Endpoint,
+// its request body and first result types are described. It panics if f is neither.
+// Wrappable endpoints that do not implement Describer get an empty Descriptor.
+func (m Wrapper) DescribedEndpoint(f any) DescribedHandler {
+	w, isWrappable := f.(Wrappable)
+	if !isWrappable {
+		caller, err := NewCaller(f, m.BodyExtractor, m.BodyMapper, m.ParamMappers)
+		if err != nil {
+			panic(err)
+		}
+		w = caller
+	}
+
+	handler := DescribedHandler{
+		HandlerFunc: m.EndpointV2(w),
+	}
+	describer, ok := w.(Describer)
+	if ok {
+		handler.descriptor = describer.Describe()
+	}
+	return handler
+}
+
+// Describe implements Describer for basic endpoints.
+func (fn basic[Req, Res]) Describe() Descriptor {
+	return Descriptor{
+		RequestType:  reflect.TypeFor[Req](),
+		ResponseType: reflect.TypeFor[Res](),
+	}
+}
+
+// Describe implements Describer for endpoints without response body.
+func (fn withoutResponseBody[Req]) Describe() Descriptor {
+	return Descriptor{
+		RequestType: reflect.TypeFor[Req](),
+	}
+}
+
+// Describe implements Describer for withParams endpoints.
+func (fn withParams[Params, Res]) Describe() Descriptor {
+	return Descriptor{
+		ParamsType:   reflect.TypeFor[Params](),
+		ResponseType: reflect.TypeFor[Res](),
+	}
+}
+
+// Describe implements Describer for withParamsAndBody endpoints.
+func (fn withParamsAndBody[Params, Req, Res]) Describe() Descriptor {
+	return Descriptor{
+		ParamsType:   reflect.TypeFor[Params](),
+		RequestType:  reflect.TypeFor[Req](),
+		ResponseType: reflect.TypeFor[Res](),
+	}
+}
+
+// Describe implements Describer for withParamsWithoutResponse endpoints.
+func (fn withParamsWithoutResponse[Params]) Describe() Descriptor {
+	return Descriptor{
+		ParamsType: reflect.TypeFor[Params](),
+	}
+}
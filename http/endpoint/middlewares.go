@@ -107,6 +107,27 @@ func RequestId() http2.Middleware {
 	}
 }
 
+// LogLevel is a middleware that overrides the log level for a single request.
+// The level is taken from the log.LevelHeader header, e.g. "debug",
+// and stored in the context with log.LevelToContext. Invalid levels are ignored.
+// The middleware lets a client enable debug logs of its requests, so it is not a part of DefaultWrapper
+// and should be used only for trusted endpoints, e.g. behind authentication.
+func LogLevel() http2.Middleware {
+	return func(next http2.HandlerFunc) http2.HandlerFunc {
+		return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			value := r.Header.Get(log.LevelHeader)
+			if value != "" {
+				level, err := log.ParseLevel(value)
+				if err == nil {
+					ctx = log.LevelToContext(ctx, level)
+				}
+			}
+
+			return next(ctx, w, r)
+		}
+	}
+}
+
 // sentryRequest creates a Sentry request object from an http.Request.
 // It determines the protocol based on TLS or X-Forwarded-Proto header.
 func sentryRequest(r *http.Request) *sentry.Request {
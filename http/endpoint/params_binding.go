@@ -0,0 +1,299 @@
+package endpoint
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/http/apierrors"
+	"github.com/txix-open/isp-kit/http/router"
+	"github.com/txix-open/isp-kit/validator"
+)
+
+const (
+	// PathTag is the struct tag used to bind a field to a router path parameter.
+	PathTag = "path"
+	// QueryTag is the struct tag used to bind a field to a URL query parameter.
+	QueryTag = "query"
+	// HeaderTag is the struct tag used to bind a field to a request header.
+	HeaderTag = "header"
+	// DefaultTag is the struct tag holding a value used when the parameter is absent.
+	// For slice fields the default value is split by commas.
+	DefaultTag = "default"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	durationType        = reflect.TypeFor[time.Duration]()
+)
+
+// paramField describes a single struct field bound to a request parameter.
+type paramField struct {
+	index        []int
+	source       string
+	name         string
+	detailsKey   string
+	defaultValue []string
+}
+
+// paramsBinder fills structs of a specific type from path, query and header parameters.
+type paramsBinder struct {
+	fields   []paramField
+	hasQuery bool
+}
+
+// newParamsBinder inspects the struct tags of T and prepares a binder for it.
+// It returns an error if T is not a struct or a tagged field has an unsupported type.
+func newParamsBinder[T any]() (*paramsBinder, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, errors.Errorf("params type %s must be a struct", typ)
+	}
+
+	binder := &paramsBinder{}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		source, name, ok := paramSource(field)
+		if !ok {
+			continue
+		}
+		if !isBindableType(field.Type) {
+			return nil, errors.Errorf("params type %s: unsupported type %s of field %s", typ, field.Type, field.Name)
+		}
+
+		paramField := paramField{
+			index:      field.Index,
+			source:     source,
+			name:       name,
+			detailsKey: lowerFirst(field.Name),
+		}
+		defaultValue, hasDefault := field.Tag.Lookup(DefaultTag)
+		if hasDefault {
+			paramField.defaultValue = []string{defaultValue}
+			if isSliceType(field.Type) {
+				paramField.defaultValue = strings.Split(defaultValue, ",")
+			}
+		}
+		binder.hasQuery = binder.hasQuery || source == QueryTag
+		binder.fields = append(binder.fields, paramField)
+	}
+	return binder, nil
+}
+
+// mustParamsBinder is like newParamsBinder but panics on error.
+// Endpoints are built at startup, so an invalid params type is a programming error.
+func mustParamsBinder[T any]() *paramsBinder {
+	binder, err := newParamsBinder[T]()
+	if err != nil {
+		panic(err)
+	}
+	return binder
+}
+
+// Bind fills ptr from the request parameters and validates it using validator.Default.
+// Conversion and validation failures are returned as a business error with per-field details.
+func (b *paramsBinder) Bind(ctx context.Context, r *http.Request, ptr any) error {
+	var (
+		elem    = reflect.ValueOf(ptr).Elem()
+		params  = router.ParamsFromContext(ctx)
+		query   url.Values
+		details = make(map[string]any)
+	)
+	if b.hasQuery {
+		query = r.URL.Query()
+	}
+
+	for _, field := range b.fields {
+		var values []string
+		switch field.source {
+		case PathTag:
+			for _, param := range params {
+				if param.Key == field.name {
+					values = []string{param.Value}
+					break
+				}
+			}
+		case QueryTag:
+			values = query[field.name]
+		case HeaderTag:
+			values = r.Header.Values(field.name)
+		}
+		if len(values) == 0 {
+			values = field.defaultValue
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		err := setParamValue(elem.FieldByIndex(field.index), values)
+		if err != nil {
+			details[field.detailsKey] = fmt.Sprintf("invalid %s parameter '%s': %v", field.source, field.name, err)
+		}
+	}
+	if len(details) > 0 {
+		return apierrors.NewBusinessError(
+			http.StatusBadRequest,
+			"invalid request parameters",
+			errors.Errorf("bind errors: %v", details),
+		).WithDetails(details)
+	}
+
+	ok, validationDetails := validator.Default.Validate(ptr)
+	if ok {
+		return nil
+	}
+	formattedDetails := formatDetails(validationDetails)
+	return apierrors.NewBusinessError(
+		http.StatusBadRequest,
+		"invalid request parameters",
+		errors.Errorf("validation errors: %v", formattedDetails),
+	).WithDetails(formattedDetails)
+}
+
+// extractParams creates a value of type T and fills it using the binder.
+func extractParams[T any](ctx context.Context, binder *paramsBinder, r *http.Request) (T, error) {
+	var params T
+	err := binder.Bind(ctx, r, &params)
+	if err != nil {
+		return *new(T), err
+	}
+	return params, nil
+}
+
+// paramSource returns the parameter source and name declared by the field tags.
+func paramSource(field reflect.StructField) (string, string, bool) {
+	for _, source := range []string{PathTag, QueryTag, HeaderTag} {
+		name, ok := field.Tag.Lookup(source)
+		if ok && name != "" && name != "-" {
+			return source, name, true
+		}
+	}
+	return "", "", false
+}
+
+// isSliceType reports whether values of t are bound from multiple parameter values.
+func isSliceType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// isBindableType reports whether a field of type t can be filled from string parameters.
+func isBindableType(t reflect.Type) bool {
+	if isSliceType(t) {
+		return isScalarType(t.Elem())
+	}
+	return isScalarType(t)
+}
+
+// isScalarType reports whether a single string can be converted to a value of type t.
+func isScalarType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		return isScalarType(t.Elem())
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setParamValue converts raw parameter values and stores the result in v.
+func setParamValue(v reflect.Value, values []string) error {
+	if !isSliceType(v.Type()) {
+		return setScalarValue(v, values[0])
+	}
+
+	slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		err := setScalarValue(slice.Index(i), value)
+		if err != nil {
+			return err
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// setScalarValue converts a single raw value and stores the result in v.
+// nolint:cyclop
+func setScalarValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		err := setScalarValue(ptr.Elem(), value)
+		if err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+
+	unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	if ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// lowerFirst lowercases the first letter of s.
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
@@ -0,0 +1,143 @@
+package endpoint_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/http/apierrors"
+	"github.com/txix-open/isp-kit/http/endpoint"
+	"github.com/txix-open/isp-kit/http/endpoint/httplog"
+	"github.com/txix-open/isp-kit/http/httpcli"
+	"github.com/txix-open/isp-kit/http/router"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/test"
+	"github.com/txix-open/isp-kit/test/httpt"
+)
+
+type getItemsParams struct {
+	Id      int64         `path:"id" validate:"required"`
+	Limit   int           `query:"limit" default:"10" validate:"max=100"`
+	Tags    []string      `query:"tag" default:"a,b"`
+	Timeout time.Duration `query:"timeout" default:"1s"`
+	Active  *bool         `query:"active"`
+	Tenant  string        `header:"x-tenant" validate:"required"`
+	Ignored string
+}
+
+type getItemsBody struct {
+	Name string `validate:"required"`
+}
+
+type getItemsResponse struct {
+	Params getItemsParams
+	Name   string
+}
+
+func newParamsTestClient(t *testing.T) *httpcli.Client {
+	t.Helper()
+	test, _ := test.New(t)
+	w := endpoint.DefaultWrapper(test.Logger(), httplog.Noop())
+
+	r := router.New()
+	r.GET("/items/:id", w.EndpointV2(endpoint.NewWithParams(
+		func(ctx context.Context, params getItemsParams) (getItemsResponse, error) {
+			return getItemsResponse{Params: params}, nil
+		},
+	)))
+	r.POST("/items/:id", w.EndpointV2(endpoint.NewWithParamsAndBody(
+		func(ctx context.Context, params getItemsParams, req getItemsBody) (getItemsResponse, error) {
+			return getItemsResponse{Params: params, Name: req.Name}, nil
+		},
+	)))
+	_, cli := httpt.TestServer(test, r)
+	return cli
+}
+
+func TestNewWithParams(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	cli := newParamsTestClient(t)
+
+	resp := getItemsResponse{}
+	err := cli.Get("/items/42").
+		QueryParams(map[string]any{"active": true}).
+		Header("X-Tenant", "tenant").
+		JsonResponseBody(&resp).
+		StatusCodeToError().
+		DoWithoutResponse(t.Context())
+	require.NoError(err)
+	active := true
+	require.Equal(getItemsParams{
+		Id:      42,
+		Limit:   10,
+		Tags:    []string{"a", "b"},
+		Timeout: time.Second,
+		Active:  &active,
+		Tenant:  "tenant",
+	}, resp.Params)
+
+	resp = getItemsResponse{}
+	err = cli.Post("/items/7").
+		QueryParams(map[string]any{"limit": 5, "tag": "c"}).
+		Header("X-Tenant", "tenant").
+		JsonRequestBody(getItemsBody{Name: "item"}).
+		JsonResponseBody(&resp).
+		StatusCodeToError().
+		DoWithoutResponse(t.Context())
+	require.NoError(err)
+	require.EqualValues(7, resp.Params.Id)
+	require.EqualValues(5, resp.Params.Limit)
+	require.Equal([]string{"c"}, resp.Params.Tags)
+	require.Nil(resp.Params.Active)
+	require.Equal("item", resp.Name)
+}
+
+func TestNewWithParams_Errors(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	cli := newParamsTestClient(t)
+
+	resp, err := cli.Get("/items/abc").
+		Header("X-Tenant", "tenant").
+		Do(t.Context())
+	require.NoError(err)
+	require.Equal(http.StatusBadRequest, resp.StatusCode())
+	apiErr := apierrors.Error{}
+	body, err := resp.BodyCopy()
+	require.NoError(err)
+	err = json.Unmarshal(body, &apiErr)
+	require.NoError(err)
+	require.Equal("invalid request parameters", apiErr.ErrorMessage)
+	require.Contains(apiErr.Details, "id")
+
+	resp, err = cli.Get("/items/1").
+		QueryParams(map[string]any{"limit": 1000}).
+		Do(t.Context())
+	require.NoError(err)
+	require.Equal(http.StatusBadRequest, resp.StatusCode())
+	apiErr = apierrors.Error{}
+	body, err = resp.BodyCopy()
+	require.NoError(err)
+	err = json.Unmarshal(body, &apiErr)
+	require.NoError(err)
+	require.Contains(apiErr.Details, "limit")
+	require.Contains(apiErr.Details, "tenant")
+}
+
+func TestNewWithParams_UnsupportedType(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	type invalidParams struct {
+		Value map[string]string `query:"value"`
+	}
+	w := endpoint.DefaultWrapper(nil, httplog.Noop())
+	require.Panics(func() {
+		w.EndpointV2(endpoint.NewWithParamsWithoutResponse(func(ctx context.Context, params invalidParams) error {
+			return nil
+		}))
+	})
+}
@@ -90,6 +90,89 @@ func (fn defaultHttp) Wrap(wrapper Wrapper) http2.HandlerFunc {
 	return http2.HandlerFunc(fn)
 }
 
+// withParams is an endpoint type that receives a struct filled from path, query and header parameters.
+type withParams[Params any, Res any] func(ctx context.Context, params Params) (Res, error)
+
+// NewWithParams creates an endpoint whose request struct is filled from tagged fields:
+// `path:"id"`, `query:"limit"` and `header:"x-foo"`, with optional `default:"10"` values.
+// The struct is validated using validator.Default. It panics on Wrap if Params has unsupported field types.
+func NewWithParams[Params any, Res any](fn func(ctx context.Context, params Params) (Res, error)) withParams[Params, Res] {
+	return fn
+}
+
+// Wrap implements Wrappable for withParams endpoints.
+func (fn withParams[Params, Res]) Wrap(wrapper Wrapper) http2.HandlerFunc {
+	binder := mustParamsBinder[Params]()
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		params, err := extractParams[Params](ctx, binder, r)
+		if err != nil {
+			return err
+		}
+
+		resp, err := fn(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		return wrapper.BodyMapper.Map(ctx, resp, w)
+	}
+}
+
+// withParamsAndBody is an endpoint type that receives both bound parameters and a request body.
+type withParamsAndBody[Params any, Req any, Res any] func(ctx context.Context, params Params, req Req) (Res, error)
+
+// NewWithParamsAndBody creates an endpoint that receives parameters bound as in NewWithParams
+// and a request body extracted by the wrapper's BodyExtractor.
+func NewWithParamsAndBody[Params any, Req any, Res any](
+	fn func(ctx context.Context, params Params, req Req) (Res, error),
+) withParamsAndBody[Params, Req, Res] {
+	return fn
+}
+
+// Wrap implements Wrappable for withParamsAndBody endpoints.
+func (fn withParamsAndBody[Params, Req, Res]) Wrap(wrapper Wrapper) http2.HandlerFunc {
+	binder := mustParamsBinder[Params]()
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		params, err := extractParams[Params](ctx, binder, r)
+		if err != nil {
+			return err
+		}
+
+		req, err := extractBody[Req](ctx, wrapper, r)
+		if err != nil {
+			return err
+		}
+
+		resp, err := fn(ctx, params, req)
+		if err != nil {
+			return err
+		}
+
+		return wrapper.BodyMapper.Map(ctx, resp, w)
+	}
+}
+
+// withParamsWithoutResponse is an endpoint type that receives bound parameters and returns no response body.
+type withParamsWithoutResponse[Params any] func(ctx context.Context, params Params) error
+
+// NewWithParamsWithoutResponse creates an endpoint that receives parameters bound as in NewWithParams
+// without returning a response body.
+func NewWithParamsWithoutResponse[Params any](fn func(ctx context.Context, params Params) error) withParamsWithoutResponse[Params] {
+	return fn
+}
+
+// Wrap implements Wrappable for withParamsWithoutResponse endpoints.
+func (fn withParamsWithoutResponse[Params]) Wrap(wrapper Wrapper) http2.HandlerFunc {
+	binder := mustParamsBinder[Params]()
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		params, err := extractParams[Params](ctx, binder, r)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, params)
+	}
+}
+
 // extractBody extracts and unmarshals the request body into the target type.
 // It uses the wrapper's BodyExtractor to handle the request body.
 func extractBody[T any](ctx context.Context, w Wrapper, r *http.Request) (T, error) {
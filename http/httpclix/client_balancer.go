@@ -16,13 +16,16 @@ const (
 )
 
 // ClientBalancer is an HTTP client that distributes requests across multiple
-// hosts using round-robin load balancing.
+// hosts using a pluggable lb.Balancer, round-robin by default.
+//
+// Transport errors and 5xx responses are reported to the balancer as failures,
+// which drives outlier ejection if it is enabled for the balancer.
 //
 // It embeds httpcli.Client and extends it with host management functionality.
 type ClientBalancer struct {
 	*httpcli.Client
 
-	hostManager *lb.RoundRobin
+	hostManager lb.Balancer
 	schema      string
 }
 
@@ -41,9 +44,16 @@ func NewClientBalancer(initialHosts []string, opts ...Option) *ClientBalancer {
 	}
 	initialHosts = addSchemaToHosts(options.schema, initialHosts)
 
+	hostManager := options.balancer
+	if hostManager == nil {
+		hostManager = lb.NewRoundRobin(initialHosts)
+	} else if len(initialHosts) > 0 {
+		hostManager.Upgrade(initialHosts)
+	}
+
 	return &ClientBalancer{
 		Client:      httpClient(options.cli, options.clientOpts...),
-		hostManager: lb.NewRoundRobin(initialHosts),
+		hostManager: hostManager,
 		schema:      options.schema,
 	}
 }
@@ -81,19 +91,28 @@ func (c *ClientBalancer) Patch(method string) *httpcli.RequestBuilder {
 // Execute sends an HTTP request using the provided builder and the load balancer.
 //
 // If GlobalRequestConfig.BaseUrl is set, the request is sent to that URL.
-// Otherwise, the next host from the round-robin balancer is selected and the
-// request path is appended to it.
+// Otherwise, a host is acquired from the balancer using the key from lb.KeyFromContext
+// and the request path is appended to it.
 func (c *ClientBalancer) Execute(ctx context.Context, builder *httpcli.RequestBuilder) (*httpcli.Response, error) {
 	if c.GlobalRequestConfig().BaseUrl != "" {
 		return c.Client.Execute(ctx, builder)
 	}
 
-	host, err := c.hostManager.Next()
+	host, done, err := c.hostManager.Acquire(lb.KeyFromContext(ctx))
 	if err != nil {
-		return nil, errors.WithMessage(err, "host manager next")
+		return nil, errors.WithMessage(err, "host manager acquire")
 	}
 
-	return c.Client.Execute(ctx, builder.BaseUrl(host))
+	resp, err := c.Client.Execute(ctx, builder.BaseUrl(host))
+	switch {
+	case err != nil:
+		done(err)
+	case resp != nil && resp.StatusCode() >= http.StatusInternalServerError:
+		done(errors.Errorf("unexpected status code %d", resp.StatusCode()))
+	default:
+		done(nil)
+	}
+	return resp, err
 }
 
 // Upgrade replaces the current set of hosts with a new set.
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/txix-open/isp-kit/http/httpcli"
+	"github.com/txix-open/isp-kit/lb"
 )
 
 // clientBalancerOptions holds configuration options for ClientBalancer.
@@ -11,6 +12,7 @@ type clientBalancerOptions struct {
 	cli        *http.Client
 	clientOpts []httpcli.Option
 	schema     string
+	balancer   lb.Balancer
 }
 
 // Option is a function that configures a ClientBalancer.
@@ -36,3 +38,12 @@ func WithClient(cli *http.Client) Option {
 		c.cli = cli
 	}
 }
+
+// WithBalancer sets a load balancing strategy for the ClientBalancer.
+// By default lb.RoundRobin is used.
+// Initial hosts passed to NewClientBalancer, if any, replace the hosts of the balancer.
+func WithBalancer(balancer lb.Balancer) Option {
+	return func(c *clientBalancerOptions) {
+		c.balancer = balancer
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"net/http/httptest"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/txix-open/isp-kit/http/httpclix"
+	"github.com/txix-open/isp-kit/lb"
 )
 
 func TestClientBalancer_NoHosts(t *testing.T) {
@@ -31,10 +33,12 @@ func TestClientBalancer(t *testing.T) {
 		callCounters = make([]atomic.Int32, hostCount)
 	)
 	for i := range hostCount {
-		hosts[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			assert.EqualValues("/some/endpoint", r.URL.Path)
 			callCounters[i].Add(1)
-		})).URL
+		}))
+		t.Cleanup(srv.Close)
+		hosts[i] = srv.URL
 	}
 
 	var (
@@ -83,15 +87,19 @@ func TestClientBalancer_GlobalConfigBaseUrl(t *testing.T) {
 		callCounter atomic.Int32
 	)
 
-	baseUrl := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	baseSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.EqualValues("/some/endpoint", r.URL.Path)
 		callCounter.Add(1)
-	})).URL
+	}))
+	t.Cleanup(baseSrv.Close)
+	baseUrl := baseSrv.URL
 
-	hostToIgnore := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	ignoredSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.EqualValues("/some/endpoint", r.URL.Path)
 		callCounter.Add(-1)
-	})).URL
+	}))
+	t.Cleanup(ignoredSrv.Close)
+	hostToIgnore := ignoredSrv.URL
 
 	/* use baseUrl if defined else passed hosts */
 	cli := httpclix.NewClientBalancer([]string{hostToIgnore})
@@ -103,3 +111,33 @@ func TestClientBalancer_GlobalConfigBaseUrl(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(1, callCounter.Load())
 }
+
+func TestClientBalancer_OutlierEjection(t *testing.T) {
+	t.Parallel()
+	var (
+		require      = require.New(t)
+		healthyCalls atomic.Int32
+		brokenCalls  atomic.Int32
+	)
+
+	healthySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthyCalls.Add(1)
+	}))
+	t.Cleanup(healthySrv.Close)
+	brokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		brokenCalls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(brokenSrv.Close)
+	healthy, broken := healthySrv.URL, brokenSrv.URL
+
+	cli := httpclix.NewClientBalancer(
+		[]string{healthy, broken},
+		httpclix.WithBalancer(lb.NewPowerOfTwoChoices(nil, lb.WithOutlierEjection(3, time.Minute, time.Minute))),
+	)
+	for range 50 {
+		_ = cli.Get("/some/endpoint").DoWithoutResponse(t.Context())
+	}
+	require.EqualValues(3, brokenCalls.Load())
+	require.EqualValues(47, healthyCalls.Load())
+}
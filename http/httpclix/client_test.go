@@ -3,14 +3,19 @@ package httpclix_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/breaker"
 	"github.com/txix-open/isp-kit/http/apierrors"
 	"github.com/txix-open/isp-kit/http/endpoint"
 	"github.com/txix-open/isp-kit/http/httpcli"
 	"github.com/txix-open/isp-kit/http/httpclix"
 	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/http_metrics"
 	"github.com/txix-open/isp-kit/requestid"
 	"github.com/txix-open/isp-kit/test"
@@ -90,3 +95,29 @@ func TestLogHeaders(t *testing.T) {
 	require.NoError(err)
 	require.True(resp.IsSuccess())
 }
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	calls := int32(0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	group := breaker.NewGroup("test", breaker.WithMinRequests(2), breaker.WithMetrics(metrics.NewRegistry()))
+	cli := httpcli.New(httpcli.WithMiddlewares(httpclix.CircuitBreaker(group)))
+
+	for range 2 {
+		resp, err := cli.Get(srv.URL).Do(t.Context())
+		require.NoError(err)
+		require.EqualValues(http.StatusServiceUnavailable, resp.StatusCode())
+	}
+
+	_, err := cli.Get(srv.URL).Do(t.Context())
+	require.ErrorIs(err, breaker.ErrOpen)
+	require.EqualValues(2, atomic.LoadInt32(&calls))
+	require.Equal(breaker.StateOpen, group.Get(srv.Listener.Addr().String()).State())
+}
@@ -2,9 +2,12 @@ package httpclix
 
 import (
 	"context"
+	"net/http"
 	"net/http/httptrace"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/breaker"
 	"github.com/txix-open/isp-kit/http/httpcli"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/http_metrics"
@@ -77,3 +80,34 @@ func Metrics(storage *http_metrics.ClientStorage) httpcli.Middleware {
 		})
 	}
 }
+
+// CircuitBreaker is a middleware that guards each host with its own breaker from the group.
+//
+// Transport errors and 5xx responses are counted as failures, requests cancelled by the caller
+// are not counted. When the breaker is open, the request is not sent and an error wrapping
+// breaker.ErrOpen is returned. Retries configured by RequestBuilder.Retry run inside the
+// middleware, so the whole sequence of attempts is counted as one call.
+func CircuitBreaker(group *breaker.Group) httpcli.Middleware {
+	return func(next httpcli.RoundTripper) httpcli.RoundTripper {
+		return httpcli.RoundTripperFunc(func(ctx context.Context, request *httpcli.Request) (*httpcli.Response, error) {
+			host := request.Raw.URL.Host
+			done, err := group.Get(host).Allow(ctx)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "host '%s'", host)
+			}
+
+			resp, err := next.RoundTrip(ctx, request)
+			switch {
+			case err != nil && ctx.Err() != nil:
+				done(breaker.ResultIgnored)
+			case err != nil:
+				done(breaker.ResultFailure)
+			case resp != nil && resp.Raw != nil && resp.StatusCode() >= http.StatusInternalServerError:
+				done(breaker.ResultFailure)
+			default:
+				done(breaker.ResultSuccess)
+			}
+			return resp, err
+		})
+	}
+}
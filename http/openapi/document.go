@@ -0,0 +1,72 @@
+// Package openapi generates OpenAPI 3.1 documents from routes registered in router.Router.
+// Request and response schemas are produced by the same generator as remote config schemas (rc/schema).
+package openapi
+
+import (
+	"github.com/txix-open/jsonschema"
+)
+
+const (
+	// Version is the OpenAPI specification version of generated documents.
+	Version = "3.1.0"
+	// JsonContentType is the media type used for request and response bodies.
+	JsonContentType = "application/json"
+	// ErrorSchemaName is the name of the apierrors.Error envelope schema in components.
+	ErrorSchemaName = "Error"
+)
+
+// Document is the root object of an OpenAPI document.
+type Document struct {
+	OpenApi    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lowercase HTTP methods to operations available on a single path.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationId string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a single path, query or header parameter.
+type Parameter struct {
+	Name     string             `json:"name"`
+	In       string             `json:"in"`
+	Required bool               `json:"required,omitempty"`
+	Schema   *jsonschema.Schema `json:"schema"`
+}
+
+// RequestBody describes a request body.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body for a specific media type.
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// Components holds reusable schemas referenced from operations.
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas,omitempty"`
+}
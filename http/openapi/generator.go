@@ -0,0 +1,260 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/txix-open/isp-kit/http/apierrors"
+	"github.com/txix-open/isp-kit/http/endpoint"
+	"github.com/txix-open/isp-kit/http/router"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/rc/schema"
+	"github.com/txix-open/jsonschema"
+)
+
+// describedHandler is implemented by handlers created with endpoint.Wrapper.DescribedEndpoint.
+type describedHandler interface {
+	Descriptor() endpoint.Descriptor
+}
+
+// Generator creates OpenAPI documents from registered routes.
+// Schemas are produced by rc/schema.Generator, so the same struct tags apply.
+type Generator struct {
+	schemaGenerator *schema.Generator
+}
+
+// NewGenerator creates a new Generator with the default schema generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		schemaGenerator: schema.NewGenerator(),
+	}
+}
+
+// Generate creates an OpenAPI document describing the provided routes.
+// Routes whose handlers were created with endpoint.Wrapper.DescribedEndpoint get their parameters,
+// request and response schemas, both for typed endpoints like endpoint.New and for functions
+// wrapped by reflection. Handlers created with Endpoint or EndpointV2 are plain functions
+// that do not carry their types, such routes are included with a minimal operation without schemas.
+// Every operation references the apierrors.Error envelope as its default response.
+func (g *Generator) Generate(info Info, routes []router.Route) Document {
+	doc := Document{
+		OpenApi: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: map[string]*jsonschema.Schema{
+				ErrorSchemaName: g.schemaOf(reflect.TypeFor[apierrors.Error]()),
+			},
+		},
+	}
+
+	for _, route := range routes {
+		descriptor := endpoint.Descriptor{}
+		handler, ok := route.Handler.(describedHandler)
+		if ok {
+			descriptor = handler.Descriptor()
+		}
+
+		path := openApiPath(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = g.operation(route, descriptor)
+	}
+
+	return doc
+}
+
+// operation builds an Operation from the endpoint descriptor.
+func (g *Generator) operation(route router.Route, descriptor endpoint.Descriptor) *Operation {
+	operation := &Operation{
+		OperationId: operationId(route.Method, route.Path),
+		Responses: map[string]Response{
+			"default": {
+				Description: "error",
+				Content: map[string]MediaType{
+					JsonContentType: {Schema: &jsonschema.Schema{Ref: "#/components/schemas/" + ErrorSchemaName}},
+				},
+			},
+		},
+	}
+
+	if descriptor.ParamsType != nil {
+		operation.Parameters = g.parameters(descriptor.ParamsType)
+	}
+
+	if descriptor.RequestType != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				JsonContentType: {Schema: g.schemaOf(descriptor.RequestType)},
+			},
+		}
+	}
+
+	success := Response{
+		Description: http.StatusText(http.StatusOK),
+	}
+	if descriptor.ResponseType != nil {
+		success.Content = map[string]MediaType{
+			JsonContentType: {Schema: g.schemaOf(descriptor.ResponseType)},
+		}
+	}
+	operation.Responses["200"] = success
+
+	return operation
+}
+
+// parameters describes the fields of a params struct bound by endpoint.NewWithParams.
+func (g *Generator) parameters(paramsType reflect.Type) []Parameter {
+	for paramsType.Kind() == reflect.Pointer {
+		paramsType = paramsType.Elem()
+	}
+	if paramsType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := make([]Parameter, 0)
+	for i := range paramsType.NumField() {
+		field := paramsType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		in, name, ok := parameterLocation(field)
+		if !ok {
+			continue
+		}
+
+		s := g.schemaOf(field.Type)
+		schema.SetProperties(field, s)
+		encodeDefaults(s)
+		_, required := schema.GetNameAndRequiredFlag(field)
+		params = append(params, Parameter{
+			Name:     name,
+			In:       in,
+			Required: required || in == endpoint.PathTag,
+			Schema:   s,
+		})
+	}
+	return params
+}
+
+// schemaOf generates a schema for t without the $schema and $id keywords.
+func (g *Generator) schemaOf(t reflect.Type) *jsonschema.Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	reflector := *g.schemaGenerator.Reflector
+	reflector.Anonymous = true
+	if t.Kind() != reflect.Struct {
+		reflector.ExpandedStruct = false
+	}
+	s := reflector.ReflectFromType(t)
+	s.Version = ""
+	encodeDefaults(s)
+	return s
+}
+
+// encodeDefaults converts default values taken from the "default" tag as strings
+// to the type of their schemas, e.g. "10" becomes 10 for an integer schema.
+// Values that cannot be converted are left as strings.
+func encodeDefaults(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+
+	value, ok := s.Default.(string)
+	if ok {
+		s.Default = typedDefault(s.Type, value)
+	}
+
+	children := make([]*jsonschema.Schema, 0)
+	children = append(children, s.Items, s.AdditionalProperties, s.Not, s.If, s.Then, s.Else)
+	children = append(children, s.PrefixItems...)
+	children = append(children, s.AllOf...)
+	children = append(children, s.AnyOf...)
+	children = append(children, s.OneOf...)
+	for _, child := range s.PatternProperties {
+		children = append(children, child)
+	}
+	for _, child := range s.Definitions {
+		children = append(children, child)
+	}
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			children = append(children, pair.Value)
+		}
+	}
+	for _, child := range children {
+		encodeDefaults(child)
+	}
+}
+
+// typedDefault parses the default value according to the schema type.
+func typedDefault(schemaType string, value string) any {
+	switch schemaType {
+	case "integer":
+		result, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return result
+		}
+	case "number":
+		result, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return result
+		}
+	case "boolean":
+		result, err := strconv.ParseBool(value)
+		if err == nil {
+			return result
+		}
+	case "array", "object":
+		var result any
+		err := json.Unmarshal([]byte(value), &result)
+		if err == nil {
+			return result
+		}
+	}
+	return value
+}
+
+// parameterLocation returns the OpenAPI location and name of a params struct field.
+func parameterLocation(field reflect.StructField) (string, string, bool) {
+	for _, in := range []string{endpoint.PathTag, endpoint.QueryTag, endpoint.HeaderTag} {
+		name, ok := field.Tag.Lookup(in)
+		if ok && name != "" && name != "-" {
+			return in, name, true
+		}
+	}
+	return "", "", false
+}
+
+// openApiPath converts httprouter path parameters (:id, *path) to OpenAPI templates ({id}, {path}).
+func openApiPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operationId builds a unique operation identifier from the method and path.
+func operationId(method string, path string) string {
+	id := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == ':' || r == '*':
+			return -1
+		default:
+			return '_'
+		}
+	}, strings.ToLower(method)+path)
+	return strings.Trim(id, "_")
+}
@@ -0,0 +1,109 @@
+package openapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/http/endpoint"
+	"github.com/txix-open/isp-kit/http/endpoint/httplog"
+	"github.com/txix-open/isp-kit/http/openapi"
+	"github.com/txix-open/isp-kit/http/router"
+	"github.com/txix-open/isp-kit/json"
+)
+
+type getUserParams struct {
+	Id    int64 `path:"id"`
+	Limit int   `query:"limit" default:"10" validate:"max=100"`
+	Full  bool  `query:"full" default:"true"`
+}
+
+type createUserRequest struct {
+	Name  string  `validate:"required"`
+	Score float64 `default:"0.5"`
+}
+
+type user struct {
+	Id   int64
+	Name string
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	w := endpoint.DefaultWrapper(nil, httplog.Noop())
+	r := router.New()
+	r.GET("/users/:id", w.DescribedEndpoint(endpoint.NewWithParams(
+		func(ctx context.Context, params getUserParams) (user, error) {
+			return user{}, nil
+		},
+	)))
+	r.POST("/users", w.DescribedEndpoint(endpoint.New(
+		func(ctx context.Context, req createUserRequest) ([]user, error) {
+			return nil, nil
+		},
+	)))
+	r.PUT("/users/:id", w.DescribedEndpoint(func(ctx context.Context, req createUserRequest) (*user, error) {
+		return &user{}, nil
+	}))
+	r.POST("/raw", w.EndpointV2(endpoint.NewWithRequest(func(ctx context.Context, r *http.Request) error {
+		return nil
+	})))
+
+	doc := openapi.NewGenerator().Generate(openapi.Info{Title: "users", Version: "1.0.0"}, r.Routes())
+	require.Equal(openapi.Version, doc.OpenApi)
+	require.Len(doc.Paths, 3)
+	require.Contains(doc.Components.Schemas, openapi.ErrorSchemaName)
+
+	get := doc.Paths["/users/{id}"]["get"]
+	require.NotNil(get)
+	require.Equal("get_users_id", get.OperationId)
+	require.Nil(get.RequestBody)
+	require.Len(get.Parameters, 3)
+	require.Equal("id", get.Parameters[0].Name)
+	require.Equal("path", get.Parameters[0].In)
+	require.True(get.Parameters[0].Required)
+	require.Equal("integer", get.Parameters[0].Schema.Type)
+	require.Equal("limit", get.Parameters[1].Name)
+	require.Equal("query", get.Parameters[1].In)
+	require.False(get.Parameters[1].Required)
+	require.EqualValues(10, get.Parameters[1].Schema.Default)
+	require.EqualValues("100", get.Parameters[1].Schema.Maximum)
+	require.Equal(true, get.Parameters[2].Schema.Default)
+	require.Equal("object", get.Responses["200"].Content[openapi.JsonContentType].Schema.Type)
+
+	post := doc.Paths["/users"]["post"]
+	require.NotNil(post)
+	require.NotNil(post.RequestBody)
+	reqSchema := post.RequestBody.Content[openapi.JsonContentType].Schema
+	require.Equal([]string{"name"}, reqSchema.Required)
+	score, ok := reqSchema.Properties.Get("score")
+	require.True(ok)
+	require.InDelta(0.5, score.Default, 0)
+	require.Equal("array", post.Responses["200"].Content[openapi.JsonContentType].Schema.Type)
+	require.Equal(
+		"#/components/schemas/"+openapi.ErrorSchemaName,
+		post.Responses["default"].Content[openapi.JsonContentType].Schema.Ref,
+	)
+
+	put := doc.Paths["/users/{id}"]["put"]
+	require.NotNil(put)
+	require.NotNil(put.RequestBody)
+	require.Equal([]string{"name"}, put.RequestBody.Content[openapi.JsonContentType].Schema.Required)
+	require.Equal("object", put.Responses["200"].Content[openapi.JsonContentType].Schema.Type)
+
+	raw := doc.Paths["/raw"]["post"]
+	require.NotNil(raw)
+	require.Equal("post_raw", raw.OperationId)
+	require.Nil(raw.RequestBody)
+	require.Nil(raw.Responses["200"].Content)
+	require.Contains(raw.Responses, "default")
+
+	data, err := json.Marshal(doc)
+	require.NoError(err)
+	require.Contains(string(data), `"openapi":"3.1.0"`)
+	require.NotContains(string(data), `"$schema"`)
+	require.Contains(string(data), `"default":10`)
+}
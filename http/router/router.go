@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/txix-open/isp-kit/metrics/http_metrics"
@@ -14,10 +15,18 @@ import (
 // Params is an alias for httprouter.Params, representing URL path parameters.
 type Params = httprouter.Params
 
+// Route describes a handler registered in the Router.
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.Handler
+}
+
 // Router wraps httprouter.Router with automatic metrics collection for each endpoint.
 // It supports method-based routing (GET, POST, PUT, DELETE) and fluent API for route configuration.
 type Router struct {
 	router *httprouter.Router
+	routes []Route
 }
 
 // New creates a new Router instance with a fresh httprouter backend.
@@ -56,9 +65,20 @@ func (r *Router) DELETE(path string, handler http.Handler) *Router {
 // Returns the Router for fluent chaining.
 func (r *Router) Handler(method string, path string, handler http.Handler) *Router {
 	r.router.Handler(method, path, r.withMetricEndpoint(method, path, handler))
+	r.routes = append(r.routes, Route{
+		Method:  method,
+		Path:    path,
+		Handler: handler,
+	})
 	return r
 }
 
+// Routes returns all routes registered in the Router in registration order.
+// Handlers are returned as passed to Handler, without the metrics wrapper.
+func (r *Router) Routes() []Route {
+	return slices.Clone(r.routes)
+}
+
 // ServeHTTP implements the http.Handler interface and delegates to the underlying httprouter.
 func (r *Router) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	r.router.ServeHTTP(writer, request)
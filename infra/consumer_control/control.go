@@ -0,0 +1,93 @@
+// Package consumer_control provides HTTP endpoints to pause and resume
+// message consumers at runtime. Consumers are grouped by kind, e.g. "kafka"
+// or "rabbitmq", and are addressed by the name of the topic or queue.
+package consumer_control
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrConsumerNotFound is wrapped by errors of a Controller when no consumer reads the requested topic or queue.
+	ErrConsumerNotFound = errors.New("consumer not found")
+)
+
+// Muxer defines an interface for HTTP multiplexers that can register handlers.
+type Muxer interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Controller pauses and resumes consumers by the name of the topic or queue.
+// It is implemented by grmqx.Client, kafkax.Client and stompx.Client.
+// Errors wrapping ErrConsumerNotFound are responded with 404 Not Found.
+type Controller interface {
+	Pause(name string) error
+	Resume(name string) error
+}
+
+// RegisterHandlers registers pause and resume handlers for each kind of consumers
+// under the specified URL prefix. Handlers accept POST requests with the name
+// of the topic or queue in the "name" query parameter.
+//
+// Example:
+//
+//	consumer_control.RegisterHandlers("/admin", srv, map[string]consumer_control.Controller{
+//		"kafka":    kafkaCli,
+//		"rabbitmq": rmqCli,
+//	})
+//
+//	// curl -X POST "http://localhost:8080/admin/consumers/kafka/pause?name=events"
+func RegisterHandlers(prefix string, muxer Muxer, controllers map[string]Controller) {
+	for kind, controller := range controllers {
+		muxer.Handle(pausePath(prefix, kind), handler(controller.Pause))
+		muxer.Handle(resumePath(prefix, kind), handler(controller.Resume))
+	}
+}
+
+// Endpoints returns a list of all endpoint paths for the given prefix and kinds of consumers.
+func Endpoints(prefix string, kinds ...string) []string {
+	endpoints := make([]string, 0, len(kinds)*2) // nolint:mnd
+	for _, kind := range kinds {
+		endpoints = append(endpoints, pausePath(prefix, kind), resumePath(prefix, kind))
+	}
+	return endpoints
+}
+
+// handler creates an HTTP handler calling the action for the requested name.
+func handler(action func(name string) error) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writer.Header().Set("Allow", http.MethodPost)
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := request.URL.Query().Get("name")
+		if name == "" {
+			http.Error(writer, "query parameter 'name' is required", http.StatusBadRequest)
+			return
+		}
+
+		err := action(name)
+		switch {
+		case errors.Is(err, ErrConsumerNotFound):
+			http.Error(writer, err.Error(), http.StatusNotFound)
+			return
+		case err != nil:
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writer.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func pausePath(prefix string, kind string) string {
+	return fmt.Sprintf("%s/consumers/%s/pause", prefix, kind)
+}
+
+func resumePath(prefix string, kind string) string {
+	return fmt.Sprintf("%s/consumers/%s/resume", prefix, kind)
+}
@@ -0,0 +1,80 @@
+package consumer_control_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/infra/consumer_control"
+)
+
+type controllerMock struct {
+	paused map[string]bool
+}
+
+func (c *controllerMock) Pause(name string) error {
+	if name == "unknown" {
+		return errors.WithMessagef(consumer_control.ErrConsumerNotFound, "queue '%s'", name)
+	}
+	c.paused[name] = true
+	return nil
+}
+
+func (c *controllerMock) Resume(name string) error {
+	if name == "unknown" {
+		return errors.WithMessagef(consumer_control.ErrConsumerNotFound, "queue '%s'", name)
+	}
+	delete(c.paused, name)
+	return nil
+}
+
+func TestRegisterHandlers(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	controller := &controllerMock{paused: make(map[string]bool)}
+	mux := http.NewServeMux()
+	consumer_control.RegisterHandlers("/admin", mux, map[string]consumer_control.Controller{
+		"kafka": controller,
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	post := func(path string) int {
+		resp, err := http.Post(srv.URL+path, "", nil) // nolint:noctx
+		require.NoError(err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	require.EqualValues(http.StatusNoContent, post("/admin/consumers/kafka/pause?name=events"))
+	require.True(controller.paused["events"])
+
+	require.EqualValues(http.StatusNoContent, post("/admin/consumers/kafka/resume?name=events"))
+	require.False(controller.paused["events"])
+
+	require.EqualValues(http.StatusBadRequest, post("/admin/consumers/kafka/pause"))
+	require.EqualValues(http.StatusNotFound, post("/admin/consumers/kafka/pause?name=unknown"))
+	require.EqualValues(http.StatusNotFound, post("/admin/consumers/kafka/resume?name=unknown"))
+	require.EqualValues(http.StatusNotFound, post("/admin/consumers/rabbitmq/pause?name=events"))
+
+	resp, err := http.Get(srv.URL + "/admin/consumers/kafka/pause?name=events") // nolint:noctx
+	require.NoError(err)
+	_ = resp.Body.Close()
+	require.EqualValues(http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestEndpoints(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	endpoints := consumer_control.Endpoints("/admin", "kafka", "stomp")
+	require.Equal([]string{
+		"/admin/consumers/kafka/pause",
+		"/admin/consumers/kafka/resume",
+		"/admin/consumers/stomp/pause",
+		"/admin/consumers/stomp/resume",
+	}, endpoints)
+}
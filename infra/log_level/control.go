@@ -0,0 +1,87 @@
+// Package log_level provides an HTTP endpoint to list and change the levels
+// of the root logger and the named loggers at runtime.
+package log_level
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/txix-open/isp-kit/log"
+)
+
+// Muxer defines an interface for HTTP multiplexers that can register handlers.
+type Muxer interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Controller lists and changes the levels of loggers by name, the empty name means the root logger.
+// It is implemented by log.Adapter.
+type Controller interface {
+	Levels() []log.LoggerLevel
+	SetNamedLevel(name string, level log.Level, ttl time.Duration)
+	ResetNamedLevel(name string)
+}
+
+// RegisterHandlers registers the handler of the log levels under the specified URL prefix.
+// The handler accepts requests to {prefix}/log/levels:
+//   - GET returns the levels of all loggers as JSON
+//   - POST sets the level from the "level" query parameter for the logger from the "name" parameter,
+//     the optional "ttl" parameter, e.g. "10m", reverts the change afterwards
+//   - DELETE resets the level of the logger from the "name" parameter
+//
+// Example:
+//
+//	log_level.RegisterHandlers("/admin", srv, logger)
+//
+//	// curl -X POST "http://localhost:8080/admin/log/levels?name=kafka&level=debug&ttl=10m"
+func RegisterHandlers(prefix string, muxer Muxer, controller Controller) {
+	muxer.Handle(levelsPath(prefix), handler(controller))
+}
+
+// Endpoints returns a list of all endpoint paths for the given prefix.
+func Endpoints(prefix string) []string {
+	return []string{
+		levelsPath(prefix),
+	}
+}
+
+// handler creates an HTTP handler listing and changing the levels of loggers.
+func handler(controller Controller) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		name := query.Get("name")
+		switch request.Method {
+		case http.MethodGet:
+			writer.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(writer).Encode(controller.Levels())
+		case http.MethodPost:
+			level, err := log.ParseLevel(query.Get("level"))
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ttl time.Duration
+			if query.Has("ttl") {
+				ttl, err = time.ParseDuration(query.Get("ttl"))
+				if err != nil {
+					http.Error(writer, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			controller.SetNamedLevel(name, level, ttl)
+			writer.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			controller.ResetNamedLevel(name)
+			writer.WriteHeader(http.StatusNoContent)
+		default:
+			writer.Header().Set("Allow", fmt.Sprintf("%s, %s, %s", http.MethodGet, http.MethodPost, http.MethodDelete))
+			http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func levelsPath(prefix string) string {
+	return fmt.Sprintf("%s/log/levels", prefix)
+}
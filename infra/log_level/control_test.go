@@ -0,0 +1,78 @@
+package log_level_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/infra/log_level"
+	"github.com/txix-open/isp-kit/log"
+)
+
+func TestRegisterHandlers(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	logger, err := log.New(log.WithDisableDefaultOutput())
+	require.NoError(err)
+	kafkaLogger := logger.Named("kafka")
+	consumerLogger := kafkaLogger.Named("consumer")
+
+	mux := http.NewServeMux()
+	log_level.RegisterHandlers("/admin", mux, logger)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	send := func(method string, path string) int {
+		req, err := http.NewRequest(method, srv.URL+path, nil) // nolint:noctx
+		require.NoError(err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	levels := func() []log.LoggerLevel {
+		resp, err := http.Get(srv.URL + "/admin/log/levels") // nolint:noctx
+		require.NoError(err)
+		defer resp.Body.Close()
+		require.EqualValues(http.StatusOK, resp.StatusCode)
+		result := make([]log.LoggerLevel, 0)
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		require.NoError(err)
+		return result
+	}
+
+	require.Equal([]log.LoggerLevel{
+		{Name: "", Level: log.InfoLevel, Overridden: true},
+		{Name: "kafka", Level: log.InfoLevel},
+		{Name: "kafka.consumer", Level: log.InfoLevel},
+	}, levels())
+
+	require.EqualValues(http.StatusNoContent, send(http.MethodPost, "/admin/log/levels?name=kafka&level=debug"))
+	require.True(consumerLogger.Enabled(log.DebugLevel))
+	require.False(logger.Enabled(log.DebugLevel))
+	require.Equal([]log.LoggerLevel{
+		{Name: "", Level: log.InfoLevel, Overridden: true},
+		{Name: "kafka", Level: log.DebugLevel, Overridden: true},
+		{Name: "kafka.consumer", Level: log.DebugLevel},
+	}, levels())
+
+	require.EqualValues(http.StatusNoContent, send(http.MethodPost, "/admin/log/levels?name=kafka.consumer&level=error&ttl=50ms"))
+	require.False(consumerLogger.Enabled(log.WarnLevel))
+	require.Eventually(func() bool {
+		return consumerLogger.Enabled(log.DebugLevel)
+	}, time.Second, 10*time.Millisecond)
+
+	require.EqualValues(http.StatusNoContent, send(http.MethodDelete, "/admin/log/levels?name=kafka"))
+	require.False(kafkaLogger.Enabled(log.DebugLevel))
+
+	require.EqualValues(http.StatusNoContent, send(http.MethodPost, "/admin/log/levels?level=warn"))
+	require.False(consumerLogger.Enabled(log.InfoLevel))
+
+	require.EqualValues(http.StatusBadRequest, send(http.MethodPost, "/admin/log/levels?level=unknown"))
+	require.EqualValues(http.StatusBadRequest, send(http.MethodPost, "/admin/log/levels?level=info&ttl=abc"))
+	require.EqualValues(http.StatusMethodNotAllowed, send(http.MethodPut, "/admin/log/levels"))
+}
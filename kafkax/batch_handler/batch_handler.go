@@ -0,0 +1,201 @@
+// Package batch_handler provides batch message processing for Kafka consumers.
+// Messages are collected per partition and passed to the handler in batches,
+// the result of each message is decided individually.
+package batch_handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+)
+
+// BatchHandler processes collected batches of deliveries of a single partition.
+type BatchHandler interface {
+	Handle(ctx context.Context, batch []*consumer.Delivery)
+}
+
+// Handler accumulates messages per partition and processes them in batches.
+// It triggers processing of a partition batch when it reaches maxSize or when
+// the purgeInterval elapses since its first message was received.
+// Batches of different partitions are processed concurrently, batches of a partition
+// are processed one by one. While a batch of a partition is processed and the next one
+// is already collected, fetching of the partition is paused.
+// Handler implements consumer.Handler and must be used with a consumer with concurrency 1
+// to keep the order of messages.
+type Handler struct {
+	handler       BatchHandler
+	purgeInterval time.Duration
+	maxSize       int
+	c             chan batchItem
+	runner        *sync.Once
+	closed        bool
+	lock          sync.Locker
+}
+
+// batchItem is a received delivery with its context.
+type batchItem struct {
+	ctx      context.Context
+	delivery *consumer.Delivery
+}
+
+// topicPartition identifies a partition of a topic.
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// batch is a collected batch of a partition.
+type batch struct {
+	ctx        context.Context
+	deliveries []*consumer.Delivery
+	deadline   time.Time
+}
+
+// partitionState is the state of batches of a partition.
+type partitionState struct {
+	collecting *batch
+	ready      []*batch
+	processing bool
+	resume     func()
+}
+
+// New creates a new batch handler with the specified batch handler, purge interval, and max batch size.
+func New(handler BatchHandler, purgeInterval time.Duration, maxSize int) *Handler {
+	return &Handler{
+		handler:       handler,
+		purgeInterval: purgeInterval,
+		maxSize:       max(maxSize, 1),
+		c:             make(chan batchItem),
+		runner:        &sync.Once{},
+		lock:          &sync.Mutex{},
+	}
+}
+
+// Handle adds a message to the batch of its partition and triggers processing if needed.
+// If the handler is closed, the message is released without committing.
+func (r *Handler) Handle(ctx context.Context, delivery *consumer.Delivery) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		delivery.Done()
+		return
+	}
+
+	r.runner.Do(func() {
+		go r.run()
+	})
+	r.c <- batchItem{
+		ctx:      ctx,
+		delivery: delivery,
+	}
+}
+
+// Close processes collected batches immediately and prevents further message processing.
+func (r *Handler) Close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return
+	}
+	r.closed = true
+	close(r.c)
+}
+
+// run is the main loop that accumulates messages and dispatches collected batches
+// to processing. It returns after the handler is closed and all batches are processed.
+func (r *Handler) run() {
+	partitions := make(map[topicPartition]*partitionState)
+	processed := make(chan topicPartition)
+	items := r.c
+	timer := time.NewTimer(r.purgeInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case item, ok := <-items:
+			if !ok {
+				items = nil
+				for key, state := range partitions {
+					r.flush(key, state, processed)
+				}
+				break
+			}
+			source := item.delivery.Source()
+			key := topicPartition{topic: source.Topic, partition: source.Partition}
+			state, ok := partitions[key]
+			if !ok {
+				state = &partitionState{}
+				partitions[key] = state
+			}
+			if state.collecting == nil {
+				state.collecting = &batch{
+					ctx:      item.ctx,
+					deadline: time.Now().Add(r.purgeInterval),
+				}
+			}
+			state.collecting.deliveries = append(state.collecting.deliveries, item.delivery)
+			if len(state.collecting.deliveries) >= r.maxSize {
+				r.flush(key, state, processed)
+			}
+		case key := <-processed:
+			state := partitions[key]
+			state.processing = false
+			r.dispatch(key, state, processed)
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		nextDeadline := now.Add(r.purgeInterval)
+		for key, state := range partitions {
+			b := state.collecting
+			if b != nil && !b.deadline.After(now) {
+				r.flush(key, state, processed)
+			} else if b != nil && b.deadline.Before(nextDeadline) {
+				nextDeadline = b.deadline
+			}
+			if !state.processing && state.collecting == nil {
+				delete(partitions, key)
+			}
+		}
+		if items == nil && len(partitions) == 0 {
+			return
+		}
+		timer.Reset(time.Until(nextDeadline))
+	}
+}
+
+// flush moves the collected batch of the partition to the ready ones and dispatches it.
+func (r *Handler) flush(key topicPartition, state *partitionState, processed chan<- topicPartition) {
+	if state.collecting == nil {
+		return
+	}
+	state.ready = append(state.ready, state.collecting)
+	state.collecting = nil
+	r.dispatch(key, state, processed)
+}
+
+// dispatch starts processing of the next ready batch of the partition if the previous one is processed.
+// Fetching of the partition is paused while there are ready batches waiting for processing.
+func (r *Handler) dispatch(key topicPartition, state *partitionState, processed chan<- topicPartition) {
+	if !state.processing && len(state.ready) > 0 {
+		b := state.ready[0]
+		state.ready = state.ready[1:]
+		state.processing = true
+		go func() {
+			r.handler.Handle(b.ctx, b.deliveries)
+			processed <- key
+		}()
+	}
+
+	switch {
+	case len(state.ready) > 0 && state.resume == nil:
+		state.resume = state.ready[0].deliveries[0].PausePartition()
+	case len(state.ready) == 0 && state.resume != nil:
+		state.resume()
+		state.resume = nil
+	}
+}
@@ -0,0 +1,84 @@
+package batch_handler_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+)
+
+type batchHandlerFunc func(ctx context.Context, batch []*consumer.Delivery)
+
+func (f batchHandlerFunc) Handle(ctx context.Context, batch []*consumer.Delivery) {
+	f(ctx, batch)
+}
+
+func TestHandler_PartitionsAreProcessedConcurrently(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	blocked := make(chan struct{})
+	processed := make(chan int32, 2)
+	h := batch_handler.New(batchHandlerFunc(func(ctx context.Context, batch []*consumer.Delivery) {
+		partition := batch[0].Source().Partition
+		if partition == 0 {
+			<-blocked
+		}
+		for _, delivery := range batch {
+			delivery.Done()
+		}
+		processed <- partition
+	}), time.Hour, 1)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	h.Handle(t.Context(), consumer.NewDelivery(wg, nil, &kgo.Record{Topic: "test", Partition: 0}, "group"))
+	h.Handle(t.Context(), consumer.NewDelivery(wg, nil, &kgo.Record{Topic: "test", Partition: 1}, "group"))
+
+	select {
+	case partition := <-processed:
+		require.EqualValues(1, partition)
+	case <-time.After(5 * time.Second):
+		require.Fail("batch of partition 1 is blocked by partition 0")
+	}
+
+	close(blocked)
+	h.Close()
+	wg.Wait()
+	require.EqualValues(0, <-processed)
+}
+
+func TestHandler_CloseProcessesCollectedBatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	lock := &sync.Mutex{}
+	batches := make([][]int64, 0)
+	h := batch_handler.New(batchHandlerFunc(func(ctx context.Context, batch []*consumer.Delivery) {
+		offsets := make([]int64, 0, len(batch))
+		for _, delivery := range batch {
+			offsets = append(offsets, delivery.Source().Offset)
+			delivery.Done()
+		}
+		lock.Lock()
+		batches = append(batches, offsets)
+		lock.Unlock()
+	}), time.Hour, 2)
+
+	wg := &sync.WaitGroup{}
+	for offset := range int64(3) {
+		wg.Add(1)
+		h.Handle(t.Context(), consumer.NewDelivery(wg, nil, &kgo.Record{Topic: "test", Offset: offset}, "group"))
+	}
+	h.Close()
+	wg.Wait()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal([][]int64{{0, 1}, {2}}, batches)
+}
@@ -0,0 +1,119 @@
+package batch_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/handler"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/panic_recovery"
+)
+
+const recoverBatchRetryPeriod = 15 * time.Second
+
+// Metrics creates a middleware that records metrics for batch processing,
+// including duration, message size, and commit/retry counts of each message.
+// DLQ counts are recorded if the storage implements handler.ConsumerDlqMetricStorage.
+func Metrics(metricStorage handler.ConsumerMetricStorage) Middleware {
+	dlqMetricStorage, _ := metricStorage.(handler.ConsumerDlqMetricStorage)
+	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
+		return SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) BatchResult {
+			start := time.Now()
+
+			result := next.Handle(ctx, batch)
+
+			duration := time.Since(start)
+			for i, delivery := range batch {
+				topic := delivery.Source().Topic
+				consumerGroup := delivery.ConsumerGroupId()
+				metricStorage.ObserveConsumeDuration(consumerGroup, topic, duration)
+				metricStorage.ObserveConsumeMsgSize(consumerGroup, topic, len(delivery.Source().Value))
+
+				itemResult := result.Get(i)
+				switch {
+				case itemResult.Commit:
+					metricStorage.IncCommitCount(consumerGroup, topic)
+				case itemResult.Retry:
+					metricStorage.IncRetryCount(consumerGroup, topic)
+				case itemResult.MoveToDlq && dlqMetricStorage != nil:
+					dlqMetricStorage.IncDlqCount(consumerGroup, topic)
+				}
+			}
+
+			return result
+		})
+	}
+}
+
+// Log creates a middleware that logs the outcome of processing of each message in
+// the batch, including whether the message was committed, retried, moved to DLQ, or skipped.
+func Log(logger log.Logger) Middleware {
+	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
+		return SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) BatchResult {
+			result := next.Handle(ctx, batch)
+
+			for i, delivery := range batch {
+				topic := delivery.Source().Topic
+				partition := delivery.Source().Partition
+				offset := delivery.Source().Offset
+
+				itemResult := result.Get(i)
+				switch {
+				case itemResult.Commit:
+					logger.Debug(
+						ctx,
+						"kafka client: batch message will be committed",
+						log.String("topic", topic),
+						log.Int32("partition", partition),
+						log.Int64("offset", offset),
+					)
+				case itemResult.Retry:
+					logger.Error(
+						ctx,
+						"kafka client: batch message will be retried",
+						log.String("topic", topic),
+						log.Int32("partition", partition),
+						log.Int64("offset", offset),
+						log.Any("error", itemResult.RetryError),
+						log.String("retryAfter", itemResult.RetryAfter.String()),
+					)
+				case itemResult.MoveToDlq:
+					logger.Error(
+						ctx,
+						"kafka client: batch message will be moved to DLQ or dropped",
+						log.String("topic", topic),
+						log.Int32("partition", partition),
+						log.Int64("offset", offset),
+						log.Any("error", itemResult.DlqError),
+					)
+				default:
+					logger.Debug(
+						ctx,
+						"kafka client: batch message will be skipped",
+						log.String("topic", topic),
+						log.Int32("partition", partition),
+						log.Int64("offset", offset),
+					)
+				}
+			}
+
+			return result
+		})
+	}
+}
+
+// Recovery creates a middleware that recovers from panics during batch
+// processing. When a panic occurs, all messages of the batch are marked for
+// retry after a 15-second delay. It is recommended to place this middleware
+// early in the chain to ensure all handlers are protected.
+func Recovery() Middleware {
+	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
+		return SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) (res BatchResult) {
+			defer panic_recovery.Recover(func(err error) {
+				res = RetryAll(recoverBatchRetryPeriod, err)
+			})
+			return next.Handle(ctx, batch)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package batch_handler
+
+import (
+	"time"
+
+	"github.com/txix-open/isp-kit/kafkax/handler"
+)
+
+// BatchResult defines the outcome of batch processing. Each delivery of the batch
+// gets its own handler.Result: commit, retry, move to DLQ or skip.
+// Deliveries without an explicit result get the Default one.
+type BatchResult struct {
+	// Default is the result of deliveries without an explicit result, handler.Nothing() by default.
+	Default handler.Result
+	// Results are explicit results by the index of the delivery in the batch.
+	Results map[int]handler.Result
+}
+
+// CommitAll returns a BatchResult indicating all messages should be committed.
+func CommitAll() BatchResult {
+	return BatchResult{Default: handler.Commit()}
+}
+
+// RetryAll returns a BatchResult indicating all messages should be retried.
+func RetryAll(after time.Duration, err error) BatchResult {
+	return BatchResult{Default: handler.Retry(after, err)}
+}
+
+// MoveToDlqAll returns a BatchResult indicating all messages should be moved to the
+// dead letter topic.
+func MoveToDlqAll(err error) BatchResult {
+	return BatchResult{Default: handler.MoveToDlq(err)}
+}
+
+// Set sets the result of the delivery with index i in the batch.
+func (r *BatchResult) Set(i int, result handler.Result) {
+	if r.Results == nil {
+		r.Results = make(map[int]handler.Result)
+	}
+	r.Results[i] = result
+}
+
+// Get returns the result of the delivery with index i in the batch.
+func (r BatchResult) Get(i int) handler.Result {
+	result, ok := r.Results[i]
+	if ok {
+		return result
+	}
+	return r.Default
+}
@@ -0,0 +1,183 @@
+package batch_handler
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/handler"
+	"github.com/txix-open/isp-kit/log"
+)
+
+const republishErrorRetryPeriod = 15 * time.Second
+
+// SyncHandlerAdapter defines the interface for synchronous batch processing.
+// Implementations should return a BatchResult with the outcome of each delivery.
+type SyncHandlerAdapter interface {
+	Handle(ctx context.Context, batch []*consumer.Delivery) BatchResult
+}
+
+// Middleware is a function that wraps a SyncHandlerAdapter to add
+// cross-cutting functionality such as logging, metrics, or panic recovery.
+type Middleware func(next SyncHandlerAdapter) SyncHandlerAdapter
+
+// SyncHandlerAdapterFunc is an adapter that allows a function to be used as
+// a SyncHandlerAdapter.
+type SyncHandlerAdapterFunc func(ctx context.Context, batch []*consumer.Delivery) BatchResult
+
+// Handle implements the SyncHandlerAdapter interface by calling the underlying
+// function.
+func (a SyncHandlerAdapterFunc) Handle(ctx context.Context, batch []*consumer.Delivery) BatchResult {
+	return a(ctx, batch)
+}
+
+// Sync wraps a SyncHandlerAdapter with middleware support and applies the
+// results of batch processing to the deliveries.
+type Sync struct {
+	logger  log.Logger
+	handler SyncHandlerAdapter
+}
+
+// NewSync creates a new Sync instance with the provided logger, adapter, and
+// middlewares. Middlewares are applied in the order they are provided.
+func NewSync(logger log.Logger, adapter SyncHandlerAdapter, middlewares ...Middleware) Sync {
+	s := Sync{
+		logger: logger,
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		adapter = middlewares[i](adapter)
+	}
+	s.handler = adapter
+	return s
+}
+
+// item is a delivery of the batch with its processing state.
+type item struct {
+	delivery *consumer.Delivery
+	result   *handler.Result
+	applied  bool
+}
+
+// Handle processes a batch of deliveries of a single partition and applies their results
+// in offset order. Only the highest offset of the contiguous processed part of the batch
+// is committed. A delivery retried without a retry policy blocks the following ones and
+// is passed to the handler again after the retry delay, while the already decided results
+// of the following deliveries are kept. A failed move to DLQ is repeated without
+// processing the delivery again.
+func (r Sync) Handle(ctx context.Context, batch []*consumer.Delivery) {
+	if len(batch) == 0 {
+		return
+	}
+
+	items := make([]*item, 0, len(batch))
+	for _, delivery := range batch {
+		items = append(items, &item{delivery: delivery})
+	}
+	slices.SortStableFunc(items, func(a *item, b *item) int {
+		return cmp.Compare(a.delivery.Source().Offset, b.delivery.Source().Offset)
+	})
+
+	for {
+		pending := make([]*item, 0)
+		deliveries := make([]*consumer.Delivery, 0)
+		for _, item := range items {
+			if item.result == nil {
+				pending = append(pending, item)
+				deliveries = append(deliveries, item.delivery)
+			}
+		}
+		if len(pending) > 0 {
+			result := r.handler.Handle(ctx, deliveries)
+			for i, item := range pending {
+				itemResult := result.Get(i)
+				item.result = &itemResult
+			}
+		}
+
+		retryAfter, completed := r.apply(ctx, items)
+		if completed {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, item := range items {
+				if !item.applied {
+					item.delivery.Done()
+				}
+			}
+			return
+		case <-time.After(retryAfter):
+		}
+	}
+}
+
+// apply applies the results of the deliveries in offset order until a delivery has to be
+// processed again. Returns the delay before the next attempt and whether all results are applied.
+func (r Sync) apply(ctx context.Context, items []*item) (time.Duration, bool) {
+	var lastCommit *item
+	defer func() {
+		if lastCommit == nil {
+			return
+		}
+		err := lastCommit.delivery.Commit(ctx)
+		if err != nil {
+			r.logger.Error(
+				ctx, "kafka consumer: unexpected error during committing message",
+				log.Any("error", err),
+			)
+		}
+	}()
+
+	for _, item := range items {
+		if item.applied {
+			continue
+		}
+		result := *item.result
+		var err error
+		switch {
+		case result.Commit:
+			if lastCommit != nil {
+				lastCommit.delivery.Done()
+			}
+			lastCommit = item
+			item.applied = true
+			continue
+		case result.MoveToDlq:
+			err = item.delivery.MoveToDlq(ctx, result.DlqError)
+		case result.Retry:
+			err = item.delivery.Retry(ctx, result.RetryError)
+		default:
+			item.delivery.Done()
+			item.applied = true
+			continue
+		}
+
+		if err != nil && !errors.Is(err, consumer.ErrRetryPolicyNotSet) {
+			r.logger.Error(
+				ctx, "kafka consumer: unexpected error during republishing message",
+				log.Any("error", err),
+			)
+		}
+		if err == nil || item.delivery.Handled() {
+			// the offset of the republished message is committed along with all previous ones
+			if lastCommit != nil {
+				lastCommit.delivery.Done()
+				lastCommit = nil
+			}
+			item.applied = true
+			continue
+		}
+
+		if result.Retry {
+			item.result = nil
+			return result.RetryAfter, false
+		}
+		return republishErrorRetryPeriod, false
+	}
+	return 0, true
+}
@@ -2,7 +2,10 @@ package kafkax
 
 import (
 	"context"
+	"slices"
+
 	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/infra/consumer_control"
 	"github.com/txix-open/isp-kit/kafkax/consumer"
 	"github.com/txix-open/isp-kit/kafkax/publisher"
 	"github.com/txix-open/isp-kit/log"
@@ -11,6 +14,11 @@ import (
 	"sync"
 )
 
+var (
+	// ErrConsumerNotFound is returned when no consumer reads the requested topic.
+	ErrConsumerNotFound = consumer_control.ErrConsumerNotFound
+)
+
 // state represents the runtime state of a Client, including active publishers,
 // consumers, and the lifecycle observer.
 type state struct {
@@ -34,6 +42,7 @@ func (c *state) run(ctx context.Context) {
 type Client struct {
 	prevCfg Config
 	state   *state
+	paused  map[string]bool
 	lock    sync.Locker
 	logger  log.Logger
 }
@@ -43,6 +52,7 @@ func New(logger log.Logger) *Client {
 	return &Client{
 		prevCfg: Config{},
 		state:   nil,
+		paused:  make(map[string]bool),
 		lock:    &sync.Mutex{},
 		logger:  logger,
 	}
@@ -71,6 +81,7 @@ func (c *Client) UpgradeAndServe(ctx context.Context, config Config) {
 	}
 
 	c.state = c.newState(ctx, config)
+	c.applyPause()
 	c.state.run(ctx)
 
 	c.state.observer.ClientReady()
@@ -99,6 +110,80 @@ func (c *Client) Healthcheck(ctx context.Context) error {
 	return nil
 }
 
+// Pause stops fetching messages of consumers reading the topic, including consumers
+// reading it as a retry topic, until Resume is called. Messages which are already
+// fetched are still processed. The pause is kept when the configuration is upgraded.
+// Returns ErrConsumerNotFound if no consumer reads the topic.
+func (c *Client) Pause(topic string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	consumers := c.consumersOf(topic)
+	if len(consumers) == 0 {
+		return errors.WithMessagef(ErrConsumerNotFound, "topic '%s'", topic)
+	}
+	c.paused[topic] = true
+	for _, consumer := range consumers {
+		consumer.Pause()
+	}
+	return nil
+}
+
+// Resume resumes fetching messages of consumers paused by Pause. A consumer
+// reading several topics is resumed when all of them are resumed.
+// Returns ErrConsumerNotFound if no consumer reads the topic.
+func (c *Client) Resume(topic string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	consumers := c.consumersOf(topic)
+	if len(consumers) == 0 {
+		return errors.WithMessagef(ErrConsumerNotFound, "topic '%s'", topic)
+	}
+	delete(c.paused, topic)
+	for _, consumer := range consumers {
+		if !c.isPaused(consumer) {
+			consumer.Resume()
+		}
+	}
+	return nil
+}
+
+// applyPause pauses consumers of the current state reading paused topics.
+func (c *Client) applyPause() {
+	for i := range c.state.consumers {
+		consumer := &c.state.consumers[i]
+		if c.isPaused(consumer) {
+			consumer.Pause()
+		}
+	}
+}
+
+// isPaused reports whether the consumer reads any paused topic.
+func (c *Client) isPaused(consumer *consumer.Consumer) bool {
+	for _, topic := range consumer.Topics() {
+		if c.paused[topic] {
+			return true
+		}
+	}
+	return false
+}
+
+// consumersOf returns consumers of the current state reading the topic.
+func (c *Client) consumersOf(topic string) []*consumer.Consumer {
+	if c.state == nil {
+		return nil
+	}
+	consumers := make([]*consumer.Consumer, 0)
+	for i := range c.state.consumers {
+		consumer := &c.state.consumers[i]
+		if slices.Contains(consumer.Topics(), topic) {
+			consumers = append(consumers, consumer)
+		}
+	}
+	return consumers
+}
+
 // Close gracefully shuts down the client and releases all resources. It is
 // safe to call Close multiple times.
 func (c *Client) Close() {
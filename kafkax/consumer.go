@@ -6,16 +6,26 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/plugin/kprom"
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
 	"github.com/txix-open/isp-kit/kafkax/consumer"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/kafka_metrics"
+	"github.com/txix-open/isp-kit/ratelimit"
 )
 
 const (
 	defaultDialTimeout    = 5 * time.Second
 	defaultMaxBatchSizeMb = 64
+
+	defaultBatchSize          = 100
+	defaultBatchPurgeInterval = 1 * time.Second
+
+	defaultLagCheckInterval = 30 * time.Second
 )
 
 // ConsumerConfig holds configuration for a Kafka consumer.
@@ -30,6 +40,31 @@ type ConsumerConfig struct {
 	TLS               *TLS     `schema:"Данные для установки TLS-соединения"`
 	DialTimeoutMs     *int     `schema:"Таймаут установки соединения, по умолчанию 5 секунд"`
 	MetricConsumerId  *string  `schema:"Идентификатор консьюмера в метриках, при отсутствии метрики не отправляются"`
+
+	Dlq                bool         `schema:"Использовать топик DLQ,сообщения перемещаются в топик <topic>.dlq"`
+	RetryPolicy        *RetryPolicy `schema:"Политика повторной обработки,сообщения повторно публикуются в топики <topic>.retry.<задержка>"`
+	DisableAutoDeclare bool         `schema:"Отключить автоматическое создание топиков,по умолчанию топики повторной обработки и DLQ будут созданы автоматически"`
+	ReadCommitted      bool         `schema:"Читать только подтвержденные сообщения,сообщения незавершенных и отмененных транзакций пропускаются,автокоммит офсетов отключается"`
+
+	BatchSize            int `schema:"Количество сообщений в пачке,для пакетной обработки, по умолчанию 100"`
+	BatchPurgeIntervalMs int `schema:"Интервал обработки неполной пачки в мс,для пакетной обработки, по умолчанию 1000 мс"`
+
+	RateLimit *ratelimit.Config `schema:"Ограничение скорости обработки сообщений,по умолчанию не ограничена"`
+
+	MaxLag              *int64 `schema:"Максимальное отставание,при превышении отставания любой назначенной партиции healthcheck возвращает ошибку, по умолчанию не проверяется"`
+	LagCheckIntervalSec *int   `schema:"Интервал расчета отставания в секундах,по умолчанию 30 с"`
+}
+
+// RetryConfig represents a delayed retry topic configuration.
+type RetryConfig struct {
+	DelayInMs   int `validate:"required" schema:"Задержка в миллисекундах"`
+	MaxAttempts int `validate:"required" schema:"Количество попыток,-1 = бесконечно"`
+}
+
+// RetryPolicy represents retry policy for message processing.
+type RetryPolicy struct {
+	FinallyMoveToDlq bool          `schema:"Отправить в DLQ,отправить сообщение в DLQ в случае последней неудавшейся попытки обработки"`
+	Retries          []RetryConfig `schema:"Настройки"`
 }
 
 // GetMaxBatchSizeMb returns the maximum batch size in MB. Returns 64MB by
@@ -42,6 +77,33 @@ func (c ConsumerConfig) GetMaxBatchSizeMb() int32 {
 	return c.MaxBatchSizeMb
 }
 
+// GetBatchSize returns the maximum number of messages in a batch. Returns 100
+// by default if not configured or set to a non-positive value.
+func (c ConsumerConfig) GetBatchSize() int {
+	if c.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+// GetBatchPurgeInterval returns the interval after which an incomplete batch is processed.
+// Returns 1 second by default if not configured or set to a non-positive value.
+func (c ConsumerConfig) GetBatchPurgeInterval() time.Duration {
+	if c.BatchPurgeIntervalMs <= 0 {
+		return defaultBatchPurgeInterval
+	}
+	return time.Duration(c.BatchPurgeIntervalMs) * time.Millisecond
+}
+
+// GetLagCheckInterval returns the interval of the consumer lag calculation.
+// Returns 30 seconds by default if not configured or set to a non-positive value.
+func (c ConsumerConfig) GetLagCheckInterval() time.Duration {
+	if c.LagCheckIntervalSec == nil || *c.LagCheckIntervalSec <= 0 {
+		return defaultLagCheckInterval
+	}
+	return time.Duration(*c.LagCheckIntervalSec) * time.Second
+}
+
 // GetCommitInterval returns the auto-commit interval duration. Returns 1 second
 // by default if not configured.
 func (c ConsumerConfig) GetCommitInterval() time.Duration {
@@ -61,14 +123,81 @@ func (c ConsumerConfig) GetDialTimeout() time.Duration {
 	return time.Duration(*c.DialTimeoutMs) * time.Millisecond
 }
 
+// GetRetryPolicy returns the retry policy of the consumer built from RetryPolicy and Dlq.
+// Returns false if neither retries nor the dead letter topic are configured.
+func (c ConsumerConfig) GetRetryPolicy() (consumer.RetryPolicy, bool) {
+	if c.RetryPolicy == nil && !c.Dlq {
+		return consumer.RetryPolicy{}, false
+	}
+
+	policy := consumer.RetryPolicy{}
+	if c.Dlq {
+		policy.DlqTopic = consumer.DlqTopicName(c.Topic)
+	}
+	if c.RetryPolicy != nil {
+		policy.FinallyMoveToDlq = c.RetryPolicy.FinallyMoveToDlq
+		for _, retry := range c.RetryPolicy.Retries {
+			delay := time.Duration(retry.DelayInMs) * time.Millisecond
+			policy.Retries = append(policy.Retries, consumer.RetryTopic{
+				Topic:       consumer.RetryTopicName(c.Topic, delay),
+				Delay:       delay,
+				MaxAttempts: retry.MaxAttempts,
+			})
+		}
+	}
+	return policy, true
+}
+
 // DefaultConsumer creates a new consumer with default configuration, including
-// built-in request ID middleware and log observer. Additional middlewares can
-// be provided via restMiddlewares.
+// built-in request ID middleware, log observer and partition lag metrics.
+// If RateLimit is set, message processing is limited by a token bucket.
+// Additional middlewares can be provided via restMiddlewares.
+// In-flight messages of revoked partitions are processed before the rebalance completes.
+// If a retry policy or DLQ is configured, the consumer also reads the retry topics
+// and, unless DisableAutoDeclare is set, creates missing retry and DLQ topics.
 func (c ConsumerConfig) DefaultConsumer(
 	logCtx context.Context,
 	logger log.Logger,
 	handler consumer.Handler,
 	restMiddlewares ...consumer.Middleware,
+) consumer.Consumer {
+	return c.newConsumer(logCtx, logger, handler, true, nil, restMiddlewares...)
+}
+
+// DefaultBatchConsumer creates a new consumer with default configuration which
+// collects messages per partition into batches of up to BatchSize messages or
+// BatchPurgeIntervalMs and passes them to the handler. Concurrency is fixed to 1
+// to keep the order of messages, batches of different partitions are processed concurrently.
+// Autocommit is disabled, only offsets of processed messages are committed.
+// Collected batches are processed immediately on close.
+func (c ConsumerConfig) DefaultBatchConsumer(
+	logCtx context.Context,
+	logger log.Logger,
+	handler batch_handler.BatchHandler,
+	restMiddlewares ...consumer.Middleware,
+) consumer.Consumer {
+	batchHandler := batch_handler.New(handler, c.GetBatchPurgeInterval(), c.GetBatchSize())
+	cfg := c
+	cfg.Concurrency = 1
+	return cfg.newConsumer(
+		logCtx,
+		logger,
+		batchHandler,
+		false,
+		[]consumer.Option{consumer.WithCloser(batchHandler)},
+		restMiddlewares...,
+	)
+}
+
+// newConsumer creates a new consumer with default configuration and additional consumer options.
+// If autoCommit is false or ReadCommitted is set, offsets are committed only explicitly.
+func (c ConsumerConfig) newConsumer(
+	logCtx context.Context,
+	logger log.Logger,
+	handler consumer.Handler,
+	autoCommit bool,
+	opts []consumer.Option,
+	restMiddlewares ...consumer.Middleware,
 ) consumer.Consumer {
 	authMechanism := AuthTypePlain
 	if c.Auth != nil && c.Auth.Mechanism != nil {
@@ -85,23 +214,42 @@ func (c ConsumerConfig) DefaultConsumer(
 		logger.Error(logCtx, errors.WithMessage(err, "failed to setup tls"))
 	}
 
-	opts := []kgo.Opt{
+	observer := consumer.NewLogObserver(logCtx, logger)
+	rebalanceListener := consumer.NewRebalanceListener(observer)
+
+	retryPolicy, hasRetryPolicy := c.GetRetryPolicy()
+	topics := append([]string{c.Topic}, retryPolicy.Topics()...)
+
+	kgoOpts := []kgo.Opt{
 		kgo.SeedBrokers(c.Addresses...),
 		kgo.ConsumerGroup(c.GroupId),
 		kgo.DisableIdempotentWrite(),
-		kgo.ConsumeTopics(c.Topic),
+		kgo.ConsumeTopics(topics...),
 		kgo.DialTLSConfig(tls),
 		kgo.DialTimeout(c.GetDialTimeout()),
 		kgo.SASL(saslMechanism),
 		kgo.FetchMinBytes(1),
 		kgo.FetchMaxBytes(c.GetMaxBatchSizeMb() * bytesInMb),
-		kgo.AutoCommitInterval(c.GetCommitInterval()),
 		kgo.WithLogger(NewLogger(logCtx, "kafka consumer", kgo.LogLevelError, logger)),
 	}
+	kgoOpts = append(kgoOpts, rebalanceListener.ClientOpts()...)
+	if c.ReadCommitted {
+		kgoOpts = append(kgoOpts, kgo.FetchIsolationLevel(kgo.ReadCommitted()))
+	}
+	if autoCommit && !c.ReadCommitted {
+		kgoOpts = append(kgoOpts, kgo.AutoCommitInterval(c.GetCommitInterval()))
+	} else {
+		// offsets are committed only explicitly or in transactions of Publisher.Transaction,
+		// autocommit of polled offsets commits messages which are not processed yet
+		kgoOpts = append(kgoOpts, kgo.DisableAutoCommit())
+	}
 
 	middlewares := []consumer.Middleware{
 		ConsumerRequestId(),
 	}
+	if c.RateLimit != nil {
+		middlewares = append(middlewares, ConsumerRateLimit(c.RateLimit.Limiter()))
+	}
 	middlewares = append(middlewares, restMiddlewares...)
 
 	if c.MetricConsumerId != nil {
@@ -114,10 +262,10 @@ func (c ConsumerConfig) DefaultConsumer(
 			kprom.Registry(defaultRegistry),
 			kprom.WithStaticLabel(labels),
 		)
-		opts = append(opts, kgo.WithHooks(consumerMetrics))
+		kgoOpts = append(kgoOpts, kgo.WithHooks(consumerMetrics))
 	}
 
-	client, err := kgo.NewClient(opts...)
+	client, err := kgo.NewClient(kgoOpts...)
 	if err != nil {
 		logger.Error(logCtx, errors.WithMessage(err, "create kafka client"))
 	}
@@ -127,14 +275,57 @@ func (c ConsumerConfig) DefaultConsumer(
 		logger.Error(logCtx, errors.WithMessage(err, "ping kafka client"))
 	}
 
+	consumerOpts := []consumer.Option{
+		consumer.WithObserver(observer),
+		consumer.WithMiddlewares(middlewares...),
+		consumer.WithRebalanceListener(rebalanceListener),
+		consumer.WithLagMetrics(kafka_metrics.NewConsumerStorage(metrics.DefaultRegistry)),
+		consumer.WithLagCheckInterval(c.GetLagCheckInterval()),
+	}
+	if c.MaxLag != nil {
+		consumerOpts = append(consumerOpts, consumer.WithMaxLag(*c.MaxLag))
+	}
+	consumerOpts = append(consumerOpts, opts...)
+	if hasRetryPolicy {
+		consumerOpts = append(consumerOpts, consumer.WithRetryPolicy(retryPolicy))
+		if !c.DisableAutoDeclare {
+			err = declareTopics(logCtx, client, retryPolicy)
+			if err != nil {
+				logger.Error(logCtx, errors.WithMessage(err, "declare retry topics"))
+			}
+		}
+	}
+
 	cons := consumer.New(
 		client,
 		c.GroupId,
 		handler,
 		c.Concurrency,
-		consumer.WithObserver(consumer.NewLogObserver(logCtx, logger)),
-		consumer.WithMiddlewares(middlewares...),
+		consumerOpts...,
 	)
 
 	return *cons
 }
+
+// declareTopics creates the retry and dead letter topics of the policy with the broker
+// default settings. Already existing topics are left untouched.
+func declareTopics(ctx context.Context, client *kgo.Client, policy consumer.RetryPolicy) error {
+	topics := policy.Topics()
+	if policy.DlqTopic != "" {
+		topics = append(topics, policy.DlqTopic)
+	}
+	if len(topics) == 0 {
+		return nil
+	}
+
+	responses, err := kadm.NewClient(client).CreateTopics(ctx, -1, -1, nil, topics...)
+	if err != nil {
+		return errors.WithMessage(err, "create topics")
+	}
+	for _, response := range responses {
+		if response.Err != nil && !errors.Is(response.Err, kerr.TopicAlreadyExists) {
+			return errors.WithMessagef(response.Err, "create topic '%s'", response.Topic)
+		}
+	}
+	return nil
+}
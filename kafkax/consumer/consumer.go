@@ -6,6 +6,8 @@ package consumer
 
 import (
 	"context"
+	"regexp"
+	"slices"
 	"sync"
 	"time"
 
@@ -32,6 +34,11 @@ func (f HandlerFunc) Handle(ctx context.Context, delivery *Delivery) {
 	f(ctx, delivery)
 }
 
+// Closer defines an interface for releasing handler resources on consumer shutdown.
+type Closer interface {
+	Close()
+}
+
 // Consumer handles consuming messages from Kafka topics with configurable
 // concurrency and middleware support. It manages offset committing and
 // provides lifecycle hooks through the observer pattern.
@@ -43,10 +50,19 @@ type Consumer struct {
 	concurrency     int
 	handler         Handler
 	observer        Observer
+	retryPolicy     *RetryPolicy
+	closer          Closer
+	listener        *RebalanceListener
+
+	lagMetrics       LagMetricStorage
+	maxLag           int64
+	lagCheckInterval time.Duration
 
 	deliveryWg *sync.WaitGroup
 	deliveries chan Delivery
 	alive      *atomic.Bool
+	lag        *atomic.Int64
+	paused     *atomic.Bool
 
 	stopChan chan struct{}
 }
@@ -60,14 +76,17 @@ func New(client *kgo.Client, consumerGroupId string, handler Handler, concurrenc
 	}
 
 	c := &Consumer{
-		client:          client,
-		consumerGroupId: consumerGroupId,
-		concurrency:     concurrency,
-		handler:         handler,
-		deliveryWg:      &sync.WaitGroup{},
-		deliveries:      make(chan Delivery),
-		alive:           atomic.NewBool(true),
-		stopChan:        make(chan struct{}),
+		client:           client,
+		consumerGroupId:  consumerGroupId,
+		concurrency:      concurrency,
+		handler:          handler,
+		deliveryWg:       &sync.WaitGroup{},
+		deliveries:       make(chan Delivery),
+		alive:            atomic.NewBool(true),
+		lag:              atomic.NewInt64(0),
+		paused:           atomic.NewBool(false),
+		stopChan:         make(chan struct{}),
+		lagCheckInterval: defaultLagCheckInterval,
 	}
 
 	for _, opt := range opts {
@@ -84,11 +103,16 @@ func New(client *kgo.Client, consumerGroupId string, handler Handler, concurrenc
 
 // Run starts the consumer and begins processing messages. It returns
 // immediately and runs message processing in a separate goroutine.
+// If lag metrics or the maximum lag are configured, the lag of assigned partitions
+// is periodically calculated in a separate goroutine.
 func (c *Consumer) Run(ctx context.Context) {
 	if c.observer != nil {
 		c.observer.BeginConsuming()
 	}
 	go c.run(ctx)
+	if c.lagMetrics != nil || c.maxLag > 0 {
+		go c.runLagMonitor(ctx)
+	}
 }
 
 // Close gracefully shuts down the consumer, waits for pending message
@@ -105,6 +129,9 @@ func (c *Consumer) Close() error {
 	}
 	close(c.stopChan)
 
+	if c.closer != nil {
+		c.closer.Close()
+	}
 	c.deliveryWg.Wait()
 
 	c.client.Close()
@@ -113,12 +140,53 @@ func (c *Consumer) Close() error {
 }
 
 // Healthcheck returns nil if the consumer is healthy and able to fetch
-// messages, or an error if it has encountered issues.
+// messages, or an error if it has encountered issues or its lag exceeds
+// the configured maximum.
 func (c *Consumer) Healthcheck(ctx context.Context) error {
-	if c.alive.Load() {
-		return nil
+	if !c.alive.Load() {
+		return errors.New("could not fetch messages")
+	}
+	lag := c.lag.Load()
+	if c.maxLag > 0 && lag > c.maxLag && !c.paused.Load() {
+		return errors.Errorf("consumer lag %d exceeds max lag %d", lag, c.maxLag)
+	}
+	return nil
+}
+
+// Pause stops fetching messages from all topics of the consumer until Resume is called.
+// Messages which are already fetched are still processed. The lag of a paused consumer
+// is not checked by Healthcheck.
+func (c *Consumer) Pause() {
+	c.paused.Store(true)
+	c.client.PauseFetchTopics(c.Topics()...)
+}
+
+// Resume resumes fetching messages paused by Pause.
+func (c *Consumer) Resume() {
+	c.client.ResumeFetchTopics(c.Topics()...)
+	c.paused.Store(false)
+}
+
+// Paused reports whether the consumer is paused.
+func (c *Consumer) Paused() bool {
+	return c.paused.Load()
+}
+
+// Topics returns the topics the consumer reads, including retry topics.
+func (c *Consumer) Topics() []string {
+	configured, _ := c.client.OptValue(kgo.ConsumeTopics).(map[string]*regexp.Regexp)
+	topics := make([]string, 0, len(configured))
+	for topic := range configured {
+		topics = append(topics, topic)
 	}
-	return errors.New("could not fetch messages")
+	slices.Sort(topics)
+	return topics
+}
+
+// Lag returns the maximum lag among partitions assigned to the consumer as of the last check.
+// It is always 0 if neither lag metrics nor the maximum lag are configured.
+func (c *Consumer) Lag() int64 {
+	return c.lag.Load()
 }
 
 // run is the main message processing loop. It polls for new messages and
@@ -150,6 +218,10 @@ func (c *Consumer) run(ctx context.Context) {
 		c.alive.Store(true)
 
 		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			if c.isRetryTopic(p.Topic) {
+				c.runRetryPartition(ctx, p)
+				return
+			}
 			for _, msg := range p.Records {
 				c.deliveryWg.Add(1)
 				select {
@@ -157,14 +229,76 @@ func (c *Consumer) run(ctx context.Context) {
 					c.deliveryWg.Done()
 					return
 				default:
-					delivery := NewDelivery(c.deliveryWg, c.client, msg, c.consumerGroupId)
-					c.deliveries <- *delivery
+					c.deliveries <- *c.newDelivery(msg)
 				}
 			}
 		})
 	}
 }
 
+// newDelivery creates a delivery of the record tracked by the consumer and the rebalance listener.
+// The delivery wait group must be incremented before.
+func (c *Consumer) newDelivery(record *kgo.Record) *Delivery {
+	var donner Donner = c.deliveryWg
+	if c.listener != nil {
+		donner = c.listener.acquire(record, donner)
+	}
+	delivery := NewDelivery(donner, c.client, record, c.consumerGroupId)
+	delivery.retryPolicy = c.retryPolicy
+	return delivery
+}
+
+// isRetryTopic reports whether the topic is a retry topic of the retry policy.
+func (c *Consumer) isRetryTopic(topic string) bool {
+	if c.retryPolicy == nil {
+		return false
+	}
+	_, ok := c.retryPolicy.delay(topic)
+	return ok
+}
+
+// runRetryPartition processes fetched records of a retry topic partition in a dedicated goroutine,
+// so waiting for the retry delay does not block workers of the main topic.
+// Fetching of the partition is paused until the records are processed. Records fetched
+// in the meantime are dropped by the client and fetched again after the partition is resumed.
+func (c *Consumer) runRetryPartition(ctx context.Context, p kgo.FetchTopicPartition) {
+	if len(p.Records) == 0 {
+		return
+	}
+	select {
+	case <-c.stopChan:
+		return
+	default:
+	}
+
+	partition := map[string][]int32{p.Topic: {p.Partition}}
+	c.client.PauseFetchPartitions(partition)
+
+	deliveries := make([]*Delivery, 0, len(p.Records))
+	for _, record := range p.Records {
+		c.deliveryWg.Add(1)
+		deliveries = append(deliveries, c.newDelivery(record))
+	}
+	var revoked <-chan struct{}
+	if c.listener != nil {
+		revoked = c.listener.revoked(p.Topic, p.Partition)
+	}
+
+	go func() {
+		defer c.client.ResumeFetchPartitions(partition)
+
+		for i, delivery := range deliveries {
+			if !c.awaitRetry(ctx, delivery.Source(), revoked) {
+				for _, skipped := range deliveries[i:] {
+					skipped.Done()
+				}
+				return
+			}
+			c.handler.Handle(ctx, delivery)
+		}
+	}()
+}
+
 // handleFetchErrors handles errors that occur when polling for messages. It
 // marks the consumer as unhealthy and notifies the observer.
 func (c *Consumer) handleFetchErrors(ctx context.Context, errs []kgo.FetchError) {
@@ -191,12 +325,33 @@ func (c *Consumer) runWorker(ctx context.Context) {
 				return
 			}
 
-			c.handleMessage(ctx, &delivery)
+			c.handler.Handle(ctx, &delivery)
 		}
 	}
 }
 
-// handleMessage delegates message processing to the configured handler.
-func (c *Consumer) handleMessage(ctx context.Context, delivery *Delivery) {
-	c.handler.Handle(ctx, delivery)
+// awaitRetry waits until the delay of the retry topic passes since the message was republished.
+// Returns false if the consumer is closed, the partition is revoked or the context is done while waiting.
+func (c *Consumer) awaitRetry(ctx context.Context, record *kgo.Record, revoked <-chan struct{}) bool {
+	delay, ok := c.retryPolicy.delay(record.Topic)
+	if !ok {
+		return true
+	}
+	wait := time.Until(record.Timestamp.Add(delay))
+	if wait <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.stopChan:
+		return false
+	case <-revoked:
+		return false
+	case <-ctx.Done():
+		return false
+	}
 }
@@ -2,6 +2,8 @@ package consumer
 
 import (
 	"context"
+	"strconv"
+
 	"github.com/pkg/errors"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
@@ -10,6 +12,8 @@ var (
 	// ErrDeliveryAlreadyHandled is returned when attempting to commit a delivery
 	// that has already been handled.
 	ErrDeliveryAlreadyHandled = errors.New("delivery already handled")
+	// ErrRetryPolicyNotSet is returned by Delivery.Retry when the consumer has no retry policy.
+	ErrRetryPolicyNotSet = errors.New("retry policy is not set")
 )
 
 // Donner defines an interface for signaling when message processing is complete.
@@ -25,6 +29,7 @@ type Delivery struct {
 	source          *kgo.Record
 	handled         bool
 	consumerGroupId string
+	retryPolicy     *RetryPolicy
 }
 
 // NewDelivery creates a new Delivery instance with the provided configuration.
@@ -66,6 +71,103 @@ func (d *Delivery) Commit(ctx context.Context) error {
 	return nil
 }
 
+// MarkCommitted marks the delivery as handled and signals completion without
+// committing the offset, e.g. when the offset is already committed in a Kafka
+// transaction. Returns an error if the delivery has already been handled.
+func (d *Delivery) MarkCommitted() error {
+	if d.handled {
+		return ErrDeliveryAlreadyHandled
+	}
+
+	d.handled = true
+	d.donner.Done()
+	return nil
+}
+
+// PausePartition stops fetching messages of the delivery partition until the returned
+// function is called. Messages which are already fetched are still delivered.
+func (d *Delivery) PausePartition() (resume func()) {
+	if d.client == nil {
+		return func() {}
+	}
+	partition := map[string][]int32{d.source.Topic: {d.source.Partition}}
+	d.client.PauseFetchPartitions(partition)
+	return func() {
+		d.client.ResumeFetchPartitions(partition)
+	}
+}
+
+// GroupMetadata returns the current member id and generation of the consumer
+// in its group, or an empty string and -1 if the consumer is not in the group.
+func (d *Delivery) GroupMetadata() (string, int32) {
+	return d.client.GroupMetadata()
+}
+
+// Handled reports whether the delivery has already been committed.
+func (d *Delivery) Handled() bool {
+	return d.handled
+}
+
+// Attempt returns the number of failed processing attempts of the message,
+// which is 0 for a message that has not been retried yet.
+func (d *Delivery) Attempt() int {
+	for _, header := range d.source.Headers {
+		if header.Key == HeaderAttempt {
+			attempt, err := strconv.Atoi(string(header.Value))
+			if err != nil {
+				return 0
+			}
+			return attempt
+		}
+	}
+	return 0
+}
+
+// Retry republishes the message to the retry topic of the next attempt according to
+// the retry policy and commits the offset, so the partition is not blocked.
+// When all retries are exhausted, the message is moved to the dead letter topic if
+// RetryPolicy.FinallyMoveToDlq is set or dropped otherwise.
+// Returns ErrRetryPolicyNotSet if the consumer has no retry policy.
+func (d *Delivery) Retry(ctx context.Context, err error) error {
+	if d.retryPolicy == nil {
+		return ErrRetryPolicyNotSet
+	}
+
+	attempt := d.Attempt() + 1
+	retry, ok := d.retryPolicy.retryTopic(attempt)
+	switch {
+	case ok:
+		return d.republish(ctx, retry.Topic, attempt, err)
+	case d.retryPolicy.FinallyMoveToDlq:
+		return d.MoveToDlq(ctx, err)
+	default:
+		return d.Commit(ctx)
+	}
+}
+
+// MoveToDlq republishes the message to the dead letter topic and commits the offset.
+// If no dead letter topic is configured, the message is dropped.
+func (d *Delivery) MoveToDlq(ctx context.Context, err error) error {
+	if d.retryPolicy == nil || d.retryPolicy.DlqTopic == "" {
+		return d.Commit(ctx)
+	}
+	return d.republish(ctx, d.retryPolicy.DlqTopic, d.Attempt()+1, err)
+}
+
+// republish produces a copy of the message to the topic and commits the offset on success.
+func (d *Delivery) republish(ctx context.Context, topic string, attempt int, err error) error {
+	if d.handled {
+		return ErrDeliveryAlreadyHandled
+	}
+
+	record := republished(d.source, topic, attempt, err)
+	produceErr := d.client.ProduceSync(ctx, record).FirstErr()
+	if produceErr != nil {
+		return errors.WithMessagef(produceErr, "republish message to '%s'", topic)
+	}
+	return d.Commit(ctx)
+}
+
 // Done signals that message processing is complete without committing the
 // offset. This is typically used when the message should be skipped or
 // reprocessed later.
@@ -0,0 +1,88 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+const defaultLagCheckInterval = 30 * time.Second
+
+// LagMetricStorage defines the interface for collecting per-partition consumer lag.
+type LagMetricStorage interface {
+	SetLag(consumerGroup string, topic string, partition int32, lag int64)
+	DeleteLag(consumerGroup string, topic string, partition int32)
+}
+
+// runLagMonitor periodically calculates the lag of partitions assigned to the consumer
+// until the consumer is closed.
+func (c *Consumer) runLagMonitor(ctx context.Context) {
+	reported := make(map[topicPartition]bool)
+	ticker := time.NewTicker(c.lagCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		err := c.checkLag(ctx, reported)
+		if err != nil && c.observer != nil {
+			c.observer.ConsumerError(errors.WithMessage(err, "check consumer lag"))
+		}
+
+		select {
+		case <-c.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkLag calculates the lag of partitions assigned to the consumer group member,
+// updates the lag metrics and stores the maximum lag for the healthcheck.
+// Metrics of partitions which are no longer assigned to the member are deleted.
+func (c *Consumer) checkLag(ctx context.Context, reported map[topicPartition]bool) error {
+	lags, err := kadm.NewClient(c.client).Lag(ctx, c.consumerGroupId)
+	if err != nil {
+		return errors.WithMessage(err, "describe group lag")
+	}
+	groupLag, ok := lags[c.consumerGroupId]
+	if !ok {
+		return errors.Errorf("group '%s' is not described", c.consumerGroupId)
+	}
+	err = groupLag.Error()
+	if err != nil {
+		return errors.WithMessage(err, "describe group lag")
+	}
+
+	memberId, _ := c.client.GroupMetadata()
+	maxLag := int64(0)
+	current := make(map[topicPartition]bool)
+	for _, lag := range groupLag.Lag.Sorted() {
+		if lag.Member == nil || lag.Member.MemberID != memberId || lag.Err != nil {
+			continue
+		}
+		maxLag = max(maxLag, lag.Lag)
+		key := topicPartition{topic: lag.Topic, partition: lag.Partition}
+		current[key] = true
+		if c.lagMetrics != nil {
+			c.lagMetrics.SetLag(c.consumerGroupId, lag.Topic, lag.Partition, lag.Lag)
+		}
+	}
+	for key := range reported {
+		if current[key] {
+			continue
+		}
+		delete(reported, key)
+		if c.lagMetrics != nil {
+			c.lagMetrics.DeleteLag(c.consumerGroupId, key.topic, key.partition)
+		}
+	}
+	for key := range current {
+		reported[key] = true
+	}
+
+	c.lag.Store(maxLag)
+	return nil
+}
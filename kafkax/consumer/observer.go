@@ -15,6 +15,14 @@ type Observer interface {
 	CloseDone()
 }
 
+// RebalanceObserver is an optional interface of an Observer receiving partition
+// assignment changes of the consumer group member from the RebalanceListener.
+type RebalanceObserver interface {
+	PartitionsAssigned(partitions map[string][]int32)
+	PartitionsRevoked(partitions map[string][]int32)
+	PartitionsLost(partitions map[string][]int32)
+}
+
 // NoopObserver is a no-op implementation of the Observer interface that
 // ignores all events.
 type NoopObserver struct{}
@@ -35,6 +43,21 @@ func (n NoopObserver) CloseDone() {
 
 }
 
+// PartitionsAssigned does nothing.
+func (n NoopObserver) PartitionsAssigned(partitions map[string][]int32) {
+
+}
+
+// PartitionsRevoked does nothing.
+func (n NoopObserver) PartitionsRevoked(partitions map[string][]int32) {
+
+}
+
+// PartitionsLost does nothing.
+func (n NoopObserver) PartitionsLost(partitions map[string][]int32) {
+
+}
+
 // LogObserver is an Observer implementation that logs consumer lifecycle
 // events to the provided logger.
 type LogObserver struct {
@@ -84,3 +107,30 @@ func (l LogObserver) CloseDone() {
 		"kafka client: closing consumer done",
 	)
 }
+
+// PartitionsAssigned logs the partitions assigned to the consumer.
+func (l LogObserver) PartitionsAssigned(partitions map[string][]int32) {
+	l.logger.Info(
+		l.ctx,
+		"kafka client: partitions assigned",
+		log.Any("partitions", partitions),
+	)
+}
+
+// PartitionsRevoked logs the partitions revoked from the consumer.
+func (l LogObserver) PartitionsRevoked(partitions map[string][]int32) {
+	l.logger.Info(
+		l.ctx,
+		"kafka client: partitions revoked",
+		log.Any("partitions", partitions),
+	)
+}
+
+// PartitionsLost logs the partitions lost by the consumer.
+func (l LogObserver) PartitionsLost(partitions map[string][]int32) {
+	l.logger.Warn(
+		l.ctx,
+		"kafka client: partitions lost",
+		log.Any("partitions", partitions),
+	)
+}
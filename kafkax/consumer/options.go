@@ -1,5 +1,9 @@
 package consumer
 
+import (
+	"time"
+)
+
 // Option is a function that configures a Consumer instance.
 type Option func(p *Consumer)
 
@@ -18,3 +22,55 @@ func WithObserver(observer Observer) Option {
 		c.observer = observer
 	}
 }
+
+// WithRetryPolicy configures the consumer to republish failed messages to delayed
+// retry topics and the dead letter topic instead of blocking the partition.
+// The retry topics must be consumed by the same consumer.
+//
+// Records of a retry topic partition are processed by a dedicated goroutine, which waits
+// for the retry delay with the partition fetching paused, so workers of the main topic are not blocked.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Consumer) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithCloser configures the consumer to close the provided closer on shutdown
+// before waiting for in-flight messages, e.g. to process collected batches immediately.
+func WithCloser(closer Closer) Option {
+	return func(c *Consumer) {
+		c.closer = closer
+	}
+}
+
+// WithRebalanceListener configures the consumer to track in-flight messages per partition,
+// so the listener can wait for them before partitions are revoked.
+// The listener must also be passed to the client with RebalanceListener.ClientOpts.
+func WithRebalanceListener(listener *RebalanceListener) Option {
+	return func(c *Consumer) {
+		c.listener = listener
+	}
+}
+
+// WithLagMetrics configures the consumer to periodically report the lag
+// of each partition assigned to it.
+func WithLagMetrics(storage LagMetricStorage) Option {
+	return func(c *Consumer) {
+		c.lagMetrics = storage
+	}
+}
+
+// WithMaxLag configures the consumer healthcheck to fail when the lag of
+// any partition assigned to it exceeds maxLag messages.
+func WithMaxLag(maxLag int64) Option {
+	return func(c *Consumer) {
+		c.maxLag = maxLag
+	}
+}
+
+// WithLagCheckInterval sets the interval of the lag calculation. By default, it is 30 seconds.
+func WithLagCheckInterval(interval time.Duration) Option {
+	return func(c *Consumer) {
+		c.lagCheckInterval = interval
+	}
+}
@@ -0,0 +1,203 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// topicPartition identifies a partition of a topic.
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// RebalanceListener receives partition assignment changes of the consumer group
+// member and notifies the observer about them if it implements RebalanceObserver.
+// Before partitions are revoked, it waits for in-flight deliveries of these partitions
+// to be processed and commits the polled offsets, as the franz-go client does by default.
+// The offsets are not committed if autocommit is disabled or in-flight deliveries are not
+// processed in time, so unprocessed messages are consumed again after reassignment.
+//
+// The listener must be passed to the client with ClientOpts and to the consumer
+// with WithRebalanceListener.
+type RebalanceListener struct {
+	observer          Observer
+	rebalanceObserver RebalanceObserver
+	drainTimeout      time.Duration
+
+	lock     sync.Locker
+	inFlight map[topicPartition]*partitionState
+}
+
+// partitionState tracks in-flight deliveries of an assigned partition.
+type partitionState struct {
+	wg      *sync.WaitGroup
+	revoked chan struct{}
+}
+
+// NewRebalanceListener creates a new RebalanceListener notifying the observer.
+// The observer may be nil.
+func NewRebalanceListener(observer Observer) *RebalanceListener {
+	rebalanceObserver, _ := observer.(RebalanceObserver)
+	return &RebalanceListener{
+		observer:          observer,
+		rebalanceObserver: rebalanceObserver,
+		drainTimeout:      defaultDrainTimeout,
+		lock:              &sync.Mutex{},
+		inFlight:          make(map[topicPartition]*partitionState),
+	}
+}
+
+// ClientOpts returns the franz-go client options with the partition assignment callbacks.
+func (l *RebalanceListener) ClientOpts() []kgo.Opt {
+	return []kgo.Opt{
+		kgo.OnPartitionsAssigned(l.onAssigned),
+		kgo.OnPartitionsRevoked(l.onRevoked),
+		kgo.OnPartitionsLost(l.onLost),
+	}
+}
+
+// onAssigned notifies the observer about assigned partitions.
+func (l *RebalanceListener) onAssigned(_ context.Context, _ *kgo.Client, assigned map[string][]int32) {
+	if l.rebalanceObserver != nil {
+		l.rebalanceObserver.PartitionsAssigned(assigned)
+	}
+}
+
+// onRevoked waits for in-flight deliveries of revoked partitions, commits the polled offsets
+// if they are drained and autocommit is enabled, and notifies the observer. It is also called with all partitions when the client is closed.
+func (l *RebalanceListener) onRevoked(ctx context.Context, client *kgo.Client, revoked map[string][]int32) {
+	if len(revoked) == 0 {
+		return
+	}
+
+	drained := l.drain(ctx, revoked)
+	autoCommitDisabled, _ := client.OptValue(kgo.DisableAutoCommit).(bool)
+	switch {
+	case !drained:
+		// polled offsets include in-flight messages, committing them loses these messages
+		if l.observer != nil {
+			l.observer.ConsumerError(errors.Errorf(
+				"in-flight messages of revoked partitions were not processed in %s, offsets are not committed",
+				l.drainTimeout,
+			))
+		}
+	case !autoCommitDisabled:
+		err := client.CommitUncommittedOffsets(ctx)
+		if err != nil && l.observer != nil {
+			l.observer.ConsumerError(errors.WithMessage(err, "commit offsets of revoked partitions"))
+		}
+	}
+
+	if l.rebalanceObserver != nil {
+		l.rebalanceObserver.PartitionsRevoked(revoked)
+	}
+}
+
+// onLost notifies the observer about lost partitions. In-flight deliveries are not awaited
+// because their offsets can not be committed anymore.
+func (l *RebalanceListener) onLost(_ context.Context, _ *kgo.Client, lost map[string][]int32) {
+	if len(lost) == 0 {
+		return
+	}
+	l.forget(lost)
+	if l.rebalanceObserver != nil {
+		l.rebalanceObserver.PartitionsLost(lost)
+	}
+}
+
+// acquire registers an in-flight delivery of the record partition.
+// The returned donner must be called when the delivery is processed.
+func (l *RebalanceListener) acquire(record *kgo.Record, next Donner) Donner {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	state := l.state(topicPartition{topic: record.Topic, partition: record.Partition})
+	state.wg.Add(1)
+	return partitionDonner{partition: state.wg, next: next}
+}
+
+// revoked returns a channel which is closed when the partition is revoked or lost.
+func (l *RebalanceListener) revoked(topic string, partition int32) <-chan struct{} {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.state(topicPartition{topic: topic, partition: partition}).revoked
+}
+
+// state returns the state of the partition, creating it if needed. The lock must be held.
+func (l *RebalanceListener) state(key topicPartition) *partitionState {
+	state, ok := l.inFlight[key]
+	if !ok {
+		state = &partitionState{
+			wg:      &sync.WaitGroup{},
+			revoked: make(chan struct{}),
+		}
+		l.inFlight[key] = state
+	}
+	return state
+}
+
+// drain waits for in-flight deliveries of the partitions.
+// Returns false if the drain timeout elapsed or the context is done.
+func (l *RebalanceListener) drain(ctx context.Context, partitions map[string][]int32) bool {
+	groups := l.forget(partitions)
+
+	done := make(chan struct{})
+	go func() {
+		for _, wg := range groups {
+			wg.Wait()
+		}
+		close(done)
+	}()
+
+	timer := time.NewTimer(l.drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// forget stops tracking the partitions, signals that they are revoked and returns their in-flight wait groups.
+func (l *RebalanceListener) forget(partitions map[string][]int32) []*sync.WaitGroup {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	groups := make([]*sync.WaitGroup, 0)
+	for topic, ps := range partitions {
+		for _, partition := range ps {
+			key := topicPartition{topic: topic, partition: partition}
+			state, ok := l.inFlight[key]
+			if !ok {
+				continue
+			}
+			delete(l.inFlight, key)
+			close(state.revoked)
+			groups = append(groups, state.wg)
+		}
+	}
+	return groups
+}
+
+// partitionDonner signals completion of a delivery to its partition and to the next donner.
+type partitionDonner struct {
+	partition *sync.WaitGroup
+	next      Donner
+}
+
+// Done implements the Donner interface.
+func (d partitionDonner) Done() {
+	d.partition.Done()
+	d.next.Done()
+}
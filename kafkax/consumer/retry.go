@@ -0,0 +1,152 @@
+package consumer
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const (
+	// HeaderError is the header with the error of the last failed processing attempt.
+	HeaderError = "x-error"
+	// HeaderAttempt is the header with the number of failed processing attempts.
+	HeaderAttempt = "x-attempt"
+	// HeaderOriginalTopic is the header with the topic the record was first consumed from.
+	HeaderOriginalTopic = "x-original-topic"
+	// HeaderOriginalPartition is the header with the partition the record was first consumed from.
+	HeaderOriginalPartition = "x-original-partition"
+	// HeaderOriginalOffset is the header with the offset the record was first consumed from.
+	HeaderOriginalOffset = "x-original-offset"
+)
+
+// RetryTopic describes a delayed retry topic.
+type RetryTopic struct {
+	// Topic is the name of the retry topic.
+	Topic string
+	// Delay is the minimum time between republishing a record and its next processing attempt.
+	Delay time.Duration
+	// MaxAttempts is the number of attempts made through the topic, -1 means unlimited.
+	MaxAttempts int
+}
+
+// RetryPolicy describes where failed records are republished: a chain of delayed
+// retry topics and a dead letter topic.
+type RetryPolicy struct {
+	// Retries are the retry topics in the order they are used.
+	Retries []RetryTopic
+	// DlqTopic is the dead letter topic, empty if it is disabled.
+	DlqTopic string
+	// FinallyMoveToDlq moves records to the dead letter topic when all retries are exhausted.
+	// Otherwise, such records are dropped.
+	FinallyMoveToDlq bool
+}
+
+// Topics returns the retry topics which must be consumed along with the main topic.
+func (p RetryPolicy) Topics() []string {
+	topics := make([]string, 0, len(p.Retries))
+	for _, retry := range p.Retries {
+		if !containsTopic(topics, retry.Topic) {
+			topics = append(topics, retry.Topic)
+		}
+	}
+	return topics
+}
+
+// retryTopic returns the retry topic for the attempt or false if retries are exhausted.
+func (p RetryPolicy) retryTopic(attempt int) (RetryTopic, bool) {
+	passed := 0
+	for _, retry := range p.Retries {
+		if retry.MaxAttempts < 0 {
+			return retry, true
+		}
+		passed += retry.MaxAttempts
+		if attempt <= passed {
+			return retry, true
+		}
+	}
+	return RetryTopic{}, false
+}
+
+// delay returns the delay of the retry topic or false if the topic is not a retry topic.
+func (p RetryPolicy) delay(topic string) (time.Duration, bool) {
+	for _, retry := range p.Retries {
+		if retry.Topic == topic {
+			return retry.Delay, true
+		}
+	}
+	return 0, false
+}
+
+// RetryTopicName returns the name of the retry topic for the topic and delay, e.g. "events.retry.5s".
+func RetryTopicName(topic string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", topic, formatDelay(delay))
+}
+
+// DlqTopicName returns the name of the dead letter topic for the topic, e.g. "events.dlq".
+func DlqTopicName(topic string) string {
+	return topic + ".dlq"
+}
+
+// formatDelay formats the delay in the largest whole unit.
+func formatDelay(delay time.Duration) string {
+	switch {
+	case delay >= time.Hour && delay%time.Hour == 0:
+		return fmt.Sprintf("%dh", delay/time.Hour)
+	case delay >= time.Minute && delay%time.Minute == 0:
+		return fmt.Sprintf("%dm", delay/time.Minute)
+	case delay >= time.Second && delay%time.Second == 0:
+		return fmt.Sprintf("%ds", delay/time.Second)
+	default:
+		return fmt.Sprintf("%dms", delay.Milliseconds())
+	}
+}
+
+// republished creates a copy of the record for the topic with the failure headers.
+// The original topic, partition and offset of a record that has already been republished are kept.
+func republished(source *kgo.Record, topic string, attempt int, err error) *kgo.Record {
+	headers := make([]kgo.RecordHeader, 0, len(source.Headers)+5) // nolint:mnd
+	originalHeaders := []kgo.RecordHeader{
+		{Key: HeaderOriginalTopic, Value: []byte(source.Topic)},
+		{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(int(source.Partition)))},
+		{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(source.Offset, 10))},
+	}
+	for _, header := range source.Headers {
+		switch header.Key {
+		case HeaderError, HeaderAttempt:
+		case HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset:
+			originalHeaders = nil
+			headers = append(headers, header)
+		default:
+			headers = append(headers, header)
+		}
+	}
+	headers = append(headers, originalHeaders...)
+
+	errorText := ""
+	if err != nil {
+		errorText = err.Error()
+	}
+	headers = append(headers,
+		kgo.RecordHeader{Key: HeaderError, Value: []byte(errorText)},
+		kgo.RecordHeader{Key: HeaderAttempt, Value: []byte(strconv.Itoa(attempt))},
+	)
+
+	return &kgo.Record{
+		Key:     source.Key,
+		Value:   source.Value,
+		Headers: headers,
+		Topic:   topic,
+	}
+}
+
+// containsTopic reports whether the topic is in the list.
+func containsTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
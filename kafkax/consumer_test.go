@@ -0,0 +1,53 @@
+package kafkax_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/kafkax"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+)
+
+func TestConsumerConfigGetRetryPolicy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	cfg := kafkax.ConsumerConfig{Topic: "events"}
+	_, ok := cfg.GetRetryPolicy()
+	require.False(ok)
+
+	cfg.Dlq = true
+	cfg.RetryPolicy = &kafkax.RetryPolicy{
+		FinallyMoveToDlq: true,
+		Retries: []kafkax.RetryConfig{
+			{DelayInMs: 5000, MaxAttempts: 3},
+			{DelayInMs: 60000, MaxAttempts: 2},
+			{DelayInMs: 1500, MaxAttempts: -1},
+		},
+	}
+	policy, ok := cfg.GetRetryPolicy()
+	require.True(ok)
+	require.EqualValues(consumer.RetryPolicy{
+		Retries: []consumer.RetryTopic{
+			{Topic: "events.retry.5s", Delay: 5 * time.Second, MaxAttempts: 3},
+			{Topic: "events.retry.1m", Delay: time.Minute, MaxAttempts: 2},
+			{Topic: "events.retry.1500ms", Delay: 1500 * time.Millisecond, MaxAttempts: -1},
+		},
+		DlqTopic:         "events.dlq",
+		FinallyMoveToDlq: true,
+	}, policy)
+	require.EqualValues([]string{"events.retry.5s", "events.retry.1m", "events.retry.1500ms"}, policy.Topics())
+}
+
+func TestConsumerConfigGetLagCheckInterval(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	cfg := kafkax.ConsumerConfig{}
+	require.EqualValues(30*time.Second, cfg.GetLagCheckInterval())
+
+	interval := 5
+	cfg.LagCheckIntervalSec = &interval
+	require.EqualValues(5*time.Second, cfg.GetLagCheckInterval())
+}
@@ -1,14 +1,16 @@
 package kafkax
 
 import (
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
 	"github.com/txix-open/isp-kit/kafkax/handler"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/kafka_metrics"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka/consumer_tracing"
 )
 
 // NewResultHandler creates a new synchronous message handler with default
-// middlewares including logging, metrics, and panic recovery. The provided
+// middlewares including logging, metrics, tracing, and panic recovery. The provided
 // adapter implements the business logic for handling messages.
 func NewResultHandler(logger log.Logger, adapter handler.SyncHandlerAdapter) handler.Sync {
 	return handler.NewSync(
@@ -16,6 +18,21 @@ func NewResultHandler(logger log.Logger, adapter handler.SyncHandlerAdapter) han
 		adapter,
 		handler.Log(logger),
 		handler.Metrics(kafka_metrics.NewConsumerStorage(metrics.DefaultRegistry)),
+		consumer_tracing.NewConfig().Middleware(),
 		handler.Recovery(),
 	)
 }
+
+// NewBatchResultHandler creates a new batch handler with default middlewares
+// including logging, metrics, tracing, and panic recovery. The provided adapter
+// implements the business logic for handling batches of messages.
+func NewBatchResultHandler(logger log.Logger, adapter batch_handler.SyncHandlerAdapter) batch_handler.Sync {
+	return batch_handler.NewSync(
+		logger,
+		adapter,
+		batch_handler.Log(logger),
+		batch_handler.Metrics(kafka_metrics.NewConsumerStorage(metrics.DefaultRegistry)),
+		consumer_tracing.NewConfig().BatchMiddleware(),
+		batch_handler.Recovery(),
+	)
+}
@@ -19,9 +19,17 @@ type ConsumerMetricStorage interface {
 	IncRetryCount(consumerGroup, topic string)
 }
 
+// ConsumerDlqMetricStorage is optionally implemented by a ConsumerMetricStorage
+// counting messages moved to the dead letter topic.
+type ConsumerDlqMetricStorage interface {
+	IncDlqCount(consumerGroup, topic string)
+}
+
 // Metrics creates a middleware that records metrics for message processing,
 // including duration, message size, and commit/retry counts.
+// DLQ counts are recorded if the storage implements ConsumerDlqMetricStorage.
 func Metrics(metricStorage ConsumerMetricStorage) Middleware {
+	dlqMetricStorage, _ := metricStorage.(ConsumerDlqMetricStorage)
 	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
 		return SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) Result {
 			topic := delivery.Source().Topic
@@ -38,6 +46,8 @@ func Metrics(metricStorage ConsumerMetricStorage) Middleware {
 				metricStorage.IncCommitCount(consumerGroup, topic)
 			case result.Retry:
 				metricStorage.IncRetryCount(consumerGroup, topic)
+			case result.MoveToDlq && dlqMetricStorage != nil:
+				dlqMetricStorage.IncDlqCount(consumerGroup, topic)
 			}
 
 			return result
@@ -46,7 +56,7 @@ func Metrics(metricStorage ConsumerMetricStorage) Middleware {
 }
 
 // Log creates a middleware that logs the outcome of message processing,
-// including whether the message was committed, retried, or skipped.
+// including whether the message was committed, retried, moved to DLQ, or skipped.
 func Log(logger log.Logger) Middleware {
 	return func(next SyncHandlerAdapter) SyncHandlerAdapter {
 		return SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) Result {
@@ -75,6 +85,15 @@ func Log(logger log.Logger) Middleware {
 					log.String("error", result.RetryError.Error()),
 					log.String("retryAfter", result.RetryAfter.String()),
 				)
+			case result.MoveToDlq:
+				logger.Error(
+					ctx,
+					"kafka client: message will be moved to DLQ or dropped",
+					log.String("topic", topic),
+					log.Int32("partition", partition),
+					log.Int64("offset", offset),
+					log.Any("error", result.DlqError),
+				)
 			default:
 				logger.Debug(
 					ctx,
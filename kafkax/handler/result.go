@@ -5,12 +5,15 @@ import (
 )
 
 // Result defines the outcome of message processing, indicating whether to
-// commit the offset, retry processing, or skip the message.
+// commit the offset, retry processing, move the message to the dead letter topic,
+// or skip the message.
 type Result struct {
 	Commit     bool
 	Retry      bool
 	RetryError error
 	RetryAfter time.Duration
+	MoveToDlq  bool
+	DlqError   error
 }
 
 // Commit returns a Result indicating the message should be committed.
@@ -26,6 +29,15 @@ func Nothing() Result {
 
 // Retry returns a Result indicating the message should be retried after the
 // specified duration. The error will be logged for diagnostic purposes.
+// If the consumer has a retry policy, the message is republished to a retry
+// topic instead and the duration is only used when republishing fails.
 func Retry(after time.Duration, err error) Result {
 	return Result{Retry: true, RetryAfter: after, RetryError: err}
 }
+
+// MoveToDlq returns a Result indicating the message should be moved to the
+// dead letter topic. If no dead letter topic is configured, the message will
+// be dropped.
+func MoveToDlq(err error) Result {
+	return Result{MoveToDlq: true, DlqError: err}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/kafkax/consumer"
 	"github.com/txix-open/isp-kit/log"
 )
@@ -56,7 +57,8 @@ func NewSync(logger log.Logger, adapter SyncHandlerAdapter, middlewares ...Middl
 
 // Handle processes a message by calling the configured handler and acting
 // on the returned Result. It supports automatic retry with backoff and
-// error logging during offset committing.
+// error logging during offset committing. If the consumer has a retry policy,
+// retried messages are republished to retry topics without blocking the partition.
 func (r Sync) Handle(ctx context.Context, delivery *consumer.Delivery) {
 	for {
 		result := r.handler.Handle(ctx, delivery)
@@ -70,13 +72,31 @@ func (r Sync) Handle(ctx context.Context, delivery *consumer.Delivery) {
 				)
 			}
 			return
+		case result.MoveToDlq:
+			err := delivery.MoveToDlq(ctx, result.DlqError)
+			if err == nil {
+				return
+			}
+			r.logger.Error(
+				ctx, "kafka consumer: unexpected error during moving message to DLQ",
+				log.Any("error", err),
+			)
+			if delivery.Handled() || !r.wait(ctx, delivery, recoverMessageRetryPeriod) {
+				return
+			}
 		case result.Retry:
-			select {
-			case <-ctx.Done():
-				delivery.Done()
+			err := delivery.Retry(ctx, result.RetryError)
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, consumer.ErrRetryPolicyNotSet) {
+				r.logger.Error(
+					ctx, "kafka consumer: unexpected error during republishing message for retry",
+					log.Any("error", err),
+				)
+			}
+			if delivery.Handled() || !r.wait(ctx, delivery, result.RetryAfter) {
 				return
-			case <-time.After(result.RetryAfter):
-				continue
 			}
 		default:
 			delivery.Done()
@@ -84,3 +104,15 @@ func (r Sync) Handle(ctx context.Context, delivery *consumer.Delivery) {
 		}
 	}
 }
+
+// wait waits before the next processing attempt and returns false
+// if the context is done in the meantime.
+func (r Sync) wait(ctx context.Context, delivery *consumer.Delivery, after time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		delivery.Done()
+		return false
+	case <-time.After(after):
+		return true
+	}
+}
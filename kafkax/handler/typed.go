@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/serde"
+)
+
+const decodeRetryPeriod = 5 * time.Second
+
+// TypedFunc processes a consumed message decoded to a value of type T.
+type TypedFunc[T any] func(ctx context.Context, delivery *consumer.Delivery, value T) Result
+
+// Typed is a SyncHandlerAdapter decoding messages in the Confluent wire format
+// before passing them to the TypedFunc.
+// Malformed messages are moved to the dead letter topic or dropped if it is not configured.
+// If the schema can not be fetched from the registry, the message is retried after 5 seconds.
+type Typed[T any] struct {
+	serde   *serde.Serde[T]
+	handler TypedFunc[T]
+}
+
+// NewTyped creates a new Typed with the serde and the handler function.
+func NewTyped[T any](serde *serde.Serde[T], handler TypedFunc[T]) Typed[T] {
+	return Typed[T]{
+		serde:   serde,
+		handler: handler,
+	}
+}
+
+// Handle decodes the message and calls the handler function.
+func (h Typed[T]) Handle(ctx context.Context, delivery *consumer.Delivery) Result {
+	value, err := h.serde.Decode(ctx, delivery.Source().Value)
+	switch {
+	case errors.Is(err, serde.ErrMalformedMessage):
+		return MoveToDlq(err)
+	case err != nil:
+		return Retry(decodeRetryPeriod, err)
+	default:
+		return h.handler(ctx, delivery, value)
+	}
+}
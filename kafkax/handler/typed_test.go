@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/handler"
+	"github.com/txix-open/isp-kit/kafkax/serde"
+)
+
+type event struct {
+	Id int64 `avro:"id"`
+}
+
+type unavailableRegistry struct {
+	serde.Registry
+}
+
+func (unavailableRegistry) SchemaById(ctx context.Context, id int) (serde.Schema, error) {
+	return serde.Schema{}, errors.New("registry is unavailable")
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	codec, err := serde.NewAvro[event](`{"type":"record","name":"Event","fields":[{"name":"id","type":"long"}]}`)
+	require.NoError(err)
+	events := serde.NewSerde[event](serde.NewMemoryRegistry(), codec)
+	typed := handler.NewTyped(events, func(ctx context.Context, delivery *consumer.Delivery, value event) handler.Result {
+		require.EqualValues(1, value.Id)
+		return handler.Commit()
+	})
+	newDelivery := func(value []byte) *consumer.Delivery {
+		return consumer.NewDelivery(&sync.WaitGroup{}, nil, &kgo.Record{Topic: "events", Value: value}, "group")
+	}
+
+	data, err := events.Encode(t.Context(), "events", event{Id: 1})
+	require.NoError(err)
+	result := typed.Handle(t.Context(), newDelivery(data))
+	require.True(result.Commit)
+
+	result = typed.Handle(t.Context(), newDelivery([]byte("malformed")))
+	require.True(result.MoveToDlq)
+	require.ErrorIs(result.DlqError, serde.ErrMalformedMessage)
+
+	unavailable := handler.NewTyped(
+		serde.NewSerde[event](unavailableRegistry{}, codec),
+		func(ctx context.Context, delivery *consumer.Delivery, value event) handler.Result {
+			require.Fail("unexpected call")
+			return handler.Commit()
+		},
+	)
+	result = unavailable.Handle(t.Context(), newDelivery(data))
+	require.True(result.Retry)
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/kafkax"
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
 	"github.com/txix-open/isp-kit/kafkax/consumer"
 	"github.com/txix-open/isp-kit/kafkax/handler"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/requestid"
 	"github.com/txix-open/isp-kit/test"
 	"github.com/txix-open/isp-kit/test/kafkat"
+	"go.uber.org/atomic"
 )
 
 const (
@@ -252,3 +255,219 @@ func TestRecover(t *testing.T) {
 		require.Fail("handler wasn't called")
 	}
 }
+
+func TestRetryTopics(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	testKafka := kafkat.NewKafka(test)
+	topic := "test_retry_topics"
+	consumerCfg := testKafka.ConsumerConfig(topic, "testRetryTopics")
+	consumerCfg.Dlq = true
+	consumerCfg.RetryPolicy = &kafkax.RetryPolicy{
+		FinallyMoveToDlq: true,
+		Retries: []kafkax.RetryConfig{{
+			DelayInMs:   500,
+			MaxAttempts: 2,
+		}},
+	}
+	testKafka.CreateDefaultTopic(topic)
+	testKafka.CreateDefaultTopic(consumer.RetryTopicName(topic, 500*time.Millisecond))
+	testKafka.CreateDefaultTopic(consumer.DlqTopicName(topic))
+
+	time.Sleep(500 * time.Millisecond)
+
+	attempts := make([]int, 0)
+	handler1 := kafkax.NewResultHandler(
+		test.Logger(),
+		handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+			attempts = append(attempts, delivery.Attempt())
+			return handler.Retry(1*time.Second, errors.New("some error"))
+		}),
+	)
+	cons1 := consumerCfg.DefaultConsumer(t.Context(), test.Logger(), handler1)
+
+	client := kafkax.New(test.Logger())
+	client.UpgradeAndServe(t.Context(), kafkax.NewConfig(kafkax.WithConsumers(cons1)))
+
+	time.Sleep(500 * time.Millisecond)
+
+	testKafka.WriteMessages(&kgo.Record{
+		Topic: topic,
+		Value: []byte("test message"),
+	})
+
+	time.Sleep(5 * time.Second)
+	client.Close()
+
+	require.EqualValues([]int{0, 1, 2}, attempts)
+	msg := testKafka.ReadMessage(consumer.DlqTopicName(topic), 0)
+	require.EqualValues([]byte("test message"), msg.Value)
+	headers := make(map[string]string)
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	require.EqualValues("3", headers[consumer.HeaderAttempt])
+	require.EqualValues("some error", headers[consumer.HeaderError])
+	require.EqualValues(topic, headers[consumer.HeaderOriginalTopic])
+	require.EqualValues("0", headers[consumer.HeaderOriginalOffset])
+}
+
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	testKafka := kafkat.NewKafka(test)
+	inTopic := "test_transaction_in"
+	outTopic := "test_transaction_out"
+	testKafka.CreateDefaultTopic(inTopic)
+	testKafka.CreateDefaultTopic(outTopic)
+
+	time.Sleep(500 * time.Millisecond)
+
+	pubCfg := testKafka.PublisherConfig(outTopic)
+	pubCfg.TransactionalId = "test_transaction"
+	pub := pubCfg.DefaultPublisher(t.Context(), test.Logger())
+
+	consumerCfg := testKafka.ConsumerConfig(inTopic, "testTransaction")
+	consumerCfg.ReadCommitted = true
+	await := make(chan struct{})
+	handler1 := kafkax.NewResultHandler(
+		test.Logger(),
+		handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+			defer close(await)
+			err := pub.Transaction(ctx, delivery, &kgo.Record{
+				Value: append([]byte("transformed "), delivery.Source().Value...),
+			})
+			require.NoError(err)
+			require.True(delivery.Handled())
+			return handler.Nothing()
+		}),
+	)
+	cons := consumerCfg.DefaultConsumer(t.Context(), test.Logger(), handler1)
+
+	client := kafkax.New(test.Logger())
+	client.UpgradeAndServe(t.Context(), kafkax.NewConfig(
+		kafkax.WithPublishers(pub),
+		kafkax.WithConsumers(cons),
+	))
+
+	time.Sleep(500 * time.Millisecond)
+
+	testKafka.WriteMessages(&kgo.Record{
+		Topic: inTopic,
+		Value: []byte("message"),
+	})
+
+	select {
+	case <-await:
+	case <-time.After(15 * time.Second):
+		require.Fail("handler wasn't called")
+	}
+	client.Close()
+
+	msg := testKafka.ReadMessage(outTopic, 0)
+	require.EqualValues([]byte("transformed message"), msg.Value)
+}
+
+func TestBatchHandler(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	testKafka := kafkat.NewKafka(test)
+	topic := "test_batch_handler"
+	testKafka.CreateDefaultTopic(topic)
+
+	time.Sleep(500 * time.Millisecond)
+
+	consumerCfg := testKafka.ConsumerConfig(topic, "testBatchHandler")
+	consumerCfg.BatchSize = 3
+	consumerCfg.BatchPurgeIntervalMs = 500
+
+	lock := sync.Mutex{}
+	batches := make([][]string, 0)
+	retried := false
+	batchHandler := kafkax.NewBatchResultHandler(
+		test.Logger(),
+		batch_handler.SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) batch_handler.BatchResult {
+			lock.Lock()
+			defer lock.Unlock()
+
+			values := make([]string, 0, len(batch))
+			for _, delivery := range batch {
+				values = append(values, string(delivery.Source().Value))
+			}
+			batches = append(batches, values)
+
+			result := batch_handler.CommitAll()
+			if !retried && len(batch) == 3 {
+				retried = true
+				result.Set(1, handler.Retry(100*time.Millisecond, errors.New("some error")))
+			}
+			return result
+		}),
+	)
+	cons := consumerCfg.DefaultBatchConsumer(t.Context(), test.Logger(), batchHandler)
+
+	client := kafkax.New(test.Logger())
+	client.UpgradeAndServe(t.Context(), kafkax.NewConfig(kafkax.WithConsumers(cons)))
+
+	time.Sleep(500 * time.Millisecond)
+
+	for i := 1; i <= 4; i++ {
+		testKafka.WriteMessages(&kgo.Record{
+			Topic: topic,
+			Value: []byte(strconv.Itoa(i)),
+		})
+	}
+
+	time.Sleep(3 * time.Second)
+	client.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.EqualValues([][]string{{"1", "2", "3"}, {"2"}, {"4"}}, batches)
+}
+
+func TestConsumerLag(t *testing.T) {
+	t.Parallel()
+	test, require := test.New(t)
+
+	testKafka := kafkat.NewKafka(test)
+	topic := "test_consumer_lag"
+	testKafka.CreateDefaultTopic(topic)
+	consumerCfg := testKafka.ConsumerConfig(topic, "testConsumerLag")
+	maxLag := int64(1)
+	lagCheckInterval := 1
+	consumerCfg.MaxLag = &maxLag
+	consumerCfg.LagCheckIntervalSec = &lagCheckInterval
+
+	time.Sleep(500 * time.Millisecond)
+
+	handled := atomic.NewInt32(0)
+	resultHandler := kafkax.NewResultHandler(
+		test.Logger(),
+		handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+			handled.Inc()
+			return handler.Commit()
+		}),
+	)
+	cons := consumerCfg.DefaultConsumer(t.Context(), test.Logger(), resultHandler)
+
+	client := kafkax.New(test.Logger())
+	client.UpgradeAndServe(t.Context(), kafkax.NewConfig(kafkax.WithConsumers(cons)))
+	defer client.Close()
+
+	for i := range 3 {
+		testKafka.WriteMessages(&kgo.Record{
+			Topic: topic,
+			Value: []byte("test message " + strconv.Itoa(i)),
+		})
+	}
+
+	time.Sleep(5 * time.Second)
+
+	require.EqualValues(3, handled.Load())
+	require.EqualValues(0, cons.Lag())
+	require.NoError(client.Healthcheck(t.Context()))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/txix-open/isp-kit/kafkax/publisher"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/requestid"
+	"golang.org/x/time/rate"
 )
 
 // PublisherMetricStorage defines the interface for publisher metrics storage.
@@ -159,6 +160,22 @@ func ConsumerRequestId() consumer.Middleware {
 	}
 }
 
+// ConsumerRateLimit creates a middleware that limits the rate of message processing
+// with the token bucket limiter. If the context is done while waiting for a token,
+// the message is released without committing.
+func ConsumerRateLimit(limiter *rate.Limiter) consumer.Middleware {
+	return func(next consumer.Handler) consumer.Handler {
+		return consumer.HandlerFunc(func(ctx context.Context, delivery *consumer.Delivery) {
+			err := limiter.Wait(ctx)
+			if err != nil {
+				delivery.Done()
+				return
+			}
+			next.Handle(ctx, delivery)
+		})
+	}
+}
+
 // GetHeaderValue retrieves the value of a header with the specified key from
 // the provided headers slice. Returns an empty string if the key is not found.
 func GetHeaderValue(headers []kgo.RecordHeader, key string) string {
@@ -9,6 +9,7 @@ import (
 	"github.com/twmb/franz-go/plugin/kprom"
 	"github.com/txix-open/isp-kit/metrics"
 	"github.com/txix-open/isp-kit/metrics/kafka_metrics"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka/publisher_tracing"
 
 	"github.com/pkg/errors"
 	"github.com/txix-open/isp-kit/kafkax/publisher"
@@ -36,12 +37,25 @@ type PublisherConfig struct {
 	TLS                        *TLS     `schema:"Данные для установки TLS-соединения"`
 	DialTimeoutMs              *int     `schema:"Таймаут установки соединения, по умолчанию 5 секунд"`
 	MetricPublisherId          *string  `schema:"Идентификатор паблишера в метриках, при отсутствии метрики не отправляются"`
+
+	Idempotent      bool   `schema:"Идемпотентная запись,исключает дубликаты сообщений при повторных отправках, требует подтверждения всех реплик"`
+	TransactionalId string `schema:"Идентификатор транзакций,включает транзакционную запись через Publisher.Transaction, должен быть уникален для каждого экземпляра сервиса"`
+}
+
+// IsIdempotent reports whether idempotent writes are enabled.
+// Transactional writes are always idempotent.
+func (p PublisherConfig) IsIdempotent() bool {
+	return p.Idempotent || p.TransactionalId != ""
 }
 
 // GetRequiredAckLevel returns the required acknowledgment level for message
 // production. Returns LeaderAck() for level 1, AllISRAcks() for level -1,
-// and NoAck() for all other values.
+// and NoAck() for all other values. Idempotent writes always require AllISRAcks().
 func (p PublisherConfig) GetRequiredAckLevel() kgo.Acks {
+	if p.IsIdempotent() {
+		return kgo.AllISRAcks()
+	}
+
 	switch p.RequiredAckLevel {
 	case 1:
 		return kgo.LeaderAck()
@@ -99,8 +113,9 @@ func (p PublisherConfig) GetDialTimeout() time.Duration {
 }
 
 // DefaultPublisher creates a new publisher with default configuration, including
-// built-in metrics and request ID middlewares. Additional middlewares can be
+// built-in metrics, request ID and tracing middlewares. Additional middlewares can be
 // provided via restMiddlewares.
+// If TransactionalId is set, messages must be published only through Publisher.Transaction.
 func (p PublisherConfig) DefaultPublisher(
 	logCtx context.Context,
 	logger log.Logger,
@@ -124,7 +139,6 @@ func (p PublisherConfig) DefaultPublisher(
 	opts := []kgo.Opt{
 		kgo.SeedBrokers(p.Addresses...),
 		kgo.ProduceRequestTimeout(p.GetWriteTimeout()),
-		kgo.DisableIdempotentWrite(),
 		kgo.RequiredAcks(p.GetRequiredAckLevel()),
 		kgo.ProducerBatchMaxBytes(p.GetMaxMessageSizePerPartition() * bytesInMb),
 		kgo.MaxBufferedRecords(p.GetBatchSizePerPartition()),
@@ -134,10 +148,17 @@ func (p PublisherConfig) DefaultPublisher(
 		kgo.DialTLSConfig(tls),
 		kgo.WithLogger(NewLogger(logCtx, "kafka publisher", kgo.LogLevelError, logger)),
 	}
+	switch {
+	case p.TransactionalId != "":
+		opts = append(opts, kgo.TransactionalID(p.TransactionalId))
+	case !p.Idempotent:
+		opts = append(opts, kgo.DisableIdempotentWrite())
+	}
 
 	middlewares := []publisher.Middleware{
 		PublisherMetrics(kafka_metrics.NewPublisherStorage(metrics.DefaultRegistry)),
 		PublisherRequestId(),
+		publisher_tracing.NewConfig().Middleware(),
 	}
 	middlewares = append(middlewares, restMiddlewares...)
 
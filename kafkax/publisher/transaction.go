@@ -0,0 +1,173 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+)
+
+var (
+	// ErrTransactionOutcomeUnknown is returned by Publisher.Transaction when committing
+	// the transaction fails in a way the transaction may have been committed anyway.
+	ErrTransactionOutcomeUnknown = errors.New("transaction outcome is unknown")
+)
+
+// Transaction publishes messages and commits the offset of the consumed delivery
+// in a single Kafka transaction, which gives exactly-once consume-transform-produce
+// processing when the consumer reads only committed messages.
+// If publishing or committing fails, the transaction is aborted and neither the
+// messages nor the offset become visible. If the commit request itself fails,
+// the returned error wraps ErrTransactionOutcomeUnknown unless the transaction
+// is known to be not committed.
+//
+// The publisher must be created with a transactional id. Transactions of the publisher
+// are serialized, so it can be shared between consumer workers.
+// The delivery may be nil to publish messages atomically without committing an offset.
+// If no messages are provided, the delivery is committed as usual.
+func (p *Publisher) Transaction(ctx context.Context, delivery *consumer.Delivery, rs ...*kgo.Record) error {
+	if delivery != nil && delivery.Handled() {
+		return consumer.ErrDeliveryAlreadyHandled
+	}
+	if len(rs) == 0 {
+		if delivery == nil {
+			return nil
+		}
+		return delivery.Commit(ctx)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	err := p.client.BeginTransaction()
+	if err != nil {
+		return errors.WithMessage(err, "begin transaction")
+	}
+
+	err = p.Publish(ctx, rs...)
+	if err == nil && delivery != nil {
+		err = p.commitDeliveryOffset(ctx, delivery)
+	}
+	if err != nil {
+		abortErr := p.abortTransaction(ctx)
+		if abortErr != nil {
+			return errors.WithMessagef(err, "abort transaction: %v", abortErr)
+		}
+		return err
+	}
+
+	err = p.client.EndTransaction(context.WithoutCancel(ctx), kgo.TryCommit)
+	if err != nil {
+		return p.commitTransactionError(ctx, err)
+	}
+
+	if delivery != nil {
+		// the offset is already committed in the transaction, committing it again may fail
+		// after the consumer group rebalance and report the committed transaction as failed
+		_ = delivery.MarkCommitted()
+	}
+	return nil
+}
+
+// commitTransactionError aborts the transaction after the failed commit and describes
+// whether the transaction may have been committed.
+func (p *Publisher) commitTransactionError(ctx context.Context, err error) error {
+	notCommitted := errors.Is(err, kerr.OperationNotAttempted) || errors.Is(err, kerr.TransactionAbortable)
+	if !notCommitted {
+		err = errors.WithMessagef(ErrTransactionOutcomeUnknown, "%v", err)
+	}
+	err = errors.WithMessage(err, "commit transaction")
+
+	abortErr := p.abortTransaction(ctx)
+	if abortErr != nil {
+		return errors.WithMessagef(err, "abort transaction: %v", abortErr)
+	}
+	return err
+}
+
+// commitDeliveryOffset adds the offset of the delivery to the current transaction
+// on behalf of the consumer group member which received the delivery.
+func (p *Publisher) commitDeliveryOffset(ctx context.Context, delivery *consumer.Delivery) error {
+	txnId := transactionalId(p.client)
+	producerId, producerEpoch, err := p.client.ProducerID(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "get producer id")
+	}
+	groupId := delivery.ConsumerGroupId()
+
+	addReq := kmsg.NewPtrAddOffsetsToTxnRequest()
+	addReq.TransactionalID = txnId
+	addReq.ProducerID = producerId
+	addReq.ProducerEpoch = producerEpoch
+	addReq.Group = groupId
+	addResp, err := addReq.RequestWith(ctx, p.client)
+	if err != nil {
+		return errors.WithMessage(err, "add offsets to transaction")
+	}
+	err = kerr.ErrorForCode(addResp.ErrorCode)
+	if err != nil {
+		return errors.WithMessage(err, "add offsets to transaction")
+	}
+
+	memberId, generation := delivery.GroupMetadata()
+	source := delivery.Source()
+	partition := kmsg.NewTxnOffsetCommitRequestTopicPartition()
+	partition.Partition = source.Partition
+	partition.Offset = source.Offset + 1
+	partition.LeaderEpoch = source.LeaderEpoch
+	topic := kmsg.NewTxnOffsetCommitRequestTopic()
+	topic.Topic = source.Topic
+	topic.Partitions = []kmsg.TxnOffsetCommitRequestTopicPartition{partition}
+
+	commitReq := kmsg.NewPtrTxnOffsetCommitRequest()
+	commitReq.TransactionalID = txnId
+	commitReq.Group = groupId
+	commitReq.ProducerID = producerId
+	commitReq.ProducerEpoch = producerEpoch
+	commitReq.Generation = generation
+	commitReq.MemberID = memberId
+	commitReq.Topics = []kmsg.TxnOffsetCommitRequestTopic{topic}
+	commitResp, err := commitReq.RequestWith(ctx, p.client)
+	if err != nil {
+		return errors.WithMessage(err, "commit offset in transaction")
+	}
+	for _, topic := range commitResp.Topics {
+		for _, partition := range topic.Partitions {
+			err = kerr.ErrorForCode(partition.ErrorCode)
+			if err != nil {
+				return errors.WithMessagef(err, "commit offset in transaction for '%s'", topic.Topic)
+			}
+		}
+	}
+	return nil
+}
+
+// abortTransaction drops buffered messages and aborts the current transaction.
+func (p *Publisher) abortTransaction(ctx context.Context) error {
+	ctx = context.WithoutCancel(ctx)
+	err := p.client.AbortBufferedRecords(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "abort buffered records")
+	}
+	err = p.client.EndTransaction(ctx, kgo.TryAbort)
+	if err != nil {
+		return errors.WithMessage(err, "end transaction")
+	}
+	return nil
+}
+
+// transactionalId returns the transactional id the client is configured with.
+func transactionalId(client *kgo.Client) string {
+	switch id := client.OptValue(kgo.TransactionalID).(type) {
+	case string:
+		return id
+	case *string:
+		if id != nil {
+			return *id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax/serde"
+)
+
+// Typed publishes values of type T encoded in the Confluent wire format to the publisher topic.
+type Typed[T any] struct {
+	publisher *Publisher
+	serde     *serde.Serde[T]
+}
+
+// NewTyped creates a new Typed publisher on top of the publisher.
+//
+// Example:
+//
+//	events := publisher.NewTyped(pub, serde.NewSerde[Event](registry, codec))
+//	err := events.Publish(ctx, Event{Id: 1})
+func NewTyped[T any](publisher *Publisher, serde *serde.Serde[T]) Typed[T] {
+	return Typed[T]{
+		publisher: publisher,
+		serde:     serde,
+	}
+}
+
+// Record encodes the value into a record for the publisher topic.
+// Use it to set the key or headers before publishing the record with Publisher.Publish.
+func (p Typed[T]) Record(ctx context.Context, value T) (*kgo.Record, error) {
+	data, err := p.serde.Encode(ctx, p.publisher.topic, value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "encode record")
+	}
+	return &kgo.Record{
+		Topic: p.publisher.topic,
+		Value: data,
+	}, nil
+}
+
+// Publish encodes values and publishes them in a single call.
+func (p Typed[T]) Publish(ctx context.Context, values ...T) error {
+	records := make([]*kgo.Record, 0, len(values))
+	for _, value := range values {
+		record, err := p.Record(ctx, value)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	return p.publisher.Publish(ctx, records...)
+}
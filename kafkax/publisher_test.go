@@ -0,0 +1,26 @@
+package kafkax_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax"
+)
+
+func TestPublisherConfigIdempotent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	cfg := kafkax.PublisherConfig{RequiredAckLevel: 1}
+	require.False(cfg.IsIdempotent())
+	require.EqualValues(kgo.LeaderAck(), cfg.GetRequiredAckLevel())
+
+	cfg.Idempotent = true
+	require.True(cfg.IsIdempotent())
+	require.EqualValues(kgo.AllISRAcks(), cfg.GetRequiredAckLevel())
+
+	cfg = kafkax.PublisherConfig{TransactionalId: "service-1"}
+	require.True(cfg.IsIdempotent())
+	require.EqualValues(kgo.AllISRAcks(), cfg.GetRequiredAckLevel())
+}
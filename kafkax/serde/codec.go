@@ -0,0 +1,238 @@
+package serde
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/hamba/avro/v2"
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/xeipuuv/gojsonschema"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Codec defines the interface for encoding values of type T according to a schema.
+type Codec[T any] interface {
+	// Schema returns the schema values are encoded with.
+	Schema() Schema
+	// Encode encodes the value into the payload following the wire format header.
+	Encode(value T) ([]byte, error)
+	// Decode decodes the payload written with the writer schema.
+	Decode(data []byte, writer Schema) (T, error)
+}
+
+// Avro is a Codec for Apache Avro.
+// Values are decoded with the writer schema, so fields are matched by name and
+// fields missing in the writer schema keep their zero values.
+type Avro[T any] struct {
+	raw     string
+	schema  avro.Schema
+	writers *sync.Map
+}
+
+// NewAvro creates a new Avro codec with the schema in the JSON representation.
+// The value type must be compatible with the schema, see github.com/hamba/avro for the mapping rules.
+func NewAvro[T any](schema string) (Avro[T], error) {
+	parsed, err := parseAvro(schema)
+	if err != nil {
+		return Avro[T]{}, err
+	}
+	return Avro[T]{
+		raw:     schema,
+		schema:  parsed,
+		writers: &sync.Map{},
+	}, nil
+}
+
+// Schema returns the Avro schema of the codec.
+func (c Avro[T]) Schema() Schema {
+	return Schema{Type: TypeAvro, Schema: c.raw}
+}
+
+// Encode encodes the value in the Avro binary encoding.
+func (c Avro[T]) Encode(value T) ([]byte, error) {
+	data, err := avro.Marshal(c.schema, value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "avro marshal")
+	}
+	return data, nil
+}
+
+// Decode decodes the Avro binary encoding written with the writer schema.
+func (c Avro[T]) Decode(data []byte, writer Schema) (T, error) {
+	var value T
+	writerSchema := c.schema
+	if writer.Schema != c.raw {
+		cached, ok := c.writers.Load(writer.Schema)
+		if ok {
+			writerSchema = cached.(avro.Schema) // nolint:forcetypeassert
+		} else {
+			parsed, err := parseAvro(writer.Schema)
+			if err != nil {
+				return value, err
+			}
+			c.writers.Store(writer.Schema, parsed)
+			writerSchema = parsed
+		}
+	}
+
+	err := avro.Unmarshal(writerSchema, data, &value)
+	if err != nil {
+		return value, errors.WithMessagef(ErrMalformedMessage, "avro unmarshal: %v", err)
+	}
+	return value, nil
+}
+
+// parseAvro parses the schema isolated from other schemas with the same names.
+func parseAvro(schema string) (avro.Schema, error) {
+	parsed, err := avro.ParseWithCache(schema, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse avro schema")
+	}
+	return parsed, nil
+}
+
+// Protobuf is a Codec for Protocol Buffers messages.
+// The payload is prefixed with the message indexes of the message type in the schema
+// as required by the Confluent wire format.
+type Protobuf[T proto.Message] struct {
+	schema  string
+	indexes []int
+}
+
+// NewProtobuf creates a new Protobuf codec with the .proto file content defining the message type T.
+func NewProtobuf[T proto.Message](schema string) Protobuf[T] {
+	var zero T
+	return Protobuf[T]{
+		schema:  schema,
+		indexes: messageIndexes(zero.ProtoReflect().Descriptor()),
+	}
+}
+
+// Schema returns the Protocol Buffers schema of the codec.
+func (c Protobuf[T]) Schema() Schema {
+	return Schema{Type: TypeProtobuf, Schema: c.schema}
+}
+
+// Encode encodes the message with the message indexes prefix.
+func (c Protobuf[T]) Encode(value T) ([]byte, error) {
+	data := appendMessageIndexes(nil, c.indexes)
+	data, err := proto.MarshalOptions{}.MarshalAppend(data, value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "proto marshal")
+	}
+	return data, nil
+}
+
+// Decode skips the message indexes prefix and decodes the message.
+func (c Protobuf[T]) Decode(data []byte, _ Schema) (T, error) {
+	var zero T
+	payload, err := skipMessageIndexes(data)
+	if err != nil {
+		return zero, err
+	}
+
+	value, ok := zero.ProtoReflect().Type().New().Interface().(T)
+	if !ok {
+		return zero, errors.New("unexpected proto message type")
+	}
+	err = proto.Unmarshal(payload, value)
+	if err != nil {
+		return zero, errors.WithMessagef(ErrMalformedMessage, "proto unmarshal: %v", err)
+	}
+	return value, nil
+}
+
+// messageIndexes returns the path of indexes of the message type from the top level of its file.
+func messageIndexes(desc protoreflect.MessageDescriptor) []int {
+	indexes := []int{desc.Index()}
+	for {
+		parent, ok := desc.Parent().(protoreflect.MessageDescriptor)
+		if !ok {
+			return indexes
+		}
+		indexes = append([]int{parent.Index()}, indexes...)
+		desc = parent
+	}
+}
+
+// appendMessageIndexes appends the zigzag varint encoded message indexes.
+// The most common case of the first message in the file is encoded as a single 0.
+func appendMessageIndexes(dst []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return binary.AppendVarint(dst, 0)
+	}
+	dst = binary.AppendVarint(dst, int64(len(indexes)))
+	for _, index := range indexes {
+		dst = binary.AppendVarint(dst, int64(index))
+	}
+	return dst
+}
+
+// skipMessageIndexes returns the payload following the message indexes.
+func skipMessageIndexes(data []byte) ([]byte, error) {
+	count, n := binary.Varint(data)
+	if n <= 0 || count < 0 {
+		return nil, errors.WithMessage(ErrMalformedMessage, "invalid message indexes")
+	}
+	data = data[n:]
+	for range count {
+		_, n = binary.Varint(data)
+		if n <= 0 {
+			return nil, errors.WithMessage(ErrMalformedMessage, "invalid message indexes")
+		}
+		data = data[n:]
+	}
+	return data, nil
+}
+
+// JsonSchema is a Codec for JSON values described by a JSON Schema.
+// Values are validated against the schema on encoding.
+type JsonSchema[T any] struct {
+	raw    string
+	schema *gojsonschema.Schema
+}
+
+// NewJsonSchema creates a new JsonSchema codec with the JSON Schema document.
+func NewJsonSchema[T any](schema string) (JsonSchema[T], error) {
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	if err != nil {
+		return JsonSchema[T]{}, errors.WithMessage(err, "compile json schema")
+	}
+	return JsonSchema[T]{
+		raw:    schema,
+		schema: compiled,
+	}, nil
+}
+
+// Schema returns the JSON Schema of the codec.
+func (c JsonSchema[T]) Schema() Schema {
+	return Schema{Type: TypeJson, Schema: c.raw}
+}
+
+// Encode encodes the value to JSON and validates it against the schema.
+func (c JsonSchema[T]) Encode(value T) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "json marshal")
+	}
+	result, err := c.schema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, errors.WithMessage(err, "validate json")
+	}
+	if !result.Valid() {
+		return nil, errors.Errorf("value does not match json schema: %v", result.Errors())
+	}
+	return data, nil
+}
+
+// Decode decodes the JSON value.
+func (c JsonSchema[T]) Decode(data []byte, _ Schema) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return value, errors.WithMessagef(ErrMalformedMessage, "json unmarshal: %v", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,205 @@
+package serde
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/sr"
+)
+
+var (
+	// ErrSchemaNotFound is returned when a schema is not registered.
+	ErrSchemaNotFound = errors.New("schema not found")
+)
+
+// SchemaType is the type of schema as it is named by the Schema Registry.
+type SchemaType string
+
+const (
+	// TypeAvro is the Apache Avro schema type.
+	TypeAvro SchemaType = "AVRO"
+	// TypeProtobuf is the Protocol Buffers schema type.
+	TypeProtobuf SchemaType = "PROTOBUF"
+	// TypeJson is the JSON Schema type.
+	TypeJson SchemaType = "JSON"
+)
+
+// Schema is a schema stored in the Schema Registry.
+type Schema struct {
+	Type   SchemaType
+	Schema string
+}
+
+// Registry defines the interface of a Schema Registry client.
+type Registry interface {
+	// Register registers the schema under the subject if it is not registered yet and returns its id.
+	Register(ctx context.Context, subject string, schema Schema) (int, error)
+	// Lookup returns the id of the schema registered under the subject or ErrSchemaNotFound.
+	Lookup(ctx context.Context, subject string, schema Schema) (int, error)
+	// SchemaById returns the schema with the id or ErrSchemaNotFound.
+	SchemaById(ctx context.Context, id int) (Schema, error)
+}
+
+// Config holds the configuration of a Schema Registry client.
+type Config struct {
+	Urls     []string `validate:"required" schema:"Список адресов Schema Registry"`
+	Username string   `schema:"Имя пользователя"`
+	Password string   `schema:"Пароль"`
+}
+
+// NewRegistry creates a new ClientRegistry for the configured Schema Registry.
+func (c Config) NewRegistry(opts ...sr.ClientOpt) (ClientRegistry, error) {
+	clientOpts := []sr.ClientOpt{sr.URLs(c.Urls...)}
+	if c.Username != "" {
+		clientOpts = append(clientOpts, sr.BasicAuth(c.Username, c.Password))
+	}
+	client, err := sr.NewClient(append(clientOpts, opts...)...)
+	if err != nil {
+		return ClientRegistry{}, errors.WithMessage(err, "new schema registry client")
+	}
+	return NewClientRegistry(client), nil
+}
+
+// ClientRegistry is a Registry backed by the Schema Registry REST API.
+type ClientRegistry struct {
+	client *sr.Client
+}
+
+// NewClientRegistry creates a new ClientRegistry using the franz-go Schema Registry client.
+func NewClientRegistry(client *sr.Client) ClientRegistry {
+	return ClientRegistry{
+		client: client,
+	}
+}
+
+// Register registers the schema under the subject if it is not registered yet and returns its id.
+func (r ClientRegistry) Register(ctx context.Context, subject string, schema Schema) (int, error) {
+	subjectSchema, err := r.client.CreateSchema(ctx, subject, toSrSchema(schema))
+	if err != nil {
+		return 0, errors.WithMessagef(err, "register schema for '%s'", subject)
+	}
+	return subjectSchema.ID, nil
+}
+
+// Lookup returns the id of the schema registered under the subject or ErrSchemaNotFound.
+func (r ClientRegistry) Lookup(ctx context.Context, subject string, schema Schema) (int, error) {
+	subjectSchema, err := r.client.LookupSchema(ctx, subject, toSrSchema(schema))
+	if isNotFound(err) {
+		return 0, errors.WithMessagef(ErrSchemaNotFound, "lookup schema for '%s'", subject)
+	}
+	if err != nil {
+		return 0, errors.WithMessagef(err, "lookup schema for '%s'", subject)
+	}
+	return subjectSchema.ID, nil
+}
+
+// SchemaById returns the schema with the id or ErrSchemaNotFound.
+func (r ClientRegistry) SchemaById(ctx context.Context, id int) (Schema, error) {
+	schema, err := r.client.SchemaByID(ctx, id)
+	if isNotFound(err) {
+		return Schema{}, errors.WithMessagef(ErrSchemaNotFound, "get schema %d", id)
+	}
+	if err != nil {
+		return Schema{}, errors.WithMessagef(err, "get schema %d", id)
+	}
+
+	schemaType := TypeAvro
+	switch schema.Type {
+	case sr.TypeProtobuf:
+		schemaType = TypeProtobuf
+	case sr.TypeJSON:
+		schemaType = TypeJson
+	}
+	return Schema{Type: schemaType, Schema: schema.Schema}, nil
+}
+
+// toSrSchema converts the schema to the franz-go representation.
+func toSrSchema(schema Schema) sr.Schema {
+	schemaType := sr.TypeAvro
+	switch schema.Type {
+	case TypeProtobuf:
+		schemaType = sr.TypeProtobuf
+	case TypeJson:
+		schemaType = sr.TypeJSON
+	}
+	return sr.Schema{Type: schemaType, Schema: schema.Schema}
+}
+
+// isNotFound reports whether the error is a "not found" response of the Schema Registry.
+func isNotFound(err error) bool {
+	var responseErr *sr.ResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+	switch responseErr.ErrorCode {
+	case sr.ErrSubjectNotFound.Code, sr.ErrSchemaNotFound.Code:
+		return true
+	default:
+		return false
+	}
+}
+
+// MemoryRegistry is an in-memory Registry for tests and local development.
+// Schemas are assigned sequential ids starting from 1, the same schema gets
+// the same id in all subjects.
+//
+// MemoryRegistry is safe for concurrent use by multiple goroutines.
+type MemoryRegistry struct {
+	lock     sync.Locker
+	ids      map[Schema]int
+	schemas  map[int]Schema
+	subjects map[string]map[int]bool
+}
+
+// NewMemoryRegistry creates a new empty MemoryRegistry.
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		lock:     &sync.Mutex{},
+		ids:      make(map[Schema]int),
+		schemas:  make(map[int]Schema),
+		subjects: make(map[string]map[int]bool),
+	}
+}
+
+// Register registers the schema under the subject if it is not registered yet and returns its id.
+func (r *MemoryRegistry) Register(_ context.Context, subject string, schema Schema) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id, ok := r.ids[schema]
+	if !ok {
+		id = len(r.ids) + 1
+		r.ids[schema] = id
+		r.schemas[id] = schema
+	}
+	if r.subjects[subject] == nil {
+		r.subjects[subject] = make(map[int]bool)
+	}
+	r.subjects[subject][id] = true
+	return id, nil
+}
+
+// Lookup returns the id of the schema registered under the subject or ErrSchemaNotFound.
+func (r *MemoryRegistry) Lookup(_ context.Context, subject string, schema Schema) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	id, ok := r.ids[schema]
+	if !ok || !r.subjects[subject][id] {
+		return 0, errors.WithMessagef(ErrSchemaNotFound, "lookup schema for '%s'", subject)
+	}
+	return id, nil
+}
+
+// SchemaById returns the schema with the id or ErrSchemaNotFound.
+func (r *MemoryRegistry) SchemaById(_ context.Context, id int) (Schema, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	schema, ok := r.schemas[id]
+	if !ok {
+		return Schema{}, errors.WithMessagef(ErrSchemaNotFound, "get schema %d", id)
+	}
+	return schema, nil
+}
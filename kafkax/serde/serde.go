@@ -0,0 +1,167 @@
+// Package serde provides schema-aware serialization of Kafka messages in the
+// Confluent wire format: a magic byte and a schema id from the Schema Registry
+// followed by the payload encoded with Avro, Protocol Buffers or JSON Schema codecs.
+package serde
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SubjectNameStrategy returns the Schema Registry subject for the topic.
+type SubjectNameStrategy func(topic string) string
+
+// TopicNameStrategy returns the subject of message values of the topic, "<topic>-value".
+func TopicNameStrategy(topic string) string {
+	return topic + "-value"
+}
+
+// Option is a function that configures a Serde.
+type Option func(o *options)
+
+// options holds the Serde configuration.
+type options struct {
+	autoRegister        bool
+	subjectNameStrategy SubjectNameStrategy
+}
+
+// WithAutoRegister enables or disables registering the schema on encoding.
+// If disabled, the schema must be registered in advance.
+// By default, it is enabled.
+func WithAutoRegister(enabled bool) Option {
+	return func(o *options) {
+		o.autoRegister = enabled
+	}
+}
+
+// WithSubjectNameStrategy sets the strategy of the subject for topics.
+// By default, TopicNameStrategy is used.
+func WithSubjectNameStrategy(strategy SubjectNameStrategy) Option {
+	return func(o *options) {
+		o.subjectNameStrategy = strategy
+	}
+}
+
+// Serde encodes and decodes values of type T in the Confluent wire format.
+// Schema ids and schemas fetched from the registry are cached.
+//
+// Serde is safe for concurrent use by multiple goroutines.
+type Serde[T any] struct {
+	registry Registry
+	codec    Codec[T]
+	options  options
+	ids      *sync.Map
+	schemas  *sync.Map
+}
+
+// NewSerde creates a new Serde with the registry and the codec.
+//
+// Example:
+//
+//	codec, err := serde.NewAvro[Event](eventSchema)
+//	events := serde.NewSerde[Event](registry, codec)
+func NewSerde[T any](registry Registry, codec Codec[T], opts ...Option) *Serde[T] {
+	o := options{
+		autoRegister:        true,
+		subjectNameStrategy: TopicNameStrategy,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Serde[T]{
+		registry: registry,
+		codec:    codec,
+		options:  o,
+		ids:      &sync.Map{},
+		schemas:  &sync.Map{},
+	}
+}
+
+// Encode encodes the value for the topic with the header containing the schema id.
+// The schema is registered under the subject of the topic if auto-registration is enabled.
+func (s *Serde[T]) Encode(ctx context.Context, topic string, value T) ([]byte, error) {
+	subject := s.options.subjectNameStrategy(topic)
+	id, err := s.schemaId(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := s.codec.Encode(value)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "encode value for '%s'", subject)
+	}
+	data := make([]byte, 0, headerSize+len(payload))
+	data = AppendHeader(data, id)
+	return append(data, payload...), nil
+}
+
+// Decode decodes the value written with the schema referenced by the header.
+// Errors caused by the message content wrap ErrMalformedMessage.
+func (s *Serde[T]) Decode(ctx context.Context, data []byte) (T, error) {
+	var value T
+	id, payload, err := ParseHeader(data)
+	if err != nil {
+		return value, err
+	}
+	schema, err := s.schemaById(ctx, id)
+	if err != nil {
+		return value, err
+	}
+	expectedType := s.codec.Schema().Type
+	if schema.Type != expectedType {
+		return value, errors.WithMessagef(
+			ErrMalformedMessage,
+			"schema %d has type %s, expected %s", id, schema.Type, expectedType,
+		)
+	}
+
+	value, err = s.codec.Decode(payload, schema)
+	if err != nil {
+		return value, errors.WithMessagef(err, "decode value with schema %d", id)
+	}
+	return value, nil
+}
+
+// schemaId returns the cached id of the codec schema in the subject,
+// registering the schema or looking it up on the first call.
+func (s *Serde[T]) schemaId(ctx context.Context, subject string) (int, error) {
+	cached, ok := s.ids.Load(subject)
+	if ok {
+		return cached.(int), nil // nolint:forcetypeassert
+	}
+
+	var (
+		id  int
+		err error
+	)
+	if s.options.autoRegister {
+		id, err = s.registry.Register(ctx, subject, s.codec.Schema())
+	} else {
+		id, err = s.registry.Lookup(ctx, subject, s.codec.Schema())
+	}
+	if err != nil {
+		return 0, errors.WithMessage(err, "get schema id")
+	}
+	s.ids.Store(subject, id)
+	return id, nil
+}
+
+// schemaById returns the cached schema with the id, fetching it from the registry on the first call.
+func (s *Serde[T]) schemaById(ctx context.Context, id int) (Schema, error) {
+	cached, ok := s.schemas.Load(id)
+	if ok {
+		return cached.(Schema), nil // nolint:forcetypeassert
+	}
+
+	schema, err := s.registry.SchemaById(ctx, id)
+	if errors.Is(err, ErrSchemaNotFound) {
+		return Schema{}, errors.WithMessagef(ErrMalformedMessage, "unknown schema %d", id)
+	}
+	if err != nil {
+		return Schema{}, errors.WithMessage(err, "get schema")
+	}
+	s.schemas.Store(id, schema)
+	return schema, nil
+}
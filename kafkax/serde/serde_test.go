@@ -0,0 +1,154 @@
+package serde_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/sr/srfake"
+	"github.com/txix-open/isp-kit/kafkax/serde"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type event struct {
+	Id   int64  `avro:"id" json:"id"`
+	Name string `avro:"name" json:"name"`
+}
+
+const (
+	eventAvroSchema = `{"type":"record","name":"Event","fields":[{"name":"id","type":"long"},{"name":"name","type":"string"}]}`
+	eventJsonSchema = `{"type":"object","properties":{"id":{"type":"integer"},"name":{"type":"string","minLength":1}},"required":["id","name"]}`
+)
+
+func TestAvro(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	codec, err := serde.NewAvro[event](eventAvroSchema)
+	require.NoError(err)
+	registry := serde.NewMemoryRegistry()
+	s := serde.NewSerde[event](registry, codec)
+
+	data, err := s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.NoError(err)
+	id, _, err := serde.ParseHeader(data)
+	require.NoError(err)
+	registeredId, err := registry.Lookup(context.Background(), "events-value", codec.Schema())
+	require.NoError(err)
+	require.EqualValues(registeredId, id)
+
+	value, err := s.Decode(context.Background(), data)
+	require.NoError(err)
+	require.EqualValues(event{Id: 1, Name: "created"}, value)
+
+	evolvedSchema := `{"type":"record","name":"Event","fields":[{"name":"id","type":"long"},{"name":"name","type":"string"},{"name":"source","type":"string","default":""}]}`
+	evolvedCodec, err := serde.NewAvro[map[string]any](evolvedSchema)
+	require.NoError(err)
+	data, err = serde.NewSerde[map[string]any](registry, evolvedCodec).
+		Encode(context.Background(), "events", map[string]any{"id": int64(2), "name": "updated", "source": "test"})
+	require.NoError(err)
+	value, err = s.Decode(context.Background(), data)
+	require.NoError(err)
+	require.EqualValues(event{Id: 2, Name: "updated"}, value)
+}
+
+func TestProtobuf(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := serde.NewSerde[*wrapperspb.StringValue](
+		serde.NewMemoryRegistry(),
+		serde.NewProtobuf[*wrapperspb.StringValue](`syntax = "proto3"; message StringValue { string value = 1; }`),
+	)
+
+	data, err := s.Encode(context.Background(), "strings", wrapperspb.String("value"))
+	require.NoError(err)
+	_, payload, err := serde.ParseHeader(data)
+	require.NoError(err)
+	// StringValue is the 8th message of wrappers.proto
+	require.EqualValues([]byte{2, 14}, payload[:2])
+
+	value, err := s.Decode(context.Background(), data)
+	require.NoError(err)
+	require.True(proto.Equal(wrapperspb.String("value"), value))
+}
+
+func TestJsonSchema(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	codec, err := serde.NewJsonSchema[event](eventJsonSchema)
+	require.NoError(err)
+	s := serde.NewSerde[event](serde.NewMemoryRegistry(), codec)
+
+	data, err := s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.NoError(err)
+	value, err := s.Decode(context.Background(), data)
+	require.NoError(err)
+	require.EqualValues(event{Id: 1, Name: "created"}, value)
+
+	_, err = s.Encode(context.Background(), "events", event{Id: 1})
+	require.Error(err)
+}
+
+func TestMalformedMessage(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	codec, err := serde.NewJsonSchema[event](eventJsonSchema)
+	require.NoError(err)
+	s := serde.NewSerde[event](serde.NewMemoryRegistry(), codec)
+
+	_, err = s.Decode(context.Background(), []byte(`{"id":1}`))
+	require.ErrorIs(err, serde.ErrMalformedMessage)
+
+	_, err = s.Decode(context.Background(), serde.AppendHeader(nil, 100))
+	require.ErrorIs(err, serde.ErrMalformedMessage)
+
+	data, err := s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.NoError(err)
+	_, err = s.Decode(context.Background(), append(data[:5], "{"...))
+	require.ErrorIs(err, serde.ErrMalformedMessage)
+}
+
+func TestWithoutAutoRegister(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	codec, err := serde.NewAvro[event](eventAvroSchema)
+	require.NoError(err)
+	registry := serde.NewMemoryRegistry()
+	s := serde.NewSerde[event](registry, codec, serde.WithAutoRegister(false))
+
+	_, err = s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.ErrorIs(err, serde.ErrSchemaNotFound)
+
+	_, err = registry.Register(context.Background(), "events-value", codec.Schema())
+	require.NoError(err)
+	_, err = s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.NoError(err)
+}
+
+func TestClientRegistry(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	fake := srfake.New()
+	t.Cleanup(fake.Close)
+	registry, err := serde.Config{Urls: []string{fake.URL()}}.NewRegistry()
+	require.NoError(err)
+
+	codec, err := serde.NewAvro[event](eventAvroSchema)
+	require.NoError(err)
+	_, err = registry.Lookup(context.Background(), "events-value", codec.Schema())
+	require.ErrorIs(err, serde.ErrSchemaNotFound)
+
+	s := serde.NewSerde[event](registry, codec)
+	data, err := s.Encode(context.Background(), "events", event{Id: 1, Name: "created"})
+	require.NoError(err)
+
+	value, err := serde.NewSerde[event](registry, codec).Decode(context.Background(), data)
+	require.NoError(err)
+	require.EqualValues(event{Id: 1, Name: "created"}, value)
+}
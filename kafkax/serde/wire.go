@@ -0,0 +1,35 @@
+package serde
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	magicByte  = 0
+	headerSize = 5
+)
+
+var (
+	// ErrMalformedMessage is returned when a message can not be decoded,
+	// e.g. it is not in the Confluent wire format or does not match the schema.
+	ErrMalformedMessage = errors.New("malformed message")
+)
+
+// AppendHeader appends the Confluent wire format header, the magic byte
+// followed by the big-endian schema id, to dst.
+func AppendHeader(dst []byte, id int) []byte {
+	dst = append(dst, magicByte)
+	return binary.BigEndian.AppendUint32(dst, uint32(id)) // nolint:gosec
+}
+
+// ParseHeader parses the Confluent wire format header and returns the schema id
+// and the payload following the header.
+func ParseHeader(data []byte) (int, []byte, error) {
+	if len(data) < headerSize || data[0] != magicByte {
+		return 0, nil, errors.WithMessage(ErrMalformedMessage, "invalid wire format header")
+	}
+	id := binary.BigEndian.Uint32(data[1:headerSize])
+	return int(id), data[headerSize:], nil
+}
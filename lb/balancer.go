@@ -0,0 +1,233 @@
+package lb
+
+import (
+	"sync"
+	"time"
+)
+
+// Balancer distributes requests across a dynamic list of hosts.
+// Implementations are safe for concurrent use by multiple goroutines.
+type Balancer interface {
+	// Upgrade replaces the list of hosts to balance.
+	// State of hosts present in both lists (outstanding requests, ejection) is preserved.
+	Upgrade(hosts []string)
+	// Size returns the current number of hosts.
+	Size() int
+	// Next selects a host without tracking the request outcome.
+	// Returns ErrNoHostsToBalance if the list is empty.
+	Next() (string, error)
+	// Acquire selects a host for the request identified by key and returns a DoneFunc
+	// which must be called exactly once when the request completes.
+	// The key is used only by key-aware strategies such as ConsistentHash.
+	// Returns ErrNoHostsToBalance if the list is empty.
+	Acquire(key string) (string, DoneFunc, error)
+}
+
+// DoneFunc reports the outcome of a request to the host obtained from Balancer.Acquire.
+// A non-nil err counts as a failure for outlier ejection.
+type DoneFunc func(err error)
+
+// Option configures a balancer.
+type Option func(o *options)
+
+// options holds settings common to all balancers.
+type options struct {
+	ejection *ejectionConfig
+}
+
+// ejectionConfig holds passive outlier ejection settings.
+type ejectionConfig struct {
+	consecutiveFailures int
+	baseEjectionTime    time.Duration
+	maxEjectionTime     time.Duration
+}
+
+// WithOutlierEjection enables passive outlier ejection.
+// A host that fails consecutiveFailures requests in a row is excluded from balancing
+// for baseEjectionTime, doubled on each subsequent ejection up to maxEjectionTime.
+// A successful request resets the backoff. If every host is ejected, all hosts are used.
+func WithOutlierEjection(consecutiveFailures int, baseEjectionTime time.Duration, maxEjectionTime time.Duration) Option {
+	return func(o *options) {
+		o.ejection = &ejectionConfig{
+			consecutiveFailures: max(consecutiveFailures, 1),
+			baseEjectionTime:    baseEjectionTime,
+			maxEjectionTime:     max(maxEjectionTime, baseEjectionTime),
+		}
+	}
+}
+
+// hostState holds per-host balancing state.
+type hostState struct {
+	addr          string
+	weight        int
+	currentWeight int
+	outstanding   int
+	failures      int
+	ejections     int
+	ejectedUntil  time.Time
+}
+
+// picker implements a balancing strategy over the list of available hosts.
+// All methods are called with the balancer lock held.
+type picker interface {
+	upgrade(hosts []*hostState)
+	pick(available []*hostState, key string) *hostState
+}
+
+// balancer implements host bookkeeping and outlier ejection shared by all strategies.
+type balancer struct {
+	locker   sync.Mutex
+	hosts    []*hostState
+	picker   picker
+	weights  map[string]int
+	ejection *ejectionConfig
+	now      func() time.Time
+}
+
+// newBalancer creates a balancer with the provided strategy.
+func newBalancer(hosts []string, picker picker, opts []Option) *balancer {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	b := &balancer{
+		picker:   picker,
+		ejection: o.ejection,
+		now:      time.Now,
+	}
+	b.Upgrade(hosts)
+	return b
+}
+
+// Upgrade replaces the list of hosts to balance.
+// This method is safe for concurrent use.
+func (b *balancer) Upgrade(hosts []string) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	b.upgradeLocked(hosts)
+}
+
+// Size returns the current number of hosts.
+// This method is safe for concurrent use.
+func (b *balancer) Size() int {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	return len(b.hosts)
+}
+
+// Next selects a host without tracking the request outcome.
+// This method is safe for concurrent use.
+func (b *balancer) Next() (string, error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	host := b.pickLocked("")
+	if host == nil {
+		return "", ErrNoHostsToBalance
+	}
+	return host.addr, nil
+}
+
+// Acquire selects a host and tracks the request until DoneFunc is called.
+// This method is safe for concurrent use.
+func (b *balancer) Acquire(key string) (string, DoneFunc, error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	host := b.pickLocked(key)
+	if host == nil {
+		return "", nil, ErrNoHostsToBalance
+	}
+	host.outstanding++
+
+	once := sync.Once{}
+	done := func(err error) {
+		once.Do(func() {
+			b.release(host, err)
+		})
+	}
+	return host.addr, done, nil
+}
+
+// upgradeLocked rebuilds host states preserving state of known hosts.
+func (b *balancer) upgradeLocked(hosts []string) {
+	known := make(map[string]*hostState, len(b.hosts))
+	for _, host := range b.hosts {
+		known[host.addr] = host
+	}
+
+	states := make([]*hostState, 0, len(hosts))
+	for _, addr := range hosts {
+		state, ok := known[addr]
+		if !ok {
+			state = &hostState{addr: addr}
+		}
+		state.weight = b.weightOf(addr)
+		states = append(states, state)
+	}
+	b.hosts = states
+	b.picker.upgrade(states)
+}
+
+// weightOf returns the configured weight of the host, 1 by default.
+func (b *balancer) weightOf(addr string) int {
+	weight, ok := b.weights[addr]
+	if !ok || weight <= 0 {
+		return 1
+	}
+	return weight
+}
+
+// pickLocked selects a host among not ejected hosts.
+// Returns nil if there are no hosts.
+func (b *balancer) pickLocked(key string) *hostState {
+	if len(b.hosts) == 0 {
+		return nil
+	}
+	if b.ejection == nil {
+		return b.picker.pick(b.hosts, key)
+	}
+
+	now := b.now()
+	available := make([]*hostState, 0, len(b.hosts))
+	for _, host := range b.hosts {
+		if !now.Before(host.ejectedUntil) {
+			available = append(available, host)
+		}
+	}
+	if len(available) == 0 {
+		available = b.hosts
+	}
+	return b.picker.pick(available, key)
+}
+
+// release completes the request to the host and applies outlier ejection.
+func (b *balancer) release(host *hostState, err error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	host.outstanding--
+	if b.ejection == nil {
+		return
+	}
+	if err == nil {
+		host.failures = 0
+		host.ejections = 0
+		return
+	}
+
+	host.failures++
+	if host.failures < b.ejection.consecutiveFailures {
+		return
+	}
+	host.failures = 0
+	host.ejections++
+	ejectionTime := b.ejection.baseEjectionTime
+	for i := 1; i < host.ejections && ejectionTime < b.ejection.maxEjectionTime; i++ {
+		ejectionTime *= 2
+	}
+	ejectionTime = min(ejectionTime, b.ejection.maxEjectionTime)
+	host.ejectedUntil = b.now().Add(ejectionTime)
+}
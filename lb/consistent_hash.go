@@ -0,0 +1,89 @@
+package lb
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+const (
+	// DefaultVirtualNodes is the default number of points per host on the hash ring.
+	DefaultVirtualNodes = 160
+)
+
+// ConsistentHash routes requests with the same key to the same host.
+// When hosts are added or removed only a small share of keys is remapped.
+// Ejected hosts are skipped by walking the ring to the next available host.
+// Requests without a key are sent to a random host.
+// The ConsistentHash balancer is safe for concurrent use by multiple goroutines.
+type ConsistentHash struct {
+	*balancer
+}
+
+// NewConsistentHash creates a new ConsistentHash balancer with the provided list of hosts
+// using DefaultVirtualNodes points per host.
+func NewConsistentHash(hosts []string, opts ...Option) *ConsistentHash {
+	return &ConsistentHash{
+		balancer: newBalancer(hosts, &consistentHashPicker{virtualNodes: DefaultVirtualNodes}, opts),
+	}
+}
+
+// ringNode is a point on the hash ring.
+type ringNode struct {
+	hash uint64
+	host *hostState
+}
+
+// consistentHashPicker implements a hash ring with virtual nodes.
+type consistentHashPicker struct {
+	virtualNodes int
+	ring         []ringNode
+}
+
+// upgrade rebuilds the hash ring.
+func (p *consistentHashPicker) upgrade(hosts []*hostState) {
+	ring := make([]ringNode, 0, len(hosts)*p.virtualNodes)
+	for _, host := range hosts {
+		for i := range p.virtualNodes {
+			ring = append(ring, ringNode{
+				hash: hashKey(host.addr + "#" + strconv.Itoa(i)),
+				host: host,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	p.ring = ring
+}
+
+// pick returns the first available host clockwise from the key hash.
+func (p *consistentHashPicker) pick(available []*hostState, key string) *hostState {
+	if key == "" {
+		return available[rand.Intn(len(available))]
+	}
+
+	isAvailable := make(map[*hostState]bool, len(available))
+	for _, host := range available {
+		isAvailable[host] = true
+	}
+	hash := hashKey(key)
+	start := sort.Search(len(p.ring), func(i int) bool {
+		return p.ring[i].hash >= hash
+	})
+	for i := range p.ring {
+		node := p.ring[(start+i)%len(p.ring)]
+		if isAvailable[node.host] {
+			return node.host
+		}
+	}
+	return available[0]
+}
+
+// hashKey returns the 64-bit FNV-1a hash of the key.
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
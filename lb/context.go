@@ -0,0 +1,21 @@
+package lb
+
+import (
+	"context"
+)
+
+// keyContextKey is the context key for the balancing key.
+type keyContextKey struct{}
+
+// KeyToContext returns a copy of ctx carrying the balancing key.
+// Clients pass this key to Balancer.Acquire, so key-aware strategies such as
+// ConsistentHash route requests with the same key to the same host.
+func KeyToContext(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyContextKey{}, key)
+}
+
+// KeyFromContext returns the balancing key stored in ctx or an empty string.
+func KeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(keyContextKey{}).(string)
+	return key
+}
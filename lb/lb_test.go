@@ -0,0 +1,199 @@
+package lb_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/lb"
+)
+
+func TestRoundRobin(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewRoundRobin([]string{"a", "b", "c"})
+	counts := make(map[string]int)
+	for range 30 {
+		host, err := balancer.Next()
+		require.NoError(err)
+		counts[host]++
+	}
+	require.Equal(map[string]int{"a": 10, "b": 10, "c": 10}, counts)
+
+	balancer.Upgrade(nil)
+	_, err := balancer.Next()
+	require.ErrorIs(err, lb.ErrNoHostsToBalance)
+	_, _, err = balancer.Acquire("")
+	require.ErrorIs(err, lb.ErrNoHostsToBalance)
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewWeightedRoundRobin(map[string]int{"a": 5, "b": 1, "c": 1})
+	require.Equal(3, balancer.Size())
+	sequence := ""
+	counts := make(map[string]int)
+	for range 70 {
+		host, err := balancer.Next()
+		require.NoError(err)
+		counts[host]++
+		sequence += host
+	}
+	require.Equal(map[string]int{"a": 50, "b": 10, "c": 10}, counts)
+	require.NotContains(sequence, "aaaaaa")
+
+	balancer.UpgradeWeights(map[string]int{"a": 1, "b": 1})
+	counts = make(map[string]int)
+	for range 10 {
+		host, err := balancer.Next()
+		require.NoError(err)
+		counts[host]++
+	}
+	require.Equal(map[string]int{"a": 5, "b": 5}, counts)
+}
+
+func TestLeastOutstanding(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewLeastOutstanding([]string{"a", "b", "c"})
+	slowHost, slowDone, err := balancer.Acquire("")
+	require.NoError(err)
+	for range 30 {
+		host, done, err := balancer.Acquire("")
+		require.NoError(err)
+		require.NotEqual(slowHost, host)
+		done(nil)
+	}
+	slowDone(nil)
+}
+
+func TestPowerOfTwoChoices(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewPowerOfTwoChoices([]string{"a", "b"})
+	slowHost, slowDone, err := balancer.Acquire("")
+	require.NoError(err)
+	for range 30 {
+		host, done, err := balancer.Acquire("")
+		require.NoError(err)
+		require.NotEqual(slowHost, host)
+		done(nil)
+	}
+	slowDone(nil)
+}
+
+func TestConsistentHash(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	hosts := []string{"a", "b", "c", "d"}
+	balancer := lb.NewConsistentHash(hosts)
+	assigned := make(map[string]string)
+	for i := range 1000 {
+		key := strconv.Itoa(i)
+		host, done, err := balancer.Acquire(key)
+		require.NoError(err)
+		done(nil)
+		assigned[key] = host
+
+		again, done, err := balancer.Acquire(key)
+		require.NoError(err)
+		done(nil)
+		require.Equal(host, again)
+	}
+
+	balancer.Upgrade([]string{"a", "b", "c"})
+	moved := 0
+	for key, prevHost := range assigned {
+		host, done, err := balancer.Acquire(key)
+		require.NoError(err)
+		done(nil)
+		if prevHost != "d" {
+			require.Equal(prevHost, host)
+		} else {
+			moved++
+		}
+	}
+	require.Positive(moved)
+}
+
+func TestOutlierEjection(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewRoundRobin(
+		[]string{"a", "b"},
+		lb.WithOutlierEjection(2, 50*time.Millisecond, time.Second),
+	)
+	failures := 0
+	for failures < 2 {
+		host, done, err := balancer.Acquire("")
+		require.NoError(err)
+		if host == "a" {
+			done(errors.New("failed"))
+			failures++
+		} else {
+			done(nil)
+		}
+	}
+
+	for range 10 {
+		host, err := balancer.Next()
+		require.NoError(err)
+		require.Equal("b", host)
+	}
+
+	require.Eventually(func() bool {
+		host, err := balancer.Next()
+		require.NoError(err)
+		return host == "a"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestOutlierEjectionAllHosts(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancer := lb.NewLeastOutstanding([]string{"a"}, lb.WithOutlierEjection(1, time.Minute, time.Minute))
+	host, done, err := balancer.Acquire("")
+	require.NoError(err)
+	done(errors.New("failed"))
+
+	again, err := balancer.Next()
+	require.NoError(err)
+	require.Equal(host, again)
+}
+
+func TestConcurrentAcquire(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	balancers := []lb.Balancer{
+		lb.NewRoundRobin([]string{"a", "b"}),
+		lb.NewWeightedRoundRobin(map[string]int{"a": 2, "b": 1}),
+		lb.NewLeastOutstanding([]string{"a", "b"}),
+		lb.NewPowerOfTwoChoices([]string{"a", "b"}),
+		lb.NewConsistentHash([]string{"a", "b"}),
+	}
+	for _, balancer := range balancers {
+		wg := sync.WaitGroup{}
+		for i := range 50 {
+			wg.Go(func() {
+				_, done, err := balancer.Acquire(strconv.Itoa(i))
+				require.NoError(err)
+				done(nil)
+				balancer.Upgrade([]string{"a", "b", "c"})
+			})
+		}
+		wg.Wait()
+		require.Equal(3, balancer.Size())
+	}
+}
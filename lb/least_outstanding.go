@@ -0,0 +1,39 @@
+package lb
+
+// LeastOutstanding sends each request to the host with the fewest requests in flight.
+// Requests are tracked between Balancer.Acquire and the returned DoneFunc, so Next
+// alone does not affect the distribution.
+// The LeastOutstanding balancer is safe for concurrent use by multiple goroutines.
+type LeastOutstanding struct {
+	*balancer
+}
+
+// NewLeastOutstanding creates a new LeastOutstanding balancer with the provided list of hosts.
+func NewLeastOutstanding(hosts []string, opts ...Option) *LeastOutstanding {
+	return &LeastOutstanding{
+		balancer: newBalancer(hosts, &leastOutstandingPicker{}, opts),
+	}
+}
+
+// leastOutstandingPicker selects the least loaded host, rotating between equally loaded ones.
+type leastOutstandingPicker struct {
+	offset int
+}
+
+// upgrade resets the rotation offset.
+func (p *leastOutstandingPicker) upgrade(_ []*hostState) {
+	p.offset = 0
+}
+
+// pick returns the host with the minimum number of outstanding requests.
+func (p *leastOutstandingPicker) pick(available []*hostState, _ string) *hostState {
+	var best *hostState
+	for i := range available {
+		host := available[(p.offset+i)%len(available)]
+		if best == nil || host.outstanding < best.outstanding {
+			best = host
+		}
+	}
+	p.offset = (p.offset + 1) % len(available)
+	return best
+}
@@ -0,0 +1,42 @@
+package lb
+
+import (
+	"math/rand"
+)
+
+// PowerOfTwoChoices picks two random hosts and sends the request to the one
+// with fewer requests in flight. It approximates LeastOutstanding at lower cost
+// and avoids herding on a single host when many clients balance independently.
+// The PowerOfTwoChoices balancer is safe for concurrent use by multiple goroutines.
+type PowerOfTwoChoices struct {
+	*balancer
+}
+
+// NewPowerOfTwoChoices creates a new PowerOfTwoChoices balancer with the provided list of hosts.
+func NewPowerOfTwoChoices(hosts []string, opts ...Option) *PowerOfTwoChoices {
+	return &PowerOfTwoChoices{
+		balancer: newBalancer(hosts, powerOfTwoChoicesPicker{}, opts),
+	}
+}
+
+// powerOfTwoChoicesPicker implements the power of two random choices strategy.
+type powerOfTwoChoicesPicker struct{}
+
+// upgrade is a no-op, the strategy is stateless.
+func (p powerOfTwoChoicesPicker) upgrade(_ []*hostState) {}
+
+// pick returns the less loaded of two randomly chosen hosts.
+func (p powerOfTwoChoicesPicker) pick(available []*hostState, _ string) *hostState {
+	if len(available) == 1 {
+		return available[0]
+	}
+	i := rand.Intn(len(available))
+	j := rand.Intn(len(available) - 1)
+	if j >= i {
+		j++
+	}
+	if available[j].outstanding < available[i].outstanding {
+		return available[j]
+	}
+	return available[i]
+}
@@ -1,20 +1,30 @@
-// Package lb provides load balancing functionality with a Round Robin algorithm.
+// Package lb provides load balancing strategies.
 //
-// The package offers a thread-safe RoundRobin balancer that distributes requests
-// across a list of hosts in a cyclic manner. It supports dynamic host list updates
-// and is safe for concurrent use by multiple goroutines.
+// The package offers thread-safe balancers that distribute requests across a list
+// of hosts: RoundRobin, WeightedRoundRobin, LeastOutstanding, PowerOfTwoChoices and
+// ConsistentHash. All of them implement the Balancer interface, support dynamic host
+// list updates and optional passive outlier ejection (see WithOutlierEjection).
 //
 // Basic usage:
 //
 //	hostList := []string{"localhost:8080", "localhost:8081"}
 //	balancer := lb.NewRoundRobin(hostList)
 //	host, err := balancer.Next()
+//
+// Usage with request outcome tracking:
+//
+//	balancer := lb.NewLeastOutstanding(hostList, lb.WithOutlierEjection(5, time.Second, time.Minute))
+//	host, done, err := balancer.Acquire("")
+//	if err != nil {
+//		return err
+//	}
+//	err = call(host)
+//	done(err)
 package lb
 
 import (
 	"errors"
 	"math/rand"
-	"sync"
 )
 
 var (
@@ -26,65 +36,37 @@ var (
 // It distributes requests across a list of hosts in a cyclic order.
 // The RoundRobin balancer is safe for concurrent use by multiple goroutines.
 type RoundRobin struct {
-	hosts   []string
-	current int
-	locker  sync.Locker
+	*balancer
 }
 
 // NewRoundRobin creates a new RoundRobin balancer with the provided list of hosts.
 // The initial position is set to a random index if hosts are provided.
 // It returns a pointer to the newly created RoundRobin instance.
-func NewRoundRobin(hosts []string) *RoundRobin {
-	current := 0
-	if len(hosts) > 0 {
-		current = rand.Intn(len(hosts))
-	}
+func NewRoundRobin(hosts []string, opts ...Option) *RoundRobin {
 	return &RoundRobin{
-		hosts:   hosts,
-		current: current,
-		locker:  &sync.Mutex{},
+		balancer: newBalancer(hosts, &roundRobinPicker{}, opts),
 	}
 }
 
-// Upgrade updates the list of hosts to balance.
-// It resets the current position to a random index if hosts are provided.
-// This method is safe for concurrent use.
-func (b *RoundRobin) Upgrade(hosts []string) {
-	b.locker.Lock()
-	defer b.locker.Unlock()
+// roundRobinPicker cycles through available hosts.
+type roundRobinPicker struct {
+	current int
+}
 
-	b.hosts = hosts
-	current := 0
+// upgrade resets the current position to a random index.
+func (p *roundRobinPicker) upgrade(hosts []*hostState) {
+	p.current = 0
 	if len(hosts) > 0 {
-		current = rand.Intn(len(hosts))
+		p.current = rand.Intn(len(hosts))
 	}
-	b.current = current
 }
 
-// Size returns the current number of hosts in the balancer.
-// This method is safe for concurrent use.
-func (b *RoundRobin) Size() int {
-	b.locker.Lock()
-	defer b.locker.Unlock()
-
-	return len(b.hosts)
-}
-
-// Next returns the next host from the list using the Round Robin algorithm.
-// It cycles through the hosts in order, returning ErrNoHostsToBalance if the list is empty.
-// This method is safe for concurrent use.
-func (b *RoundRobin) Next() (string, error) {
-	b.locker.Lock()
-	defer b.locker.Unlock()
-
-	if len(b.hosts) == 0 {
-		return "", ErrNoHostsToBalance
-	}
-	if len(b.hosts) == 1 {
-		return b.hosts[0], nil
+// pick returns the next host in cyclic order.
+func (p *roundRobinPicker) pick(available []*hostState, _ string) *hostState {
+	if len(available) == 1 {
+		return available[0]
 	}
-	host := b.hosts[b.current]
-	b.current = (b.current + 1) % len(b.hosts)
-
-	return host, nil
+	host := available[p.current%len(available)]
+	p.current = (p.current + 1) % len(available)
+	return host
 }
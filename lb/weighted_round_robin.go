@@ -0,0 +1,72 @@
+package lb
+
+import (
+	"sort"
+)
+
+// WeightedRoundRobin implements the smooth weighted Round Robin algorithm.
+// Each host receives a share of requests proportional to its weight while
+// requests to the same host are spread evenly over time.
+// The WeightedRoundRobin balancer is safe for concurrent use by multiple goroutines.
+type WeightedRoundRobin struct {
+	*balancer
+}
+
+// NewWeightedRoundRobin creates a new WeightedRoundRobin balancer.
+// The weights map holds host addresses and their positive weights.
+// Hosts added later through Upgrade without a known weight get weight 1.
+func NewWeightedRoundRobin(weights map[string]int, opts ...Option) *WeightedRoundRobin {
+	b := newBalancer(nil, &weightedRoundRobinPicker{}, opts)
+	b.weights = weights
+	b.Upgrade(sortedHosts(weights))
+	return &WeightedRoundRobin{
+		balancer: b,
+	}
+}
+
+// UpgradeWeights replaces the list of hosts and their weights.
+// This method is safe for concurrent use.
+func (b *WeightedRoundRobin) UpgradeWeights(weights map[string]int) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	b.weights = weights
+	b.upgradeLocked(sortedHosts(weights))
+}
+
+// weightedRoundRobinPicker implements smooth weighted round robin.
+type weightedRoundRobinPicker struct{}
+
+// upgrade resets accumulated weights.
+func (p *weightedRoundRobinPicker) upgrade(hosts []*hostState) {
+	for _, host := range hosts {
+		host.currentWeight = 0
+	}
+}
+
+// pick returns the host with the highest accumulated weight.
+func (p *weightedRoundRobinPicker) pick(available []*hostState, _ string) *hostState {
+	var (
+		best  *hostState
+		total int
+	)
+	for _, host := range available {
+		host.currentWeight += host.weight
+		total += host.weight
+		if best == nil || host.currentWeight > best.currentWeight {
+			best = host
+		}
+	}
+	best.currentWeight -= total
+	return best
+}
+
+// sortedHosts returns the keys of the weights map in a stable order.
+func sortedHosts(weights map[string]int) []string {
+	hosts := make([]string, 0, len(weights))
+	for host := range weights {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
@@ -21,6 +21,8 @@ type Config struct {
 	Hooks []func(entry zapcore.Entry) error
 	// InitialLevel sets the minimum log level.
 	InitialLevel Level
+	// Cores are additional cores receiving the entries enabled by the log level, e.g. OtlpCore.
+	Cores []zapcore.Core
 }
 
 // SamplingConfig is an alias for zap.SamplingConfig.
@@ -15,3 +15,8 @@ const (
 	InfoLevel  = zap.InfoLevel
 	DebugLevel = zap.DebugLevel
 )
+
+// ParseLevel parses the level name, e.g. "debug" or "INFO".
+func ParseLevel(text string) (Level, error) {
+	return zapcore.ParseLevel(text)
+}
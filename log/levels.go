@@ -0,0 +1,216 @@
+package log
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LevelHeader is the request header carrying the log level of a single request, e.g. "debug".
+	LevelHeader = "x-log-level"
+)
+
+type levelContextKey struct{}
+
+// nolint:gochecknoglobals
+var (
+	levelContextKeyValue = levelContextKey{}
+)
+
+// LoggerLevel describes the current level of a logger.
+type LoggerLevel struct {
+	// Name is the name of the logger, it is empty for the root logger.
+	Name string `json:"name"`
+	// Level is the effective level of the logger.
+	Level Level `json:"level"`
+	// Overridden reports whether the level was set for the logger itself
+	// rather than inherited from the parent or the root logger.
+	Overridden bool `json:"overridden"`
+}
+
+// LevelToContext stores the level overriding the level of loggers for the entries
+// written with the context. The override only lowers the level, e.g. DebugLevel
+// enables debug entries of a single request while the loggers stay at info.
+func LevelToContext(ctx context.Context, level Level) context.Context {
+	return context.WithValue(ctx, levelContextKeyValue, level)
+}
+
+// LevelFromContext extracts the level stored by LevelToContext.
+func LevelFromContext(ctx context.Context) (Level, bool) {
+	level, ok := ctx.Value(levelContextKeyValue).(Level)
+	return level, ok
+}
+
+// levelRegistry holds the levels of the root logger and the named loggers sharing it.
+// A named logger without its own level inherits the level of the closest parent
+// with a level, e.g. "kafka.consumer" inherits the level of "kafka", or the root level.
+type levelRegistry struct {
+	lock         sync.Mutex
+	initialLevel Level
+	root         zap.AtomicLevel
+	overrides    map[string]Level
+	levels       map[string]zap.AtomicLevel
+	reverts      map[string]*levelRevert
+}
+
+// levelRevert is the pending revert of a temporary level to the last level set without ttl.
+type levelRevert struct {
+	timer *time.Timer
+	level Level
+	isSet bool
+}
+
+func newLevelRegistry(initialLevel Level) *levelRegistry {
+	return &levelRegistry{
+		initialLevel: initialLevel,
+		root:         zap.NewAtomicLevelAt(initialLevel),
+		overrides:    make(map[string]Level),
+		levels:       make(map[string]zap.AtomicLevel),
+		reverts:      make(map[string]*levelRevert),
+	}
+}
+
+// named returns the level of the named logger, the level is updated in place on changes.
+func (r *levelRegistry) named(name string) zap.AtomicLevel {
+	if name == "" {
+		return r.root
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	level, ok := r.levels[name]
+	if !ok {
+		level = zap.NewAtomicLevelAt(r.effective(name))
+		r.levels[name] = level
+	}
+	return level
+}
+
+// set changes the level of the logger and reverts the change after ttl if it is positive.
+// Temporary levels set one after another are reverted to the level set without ttl,
+// e.g. debug for 10 minutes and then warn for 5 minutes revert to the initial level after 5 minutes.
+func (r *levelRegistry) set(name string, level Level, ttl time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	revert := r.stopRevert(name)
+	if revert == nil {
+		prevLevel, hasPrevLevel := r.overrides[name]
+		if name == "" {
+			prevLevel, hasPrevLevel = r.root.Level(), true
+		}
+		revert = &levelRevert{level: prevLevel, isSet: hasPrevLevel}
+	}
+
+	r.apply(name, level, true)
+
+	if ttl <= 0 {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		if r.reverts[name] != revert || revert.timer != timer {
+			return
+		}
+		delete(r.reverts, name)
+		r.apply(name, revert.level, revert.isSet)
+	})
+	revert.timer = timer
+	r.reverts[name] = revert
+}
+
+// reset removes the level of the named logger or restores the initial level of the root logger.
+func (r *levelRegistry) reset(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.stopRevert(name)
+	r.apply(name, r.initialLevel, name == "")
+}
+
+// list returns the levels of the root logger and the known named loggers sorted by name.
+func (r *levelRegistry) list() []LoggerLevel {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make([]string, 0, len(r.levels)+len(r.overrides))
+	for name := range r.levels {
+		names = append(names, name)
+	}
+	for name := range r.overrides {
+		_, ok := r.levels[name]
+		if !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	result := make([]LoggerLevel, 0, len(names)+1)
+	result = append(result, LoggerLevel{
+		Level:      r.root.Level(),
+		Overridden: true,
+	})
+	for _, name := range names {
+		_, overridden := r.overrides[name]
+		result = append(result, LoggerLevel{
+			Name:       name,
+			Level:      r.effective(name),
+			Overridden: overridden,
+		})
+	}
+	return result
+}
+
+// apply sets or removes the level of the logger and updates the levels of the named loggers.
+// The lock must be held.
+func (r *levelRegistry) apply(name string, level Level, isSet bool) {
+	switch {
+	case name == "":
+		r.root.SetLevel(level)
+	case isSet:
+		r.overrides[name] = level
+	default:
+		delete(r.overrides, name)
+	}
+
+	for loggerName, loggerLevel := range r.levels {
+		loggerLevel.SetLevel(r.effective(loggerName))
+	}
+}
+
+// effective returns the level of the named logger taking the parents into account.
+// The lock must be held.
+func (r *levelRegistry) effective(name string) Level {
+	for name != "" {
+		level, ok := r.overrides[name]
+		if ok {
+			return level
+		}
+		index := strings.LastIndex(name, ".")
+		if index < 0 {
+			break
+		}
+		name = name[:index]
+	}
+	return r.root.Level()
+}
+
+// stopRevert cancels the pending revert of the logger level and returns it, if any. The lock must be held.
+func (r *levelRegistry) stopRevert(name string) *levelRevert {
+	revert, ok := r.reverts[name]
+	if !ok {
+		return nil
+	}
+	revert.timer.Stop()
+	delete(r.reverts, name)
+	return revert
+}
@@ -0,0 +1,92 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/log"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNamedLevels(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	core, logs := observer.New(log.DebugLevel)
+	logger, err := log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithCores(core),
+	)
+	require.NoError(err)
+	kafkaLogger := logger.Named("kafka")
+	require.Equal("kafka", kafkaLogger.Name())
+	require.Equal("kafka.consumer", kafkaLogger.Named("consumer").Name())
+
+	ctx := context.Background()
+	kafkaLogger.SetLevel(log.DebugLevel)
+	kafkaLogger.Debug(ctx, "kafka debug")
+	logger.Debug(ctx, "root debug")
+
+	logger.SetLevel(log.ErrorLevel)
+	kafkaLogger.Debug(ctx, "kafka debug after root change")
+	logger.Info(ctx, "root info")
+
+	logger.ResetNamedLevel("kafka")
+	kafkaLogger.Debug(ctx, "kafka debug after reset")
+
+	entries := logs.All()
+	require.Len(entries, 2)
+	require.Equal("kafka debug", entries[0].Message)
+	require.Equal("kafka", entries[0].LoggerName)
+	require.Equal("kafka debug after root change", entries[1].Message)
+}
+
+func TestTemporaryLevel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	logger, err := log.New(log.WithDisableDefaultOutput())
+	require.NoError(err)
+	kafkaLogger := logger.Named("kafka")
+
+	logger.SetNamedLevel("kafka", log.DebugLevel, 200*time.Millisecond)
+	logger.SetNamedLevel("kafka", log.WarnLevel, 50*time.Millisecond)
+	require.False(kafkaLogger.Enabled(log.InfoLevel))
+	require.Eventually(func() bool {
+		return kafkaLogger.Enabled(log.InfoLevel) && !kafkaLogger.Enabled(log.DebugLevel)
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	require.False(kafkaLogger.Enabled(log.DebugLevel))
+	require.Equal(log.LoggerLevel{Name: "kafka", Level: log.InfoLevel}, logger.Levels()[1])
+
+	logger.SetNamedLevel("kafka", log.ErrorLevel, 0)
+	logger.SetNamedLevel("kafka", log.DebugLevel, 50*time.Millisecond)
+	require.True(kafkaLogger.Enabled(log.DebugLevel))
+	require.Eventually(func() bool {
+		return !kafkaLogger.Enabled(log.WarnLevel) && kafkaLogger.Enabled(log.ErrorLevel)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestContextLevel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	core, logs := observer.New(log.DebugLevel)
+	logger, err := log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithCores(core),
+	)
+	require.NoError(err)
+
+	logger.Debug(context.Background(), "skipped")
+	ctx := log.LevelToContext(context.Background(), log.DebugLevel)
+	logger.Debug(ctx, "request debug")
+	logger.Named("kafka").Debug(ctx, "named request debug")
+
+	entries := logs.All()
+	require.Len(entries, 2)
+	require.Equal("request debug", entries[0].Message)
+	require.Equal("named request debug", entries[1].Message)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -11,10 +12,13 @@ import (
 )
 
 // Adapter is the main logging implementation built on Zap.
+// Named adapters created by Named share the outputs and the levels registry of the root adapter.
 type Adapter struct {
 	cfg    Config
 	logger *zap.Logger
+	name   string
 	level  zap.AtomicLevel
+	levels *levelRegistry
 }
 
 // New creates a new Adapter with the provided options.
@@ -45,13 +49,19 @@ func NewFromConfig(config Config) (*Adapter, error) {
 		cfg.OutputPaths = append(cfg.OutputPaths, config.OutputPaths...)
 	}
 
-	level := zap.NewAtomicLevelAt(config.InitialLevel)
-	cfg.Level = level
+	// the level is checked by the Adapter, which also takes the context level into account
+	cfg.Level = zap.NewAtomicLevelAt(DebugLevel)
+	levels := newLevelRegistry(config.InitialLevel)
 
 	var opts []zap.Option
 	if len(config.Hooks) > 0 {
 		opts = append(opts, zap.Hooks(config.Hooks...))
 	}
+	if len(config.Cores) > 0 {
+		opts = append(opts, zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(append([]zapcore.Core{core}, config.Cores...)...)
+		}))
+	}
 
 	logger, err := cfg.Build(opts...)
 	if err != nil {
@@ -61,10 +71,36 @@ func NewFromConfig(config Config) (*Adapter, error) {
 	return &Adapter{
 		cfg:    config,
 		logger: logger,
-		level:  level,
+		level:  levels.root,
+		levels: levels,
 	}, nil
 }
 
+// Named creates a child adapter with the name appended to the name of the adapter
+// with a dot separator, e.g. "kafka.consumer". The child inherits the level of the parent
+// until its own level is set by SetLevel or SetNamedLevel.
+func (a *Adapter) Named(name string) *Adapter {
+	if name == "" {
+		return a
+	}
+	fullName := name
+	if a.name != "" {
+		fullName = a.name + "." + name
+	}
+	return &Adapter{
+		cfg:    a.cfg,
+		logger: a.logger.Named(name),
+		name:   fullName,
+		level:  a.levels.named(fullName),
+		levels: a.levels,
+	}
+}
+
+// Name returns the full name of the adapter, it is empty for the root adapter.
+func (a *Adapter) Name() string {
+	return a.name
+}
+
 // Fatal logs a fatal-level message.
 func (a *Adapter) Fatal(ctx context.Context, message any, fields ...Field) {
 	a.Log(ctx, FatalLevel, message, fields...)
@@ -91,17 +127,49 @@ func (a *Adapter) Debug(ctx context.Context, message any, fields ...Field) {
 }
 
 // Log writes a log entry at the specified level.
+// The entry is written if the level is enabled for the adapter or by the level from LevelToContext.
+// The fields stored in the context and the IDs of the active span are added to the entry.
 func (a *Adapter) Log(ctx context.Context, level Level, message any, fields ...Field) {
+	if !a.level.Enabled(level) {
+		ctxLevel, ok := LevelFromContext(ctx)
+		if !ok || !ctxLevel.Enabled(level) {
+			return
+		}
+	}
+
 	entry := a.logger.Check(level, castString(message))
 	if entry != nil {
 		fields = append(fields, ContextLogValues(ctx)...)
+		fields = append(fields, TraceLogValues(ctx)...)
+		if len(a.cfg.Cores) > 0 {
+			fields = append(fields, contextField(ctx))
+		}
 		entry.Write(fields...)
 	}
 }
 
-// SetLevel changes the current log level.
+// SetLevel changes the log level of the adapter.
+// The level of the root adapter is inherited by the named adapters without their own level.
 func (a *Adapter) SetLevel(level Level) {
-	a.level.SetLevel(level)
+	a.levels.set(a.name, level, 0)
+}
+
+// SetNamedLevel changes the log level of the adapter with the name, the empty name means the root adapter.
+// The level may be set before the named adapter is created. If ttl is positive,
+// the previous level is restored after ttl.
+func (a *Adapter) SetNamedLevel(name string, level Level, ttl time.Duration) {
+	a.levels.set(name, level, ttl)
+}
+
+// ResetNamedLevel removes the level of the adapter with the name, so it inherits the level of the parent.
+// For the root adapter, the empty name, the initial level is restored.
+func (a *Adapter) ResetNamedLevel(name string) {
+	a.levels.reset(name)
+}
+
+// Levels returns the levels of the root adapter and all named adapters.
+func (a *Adapter) Levels() []LoggerLevel {
+	return a.levels.list()
 }
 
 // Enabled checks if the specified level is enabled.
@@ -125,7 +193,7 @@ func StdLoggerWithLevel(adapter Logger, level Level, withFields ...Field) *log.L
 	if !ok {
 		panic(fmt.Errorf("adapter must be a [%T], got [%T]", &Adapter{}, adapter)) // nolint:err113
 	}
-	logger := kitAdapter.logger.With(withFields...)
+	logger := kitAdapter.logger.WithOptions(zap.IncreaseLevel(kitAdapter.level)).With(withFields...)
 	stdLogger, err := zap.NewStdLogAt(logger, level)
 	if err != nil {
 		panic(err)
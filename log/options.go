@@ -2,6 +2,7 @@ package log
 
 import (
 	"github.com/txix-open/isp-kit/log/file"
+	"go.uber.org/zap/zapcore"
 )
 
 // Option is a function that configures a Config.
@@ -28,6 +29,13 @@ func WithDisableDefaultOutput() Option {
 	}
 }
 
+// WithCores adds cores receiving the entries enabled by the log level along with the outputs.
+func WithCores(cores ...zapcore.Core) Option {
+	return func(a *Config) {
+		a.Cores = append(a.Cores, cores...)
+	}
+}
+
 // WithLevel sets the initial log level.
 func WithLevel(level Level) Option {
 	return func(a *Config) {
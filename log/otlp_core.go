@@ -0,0 +1,220 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	otlpLoggerName = "isp-kit/log"
+	// contextFieldKey is the key of the field carrying the context of the log call to the cores.
+	contextFieldKey = "_ctx"
+)
+
+// OtlpCore is a zap core emitting OTLP log records through the OpenTelemetry logger provider.
+// Records emitted through Adapter are correlated with the span active in the context of the call.
+type OtlpCore struct {
+	logger otellog.Logger
+	fields []zapcore.Field
+}
+
+// NewOtlpCore creates a core emitting records through the provider.
+// Use it with WithCores, the level of the Adapter applies to the core.
+func NewOtlpCore(provider otellog.LoggerProvider) OtlpCore {
+	return OtlpCore{
+		logger: provider.Logger(otlpLoggerName),
+	}
+}
+
+// Enabled reports that every level is enabled, the level is checked by the Adapter.
+func (c OtlpCore) Enabled(level zapcore.Level) bool {
+	return true
+}
+
+// With returns a copy of the core with the fields added to every record.
+// nolint:ireturn
+func (c OtlpCore) With(fields []zapcore.Field) zapcore.Core {
+	c.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	return c
+}
+
+// Check adds the core to the checked entry.
+func (c OtlpCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checked.AddCore(entry, c)
+}
+
+// Write emits the entry as a log record.
+// Fields of primitive types are converted directly, other fields are encoded with zapcore.MapObjectEncoder.
+func (c OtlpCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	ctx := context.Background()
+	attributes := make([]otellog.KeyValue, 0, len(c.fields)+len(fields)+1)
+	if entry.LoggerName != "" {
+		attributes = append(attributes, otellog.String("logger", entry.LoggerName))
+	}
+	var encoder *zapcore.MapObjectEncoder
+	for _, fields := range [2][]zapcore.Field{c.fields, fields} {
+		for _, field := range fields {
+			if field.Key == contextFieldKey && field.Type == zapcore.SkipType {
+				fieldCtx, ok := field.Interface.(context.Context)
+				if ok {
+					ctx = fieldCtx
+				}
+				continue
+			}
+			if field.Key == TraceIdKey || field.Key == SpanIdKey {
+				// the record carries the span context itself
+				continue
+			}
+			// fields following a namespace are nested into it by the encoder
+			if encoder == nil && field.Type != zapcore.NamespaceType {
+				attribute, ok := otlpAttribute(field)
+				if ok {
+					attributes = append(attributes, attribute)
+					continue
+				}
+			}
+			if encoder == nil {
+				encoder = zapcore.NewMapObjectEncoder()
+			}
+			field.AddTo(encoder)
+		}
+	}
+	if encoder != nil {
+		for key, value := range encoder.Fields {
+			attributes = append(attributes, otellog.KeyValue{Key: key, Value: otlpValue(value)})
+		}
+	}
+
+	record := otellog.Record{}
+	record.SetTimestamp(entry.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(otlpSeverity(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otellog.StringValue(entry.Message))
+	record.AddAttributes(attributes...)
+
+	c.logger.Emit(ctx, record)
+	return nil
+}
+
+// Sync does nothing, records are flushed by the logger provider.
+func (c OtlpCore) Sync() error {
+	return nil
+}
+
+// contextField returns the field carrying the context, it is skipped by the encoders.
+func contextField(ctx context.Context) Field {
+	return zap.Field{Key: contextFieldKey, Type: zapcore.SkipType, Interface: ctx}
+}
+
+// otlpSeverity converts the level to the OTLP severity.
+func otlpSeverity(level zapcore.Level) otellog.Severity {
+	switch level {
+	case zapcore.DebugLevel:
+		return otellog.SeverityDebug
+	case zapcore.InfoLevel:
+		return otellog.SeverityInfo
+	case zapcore.WarnLevel:
+		return otellog.SeverityWarn
+	case zapcore.ErrorLevel:
+		return otellog.SeverityError
+	case zapcore.DPanicLevel:
+		return otellog.SeverityFatal1
+	case zapcore.PanicLevel:
+		return otellog.SeverityFatal2
+	case zapcore.FatalLevel:
+		return otellog.SeverityFatal3
+	default:
+		return otellog.SeverityUndefined
+	}
+}
+
+// otlpAttribute converts the field of a primitive type to the attribute the same way as otlpValue
+// converts the value encoded by zapcore.MapObjectEncoder. It reports false for other fields.
+func otlpAttribute(field zapcore.Field) (otellog.KeyValue, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		return otellog.String(field.Key, field.String), true
+	case zapcore.BoolType:
+		return otellog.Bool(field.Key, field.Integer == 1), true
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+		zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return otellog.Int64(field.Key, field.Integer), true
+	case zapcore.Uint64Type, zapcore.UintptrType:
+		return otellog.String(field.Key, strconv.FormatUint(uint64(field.Integer), 10)), true // nolint:gosec
+	case zapcore.Float64Type:
+		return otellog.Float64(field.Key, math.Float64frombits(uint64(field.Integer))), true // nolint:gosec
+	case zapcore.Float32Type:
+		return otellog.Float64(field.Key, float64(math.Float32frombits(uint32(field.Integer)))), true // nolint:gosec
+	case zapcore.DurationType:
+		return otellog.String(field.Key, time.Duration(field.Integer).String()), true
+	default:
+		return otellog.KeyValue{}, false
+	}
+}
+
+// otlpValue converts a value produced by zapcore.MapObjectEncoder to the OTLP value.
+func otlpValue(value any) otellog.Value {
+	switch typed := value.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(typed)
+	case bool:
+		return otellog.BoolValue(typed)
+	case int:
+		return otellog.IntValue(typed)
+	case int8:
+		return otellog.Int64Value(int64(typed))
+	case int16:
+		return otellog.Int64Value(int64(typed))
+	case int32:
+		return otellog.Int64Value(int64(typed))
+	case int64:
+		return otellog.Int64Value(typed)
+	case uint8:
+		return otellog.Int64Value(int64(typed))
+	case uint16:
+		return otellog.Int64Value(int64(typed))
+	case uint32:
+		return otellog.Int64Value(int64(typed))
+	case uint, uint64, uintptr:
+		return otellog.StringValue(fmt.Sprintf("%d", typed))
+	case float32:
+		return otellog.Float64Value(float64(typed))
+	case float64:
+		return otellog.Float64Value(typed)
+	case []byte:
+		return otellog.BytesValue(typed)
+	case time.Time:
+		return otellog.StringValue(typed.Format(time.RFC3339Nano))
+	case time.Duration:
+		return otellog.StringValue(typed.String())
+	case []any:
+		values := make([]otellog.Value, 0, len(typed))
+		for _, item := range typed {
+			values = append(values, otlpValue(item))
+		}
+		return otellog.SliceValue(values...)
+	case map[string]any:
+		kvs := make([]otellog.KeyValue, 0, len(typed))
+		for key, item := range typed {
+			kvs = append(kvs, otellog.KeyValue{Key: key, Value: otlpValue(item)})
+		}
+		return otellog.MapValue(kvs...)
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return otellog.StringValue(fmt.Sprintf("%v", typed))
+		}
+		return otellog.StringValue(string(data))
+	}
+}
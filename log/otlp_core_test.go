@@ -0,0 +1,122 @@
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/log/file"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type exporter struct {
+	lock    sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+
+func (e *exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (e *exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+func TestOtlpCore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	exp := &exporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	logger, err := log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithCores(log.NewOtlpCore(provider)),
+	)
+	require.NoError(err)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "test")
+	ctx = log.ToContext(ctx, log.String("requestId", "abc"))
+	logger.Debug(ctx, "skipped")
+	logger.Error(ctx, "failed",
+		log.Int("attempt", 2),
+		log.Bool("retry", true),
+		log.Any("delay", time.Second),
+		log.Any("user", map[string]any{"id": 1}),
+	)
+	span.End()
+
+	require.Len(exp.records, 1)
+	record := exp.records[0]
+	require.Equal("failed", record.Body().AsString())
+	require.Equal(otellog.SeverityError, record.Severity())
+	require.Equal(span.SpanContext().TraceID(), record.TraceID())
+	require.Equal(span.SpanContext().SpanID(), record.SpanID())
+
+	attributes := make(map[string]otellog.Value)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attributes[kv.Key] = kv.Value
+		return true
+	})
+	require.Equal(map[string]otellog.Value{
+		"attempt":   otellog.Int64Value(2),
+		"retry":     otellog.BoolValue(true),
+		"delay":     otellog.StringValue("1s"),
+		"user":      otellog.MapValue(otellog.Int64("id", 1)),
+		"requestId": otellog.StringValue("abc"),
+	}, attributes)
+}
+
+func TestContextField(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	core, logs := observer.New(log.DebugLevel)
+	logger, err := log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithCores(core),
+	)
+	require.NoError(err)
+	logger.Info(context.Background(), "with cores")
+	entries := logs.All()
+	require.Len(entries, 1)
+	require.True(slices.ContainsFunc(entries[0].Context, func(field log.Field) bool {
+		return field.Key == "_ctx"
+	}))
+	require.NotContains(entries[0].ContextMap(), "_ctx")
+
+	path := filepath.Join(t.TempDir(), "log.json")
+	logger, err = log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithFileOutput(file.Output{File: path}),
+	)
+	require.NoError(err)
+	logger.Info(context.Background(), "without cores", log.String("key", "value"))
+	require.NoError(logger.Sync())
+
+	data, err := os.ReadFile(path)
+	require.NoError(err)
+	entry := make(map[string]any)
+	require.NoError(json.Unmarshal(data, &entry))
+	require.Equal("without cores", entry["msg"])
+	require.Equal("value", entry["key"])
+	require.NotContains(entry, "_ctx")
+}
@@ -0,0 +1,27 @@
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIdKey is the key of the field holding the trace ID of the active span.
+	TraceIdKey = "traceId"
+	// SpanIdKey is the key of the field holding the ID of the active span.
+	SpanIdKey = "spanId"
+)
+
+// TraceLogValues returns the trace ID and span ID fields of the span active in the context.
+// It returns nil if the context has no valid span, e.g. when tracing is disabled.
+func TraceLogValues(ctx context.Context) []Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []Field{
+		String(TraceIdKey, spanContext.TraceID().String()),
+		String(SpanIdKey, spanContext.SpanID().String()),
+	}
+}
@@ -0,0 +1,37 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTraceLogValues(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	core, logs := observer.New(log.DebugLevel)
+	logger, err := log.New(
+		log.WithDisableDefaultOutput(),
+		log.WithCores(core),
+	)
+	require.NoError(err)
+
+	require.Empty(log.TraceLogValues(context.Background()))
+	logger.Info(context.Background(), "without span")
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "test")
+	logger.Info(ctx, "with span")
+	span.End()
+
+	entries := logs.All()
+	require.Len(entries, 2)
+	require.NotContains(entries[0].ContextMap(), log.TraceIdKey)
+	require.NotContains(entries[0].ContextMap(), log.SpanIdKey)
+	require.Equal(span.SpanContext().TraceID().String(), entries[1].ContextMap()[log.TraceIdKey])
+	require.Equal(span.SpanContext().SpanID().String(), entries[1].ContextMap()[log.SpanIdKey])
+}
@@ -1,4 +1,5 @@
-// Package app_metrics provides application-level metrics including log sampling statistics.
+// Package app_metrics provides application-level metrics including log sampling statistics
+// and health check statuses of components.
 // It tracks the count of sampled and dropped log entries at different log levels.
 //
 // Example usage:
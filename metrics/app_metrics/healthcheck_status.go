@@ -0,0 +1,37 @@
+package app_metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+// HealthcheckStatus exposes the latest health check status of each registered component.
+type HealthcheckStatus struct {
+	status *prometheus.GaugeVec
+}
+
+// NewHealthcheckStatus creates a new HealthcheckStatus instance and registers its gauge
+// with the provided registry. The gauge is labeled by component name.
+func NewHealthcheckStatus(registry *metrics.Registry) HealthcheckStatus {
+	status := metrics.GetOrRegister(
+		registry,
+		prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "app",
+			Name:      "healthcheck_status",
+			Help:      "Health check status of the component: 1 - pass, 0.5 - warn, 0 - fail",
+		}, []string{"component"}),
+	)
+	return HealthcheckStatus{
+		status: status,
+	}
+}
+
+// Set stores the status value of the component.
+func (s HealthcheckStatus) Set(component string, value float64) {
+	s.status.WithLabelValues(component).Set(value)
+}
+
+// Delete removes the status of the component, e.g. when it is unregistered.
+func (s HealthcheckStatus) Delete(component string) {
+	s.status.DeleteLabelValues(component)
+}
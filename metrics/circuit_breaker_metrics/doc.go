@@ -0,0 +1,10 @@
+// Package circuit_breaker_metrics provides Prometheus metric collectors for circuit breakers.
+// It tracks the current state of each breaker, state transitions and rejected calls.
+//
+// Example usage:
+//
+//	storage := circuit_breaker_metrics.NewStorage(reg)
+//	storage.SetState(breaker, key, circuit_breaker_metrics.StateOpen)
+//	storage.IncTransition(breaker, key, "open")
+//	storage.IncRejected(breaker, key)
+package circuit_breaker_metrics
@@ -0,0 +1,61 @@
+package circuit_breaker_metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+const (
+	// StateClosed is the gauge value of a closed breaker.
+	StateClosed = 0
+	// StateHalfOpen is the gauge value of a half-open breaker.
+	StateHalfOpen = 1
+	// StateOpen is the gauge value of an open breaker.
+	StateOpen = 2
+)
+
+// Storage collects metrics for circuit breakers, including the current state,
+// state transitions and calls rejected by an open breaker.
+type Storage struct {
+	state       *prometheus.GaugeVec
+	transitions *prometheus.CounterVec
+	rejected    *prometheus.CounterVec
+}
+
+// NewStorage creates a new Storage instance and registers its metrics with the
+// provided registry. Metrics are labeled by breaker name and key (host or endpoint).
+func NewStorage(reg *metrics.Registry) *Storage {
+	s := &Storage{
+		state: metrics.GetOrRegister(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "circuit_breaker",
+			Name:      "state",
+			Help:      "Current state of the circuit breaker: 0 - closed, 1 - half-open, 2 - open",
+		}, []string{"breaker", "key"})),
+		transitions: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "circuit_breaker",
+			Name:      "transitions_count",
+			Help:      "Count of circuit breaker transitions to the state",
+		}, []string{"breaker", "key", "state"})),
+		rejected: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "circuit_breaker",
+			Name:      "rejected_count",
+			Help:      "Count of calls rejected by the circuit breaker",
+		}, []string{"breaker", "key"})),
+	}
+	return s
+}
+
+// SetState stores the current state value of the breaker.
+func (s *Storage) SetState(breaker string, key string, state float64) {
+	s.state.WithLabelValues(breaker, key).Set(state)
+}
+
+// IncTransition increments the counter of breaker transitions to the state.
+func (s *Storage) IncTransition(breaker string, key string, state string) {
+	s.transitions.WithLabelValues(breaker, key, state).Inc()
+}
+
+// IncRejected increments the counter of calls rejected by the breaker.
+func (s *Storage) IncRejected(breaker string, key string) {
+	s.rejected.WithLabelValues(breaker, key).Inc()
+}
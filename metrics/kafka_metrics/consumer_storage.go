@@ -1,6 +1,7 @@
 package kafka_metrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -8,12 +9,14 @@ import (
 )
 
 // ConsumerStorage collects metrics for Kafka consumer operations, including message
-// consume latency, message body sizes, commit counts, and retry counts.
+// consume latency, message body sizes, commit counts, retry counts, DLQ counts and partition lag.
 type ConsumerStorage struct {
 	consumeMsgDuration *prometheus.SummaryVec
 	consumeMsgBodySize *prometheus.SummaryVec
 	commitCount        *prometheus.CounterVec
 	retryCount         *prometheus.CounterVec
+	dlqCount           *prometheus.CounterVec
+	lag                *prometheus.GaugeVec
 }
 
 // NewConsumerStorage creates a new ConsumerStorage instance and registers its metrics
@@ -42,6 +45,16 @@ func NewConsumerStorage(reg *metrics.Registry) *ConsumerStorage {
 			Name:      "consume_retry_count",
 			Help:      "Count of retried messages",
 		}, []string{"consumerGroup", "topic"})),
+		dlqCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "kafka",
+			Name:      "consume_dlq_count",
+			Help:      "Count of messages moved to DLQ",
+		}, []string{"consumerGroup", "topic"})),
+		lag: metrics.GetOrRegister(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "kafka",
+			Name:      "consume_lag",
+			Help:      "The number of messages in partition not yet committed by consumer group",
+		}, []string{"consumerGroup", "topic", "partition"})),
 	}
 	return s
 }
@@ -65,3 +78,18 @@ func (c *ConsumerStorage) IncCommitCount(consumerGroup, topic string) {
 func (c *ConsumerStorage) IncRetryCount(consumerGroup, topic string) {
 	c.retryCount.WithLabelValues(consumerGroup, topic).Inc()
 }
+
+// IncDlqCount increments the counter for messages moved to the dead letter topic.
+func (c *ConsumerStorage) IncDlqCount(consumerGroup, topic string) {
+	c.dlqCount.WithLabelValues(consumerGroup, topic).Inc()
+}
+
+// SetLag sets the lag of a partition assigned to the consumer.
+func (c *ConsumerStorage) SetLag(consumerGroup string, topic string, partition int32, lag int64) {
+	c.lag.WithLabelValues(consumerGroup, topic, strconv.Itoa(int(partition))).Set(float64(lag))
+}
+
+// DeleteLag deletes the lag of a partition which is no longer assigned to the consumer.
+func (c *ConsumerStorage) DeleteLag(consumerGroup string, topic string, partition int32) {
+	c.lag.DeleteLabelValues(consumerGroup, topic, strconv.Itoa(int(partition)))
+}
@@ -0,0 +1,10 @@
+// Package outbox_metrics provides Prometheus metric collectors for the transactional outbox relay.
+// It tracks delivery latency, delivered and failed messages and the age of the oldest pending message.
+//
+// Example usage:
+//
+//	storage := outbox_metrics.NewStorage(reg)
+//	storage.ObservePublishDuration(destination, duration)
+//	storage.AddSentCount(destination, count)
+//	storage.SetOldestMessageAge(destination, age)
+package outbox_metrics
@@ -0,0 +1,66 @@
+package outbox_metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+// Storage collects metrics for the outbox relay, including delivery latency,
+// delivered and failed messages and the age of the oldest pending message.
+type Storage struct {
+	duration         *prometheus.SummaryVec
+	sentCount        *prometheus.CounterVec
+	errorCount       *prometheus.CounterVec
+	oldestMessageAge *prometheus.GaugeVec
+}
+
+// NewStorage creates a new Storage instance and registers its metrics with the
+// provided registry. Metrics are labeled by destination name.
+func NewStorage(reg *metrics.Registry) *Storage {
+	s := &Storage{
+		duration: metrics.GetOrRegister(reg, prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Subsystem:  "outbox",
+			Name:       "publish_duration_ms",
+			Help:       "The latency of publishing a batch of outbox messages",
+			Objectives: metrics.DefaultObjectives,
+		}, []string{"destination"})),
+		sentCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "outbox",
+			Name:      "sent_count",
+			Help:      "Count of delivered outbox messages",
+		}, []string{"destination"})),
+		errorCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "outbox",
+			Name:      "publish_error_count",
+			Help:      "Count of failed attempts to publish a batch of outbox messages",
+		}, []string{"destination"})),
+		oldestMessageAge: metrics.GetOrRegister(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: "outbox",
+			Name:      "oldest_message_age_ms",
+			Help:      "Age of the oldest pending outbox message",
+		}, []string{"destination"})),
+	}
+	return s
+}
+
+// ObservePublishDuration records the latency of publishing a batch of messages.
+func (s *Storage) ObservePublishDuration(destination string, duration time.Duration) {
+	s.duration.WithLabelValues(destination).Observe(metrics.Milliseconds(duration))
+}
+
+// AddSentCount increases the counter of delivered messages.
+func (s *Storage) AddSentCount(destination string, count int) {
+	s.sentCount.WithLabelValues(destination).Add(float64(count))
+}
+
+// IncErrorCount increments the counter of failed publish attempts.
+func (s *Storage) IncErrorCount(destination string) {
+	s.errorCount.WithLabelValues(destination).Inc()
+}
+
+// SetOldestMessageAge stores the age of the oldest pending message, zero if there are none.
+func (s *Storage) SetOldestMessageAge(destination string, age time.Duration) {
+	s.oldestMessageAge.WithLabelValues(destination).Set(metrics.Milliseconds(age))
+}
@@ -1,4 +1,4 @@
-// Package rabbitmq_metrics provides Prometheus metric collectors for RabbitMQ publisher and consumer operations.
+// Package rabbitmq_metrics provides Prometheus metric collectors for RabbitMQ publisher, consumer and RPC client operations.
 // It tracks message publish/consume latencies, body sizes, and various operation counts including success,
 // retry, requeue, and dead letter queue (DLQ) counts.
 //
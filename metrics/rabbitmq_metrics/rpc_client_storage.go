@@ -0,0 +1,55 @@
+package rabbitmq_metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+// RpcClientStorage collects metrics for RabbitMQ RPC client calls, including call
+// latency and counts of failed and timed out calls.
+type RpcClientStorage struct {
+	callDuration     *prometheus.SummaryVec
+	callErrorCount   *prometheus.CounterVec
+	callTimeoutCount *prometheus.CounterVec
+}
+
+// NewRpcClientStorage creates a new RpcClientStorage instance and registers its metrics
+// with the provided registry. Metrics are labeled by exchange and routing key.
+func NewRpcClientStorage(reg *metrics.Registry) *RpcClientStorage {
+	s := &RpcClientStorage{
+		callDuration: metrics.GetOrRegister(reg, prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Subsystem:  "rabbitmq",
+			Name:       "rpc_call_duration_ms",
+			Help:       "The latency of RPC call including waiting for the reply",
+			Objectives: metrics.DefaultObjectives,
+		}, []string{"exchange", "routing_key"})),
+		callErrorCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "rabbitmq",
+			Name:      "rpc_call_error_count",
+			Help:      "Count of failed RPC calls",
+		}, []string{"exchange", "routing_key"})),
+		callTimeoutCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "rabbitmq",
+			Name:      "rpc_call_timeout_count",
+			Help:      "Count of RPC calls without reply within the timeout",
+		}, []string{"exchange", "routing_key"})),
+	}
+	return s
+}
+
+// ObserveCallDuration records the latency of an RPC call.
+func (s *RpcClientStorage) ObserveCallDuration(exchange string, routingKey string, duration time.Duration) {
+	s.callDuration.WithLabelValues(exchange, routingKey).Observe(metrics.Milliseconds(duration))
+}
+
+// IncCallError increments the counter for failed RPC calls.
+func (s *RpcClientStorage) IncCallError(exchange string, routingKey string) {
+	s.callErrorCount.WithLabelValues(exchange, routingKey).Inc()
+}
+
+// IncCallTimeout increments the counter for RPC calls without reply within the timeout.
+func (s *RpcClientStorage) IncCallTimeout(exchange string, routingKey string) {
+	s.callTimeoutCount.WithLabelValues(exchange, routingKey).Inc()
+}
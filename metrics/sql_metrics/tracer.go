@@ -66,6 +66,21 @@ func (m QueryDurationMetrics) TraceQueryEnd(ctx context.Context, conn *pgx.Conn,
 	m.duration.WithLabelValues(label).Observe(metrics.Milliseconds(duration))
 }
 
+// TraceCopyFromStart is called before rows are copied with CopyFrom.
+// It starts timing the copy if the operation label is set in the context.
+func (m QueryDurationMetrics) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	if OperationLabelFromContext(ctx) == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, startedAtContextKey, time.Now())
+}
+
+// TraceCopyFromEnd is called after rows are copied with CopyFrom. It records the copy duration
+// using the operation label from the context.
+func (m QueryDurationMetrics) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	m.TraceQueryEnd(ctx, conn, pgx.TraceQueryEndData{CommandTag: data.CommandTag, Err: data.Err})
+}
+
 // OperationLabelToContext stores an operation label in the context.
 // This label is used to categorize SQL queries in metrics.
 func OperationLabelToContext(ctx context.Context, label string) context.Context {
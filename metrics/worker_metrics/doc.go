@@ -0,0 +1,9 @@
+// Package worker_metrics provides Prometheus metric collectors for periodic workers.
+// It tracks job run latency and counts of successful, failed and skipped runs.
+//
+// Example usage:
+//
+//	storage := worker_metrics.NewStorage(reg)
+//	storage.ObserveRunDuration(worker, duration)
+//	storage.IncRunCount(worker, worker_metrics.StatusSuccess)
+package worker_metrics
@@ -0,0 +1,53 @@
+package worker_metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+const (
+	// StatusSuccess labels runs completed without error.
+	StatusSuccess = "success"
+	// StatusFail labels runs completed with error.
+	StatusFail = "fail"
+	// StatusSkipped labels runs skipped because another replica holds the lock.
+	StatusSkipped = "skipped"
+)
+
+// Storage collects metrics for periodic workers, including job run latency
+// and run counts by status.
+type Storage struct {
+	duration *prometheus.SummaryVec
+	runCount *prometheus.CounterVec
+}
+
+// NewStorage creates a new Storage instance and registers its metrics with the
+// provided registry. Metrics are labeled by worker name.
+func NewStorage(reg *metrics.Registry) *Storage {
+	s := &Storage{
+		duration: metrics.GetOrRegister(reg, prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Subsystem:  "worker",
+			Name:       "run_duration_ms",
+			Help:       "The latency of single job run",
+			Objectives: metrics.DefaultObjectives,
+		}, []string{"worker"})),
+		runCount: metrics.GetOrRegister(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "worker",
+			Name:      "run_count",
+			Help:      "Count of job runs by status",
+		}, []string{"worker", "status"})),
+	}
+	return s
+}
+
+// ObserveRunDuration records the latency of a single job run.
+func (s *Storage) ObserveRunDuration(worker string, duration time.Duration) {
+	s.duration.WithLabelValues(worker).Observe(metrics.Milliseconds(duration))
+}
+
+// IncRunCount increments the counter of job runs with the specified status.
+func (s *Storage) IncRunCount(worker string, status string) {
+	s.runCount.WithLabelValues(worker, status).Inc()
+}
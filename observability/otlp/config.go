@@ -0,0 +1,28 @@
+// Package otlp provides export of metrics and logs via the OpenTelemetry protocol (OTLP)
+// for environments that do not scrape Prometheus metrics or collect log files.
+package otlp
+
+import (
+	"time"
+
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	defaultExportInterval = 60 * time.Second
+)
+
+// Config holds the connection settings of the OTLP collector.
+type Config struct {
+	// Address specifies the OTLP collector endpoint address.
+	Address string
+	// Protocol is the OTLP exporter protocol, tracing.ProtocolHttp by default.
+	Protocol string
+	// Headers are sent with every export request, e.g. for authorization.
+	Headers map[string]string
+	// Tls enables a secure connection to the collector, the connection is insecure if nil.
+	Tls *tracing.TlsConfig
+	// Resource describes the service, see tracing.NewResource.
+	Resource *resource.Resource
+}
@@ -0,0 +1,67 @@
+package otlp
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// NewLoggerProvider creates a logger provider which exports log records to the collector in batches.
+// Use it with log.NewOtlpCore to export the records of the application logger.
+// The provider must be shut down to export the remaining records.
+func NewLoggerProvider(ctx context.Context, config Config) (*sdklog.LoggerProvider, error) {
+	exporter, err := newLogExporter(ctx, config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new otlp log exporter")
+	}
+
+	opts := []sdklog.LoggerProviderOption{
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+	}
+	if config.Resource != nil {
+		opts = append(opts, sdklog.WithResource(config.Resource))
+	}
+	return sdklog.NewLoggerProvider(opts...), nil
+}
+
+// newLogExporter creates an OTLP log exporter for the protocol of the configuration.
+//
+// nolint:ireturn
+func newLogExporter(ctx context.Context, config Config) (sdklog.Exporter, error) {
+	tlsConfig, err := tracing.NewTlsClientConfig(config.Tls)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new tls config")
+	}
+
+	switch config.Protocol {
+	case "", tracing.ProtocolHttp:
+		opts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(config.Address),
+			otlploghttp.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlploghttp.WithTLSClientConfig(tlsConfig))
+		} else {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		return otlploghttp.New(ctx, opts...)
+	case tracing.ProtocolGrpc:
+		opts := []otlploggrpc.Option{
+			otlploggrpc.WithEndpoint(config.Address),
+			otlploggrpc.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		}
+		return otlploggrpc.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf("unknown otlp protocol '%s'", config.Protocol)
+	}
+}
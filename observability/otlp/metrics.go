@@ -0,0 +1,86 @@
+package otlp
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	prometheus_bridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+// NewMeterProvider creates a meter provider which periodically exports all metrics
+// collected by the gatherer, e.g. metrics.DefaultRegistry, to the collector.
+// Prometheus metrics are converted to OTLP metrics, so the existing metric storages
+// are exported without changes. The interval is one minute if it is not positive.
+// The provider must be shut down to export the remaining metrics.
+func NewMeterProvider(
+	ctx context.Context,
+	config Config,
+	gatherer prometheus.Gatherer,
+	interval time.Duration,
+) (*sdkmetric.MeterProvider, error) {
+	if interval <= 0 {
+		interval = defaultExportInterval
+	}
+
+	exporter, err := newMetricExporter(ctx, config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new otlp metric exporter")
+	}
+
+	reader := sdkmetric.NewPeriodicReader(
+		exporter,
+		sdkmetric.WithInterval(interval),
+		sdkmetric.WithProducer(prometheus_bridge.NewMetricProducer(prometheus_bridge.WithGatherer(gatherer))),
+	)
+	opts := []sdkmetric.Option{
+		sdkmetric.WithReader(reader),
+	}
+	if config.Resource != nil {
+		opts = append(opts, sdkmetric.WithResource(config.Resource))
+	}
+	return sdkmetric.NewMeterProvider(opts...), nil
+}
+
+// newMetricExporter creates an OTLP metric exporter for the protocol of the configuration.
+//
+// nolint:ireturn
+func newMetricExporter(ctx context.Context, config Config) (sdkmetric.Exporter, error) {
+	tlsConfig, err := tracing.NewTlsClientConfig(config.Tls)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new tls config")
+	}
+
+	switch config.Protocol {
+	case "", tracing.ProtocolHttp:
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(config.Address),
+			otlpmetrichttp.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsConfig))
+		} else {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	case tracing.ProtocolGrpc:
+		opts := []otlpmetricgrpc.Option{
+			otlpmetricgrpc.WithEndpoint(config.Address),
+			otlpmetricgrpc.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf("unknown otlp protocol '%s'", config.Protocol)
+	}
+}
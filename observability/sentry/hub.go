@@ -44,6 +44,10 @@ import (
 const (
 	// RequestIdKey is the key used to store the request ID in event extra data.
 	RequestIdKey = "requestId"
+	// TraceIdKey is the key used to store the trace ID of the active span in event tags.
+	TraceIdKey = log.TraceIdKey
+	// SpanIdKey is the key used to store the ID of the active span in event tags.
+	SpanIdKey = log.SpanIdKey
 
 	// defaultTransportTimeout is the timeout for sending events to Sentry.
 	defaultTransportTimeout = 3 * time.Second
@@ -118,7 +122,7 @@ func NewHubFromConfiguration(config Config) (Hub, error) {
 
 // CatchError captures an error with the specified log level.
 // It maps the log level to a Sentry level and extracts the error stack trace.
-// If a request ID is present in the context, it is added to the event.
+// The request ID and the IDs of the active span are added to the event if present in the context.
 func (s SdkHub) CatchError(ctx context.Context, err error, level log.Level) {
 	eventLevel := sentry.LevelError
 	levelFromMapping, ok := logLevelMapping[level]
@@ -132,11 +136,13 @@ func (s SdkHub) CatchError(ctx context.Context, err error, level log.Level) {
 	}
 	SetException(event, err)
 
+	event.Tags = make(map[string]string)
 	requestId := requestid.FromContext(ctx)
 	if requestId != "" {
-		event.Tags = map[string]string{
-			RequestIdKey: requestId,
-		}
+		event.Tags[RequestIdKey] = requestId
+	}
+	for _, field := range log.TraceLogValues(ctx) {
+		event.Tags[field.Key] = field.String
 	}
 
 	s.CatchEvent(ctx, event)
@@ -103,7 +103,7 @@ func (s Logger) log(level log.Level, ctx context.Context, message any, fields ..
 // EventFromLog creates a Sentry event from a log entry.
 // It extracts the error from the message or fields, enriches the event with context values,
 // and applies any event enrichment functions from the context.
-// The returned event includes the request ID if present in the context.
+// The returned event includes the request ID and the IDs of the active span if present in the context.
 func EventFromLog(
 	level sentry.Level,
 	ctx context.Context,
@@ -130,6 +130,9 @@ func EventFromLog(
 	if requestId != "" {
 		tags["requestId"] = requestId
 	}
+	for _, field := range log.TraceLogValues(ctx) {
+		tags[field.Key] = field.String
+	}
 
 	event := &sentry.Event{
 		Tags:      tags,
@@ -0,0 +1,49 @@
+package bgjob_tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/bgjob"
+	"github.com/txix-open/isp-kit/bgjobx/handler"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"github.com/txix-open/isp-kit/observability/tracing/bgjob_tracing"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestLinkedSpan(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	cfg := bgjob_tracing.Config{Provider: provider, Propagator: propagation.TraceContext{}}
+
+	list := []bgjob.EnqueueRequest{{Queue: "mail", Type: "send", RequestId: "abc"}}
+	cfg.StartEnqueue(context.Background(), list)(nil)
+	require.NotEqual("abc", list[0].RequestId)
+
+	requestIds := make([]string, 0)
+	noopCfg := bgjob_tracing.Config{Provider: tracing.NewNoopProvider(), Propagator: propagation.TraceContext{}}
+	for _, middleware := range []handler.Middleware{cfg.Middleware(), noopCfg.Middleware()} {
+		adapter := middleware(handler.SyncHandlerAdapterFunc(func(ctx context.Context, job bgjob.Job) handler.Result {
+			requestIds = append(requestIds, job.RequestId)
+			return handler.Complete()
+		}))
+		adapter.Handle(context.Background(), bgjob.Job{Queue: "mail", Type: "send", RequestId: list[0].RequestId})
+	}
+	require.Equal([]string{"abc", "abc"}, requestIds)
+
+	spans := recorder.Ended()
+	require.Len(spans, 2)
+	enqueueSpan, jobSpan := spans[0], spans[1]
+	require.Equal(trace.SpanKindProducer, enqueueSpan.SpanKind())
+	require.Equal("mail deliver", jobSpan.Name())
+	require.False(jobSpan.Parent().IsValid())
+	require.Len(jobSpan.Links(), 1)
+	require.Equal(enqueueSpan.SpanContext().SpanID(), jobSpan.Links()[0].SpanContext.SpanID())
+}
@@ -0,0 +1,164 @@
+// Package bgjob_tracing provides distributed tracing for background jobs.
+// The trace context of the enqueuing side is stored in the request id of the job
+// and the span of the job execution is linked to it.
+package bgjob_tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/bgjob"
+	"github.com/txix-open/isp-kit/bgjobx/handler"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the tracer for background job tracing.
+const tracerName = "isp-kit/observability/tracing/bgjob"
+
+const (
+	// JobType is the attribute key used to store the type of the job in spans.
+	JobType = attribute.Key("bgjob.job.type")
+	// JobAttempt is the attribute key used to store the attempt of the job execution in spans.
+	JobAttempt = attribute.Key("bgjob.job.attempt")
+)
+
+// Config holds the configuration for background job tracing.
+type Config struct {
+	// Provider is the tracer provider used to create tracers.
+	Provider tracing.TracerProvider
+	// Propagator is the text map propagator for context propagation.
+	Propagator tracing.Propagator
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() Config {
+	return Config{
+		Provider:   tracing.DefaultProvider,
+		Propagator: tracing.DefaultPropagator,
+	}
+}
+
+// StartEnqueue creates a producer span for each job and injects its trace context
+// into the request id of the job. The returned function ends the spans with the
+// result of enqueuing. If the provider is a no-op, the jobs are left unchanged.
+func (c Config) StartEnqueue(ctx context.Context, list []bgjob.EnqueueRequest) func(err error) {
+	if tracing.IsNoop(c.Provider) {
+		return func(err error) {}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	spans := make([]trace.Span, 0, len(list))
+	for i := range list {
+		attributes := []attribute.KeyValue{
+			tracing.RequestId.String(list[i].RequestId),
+			semconv.MessagingSystem("bgjob"),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(list[i].Queue),
+			JobType.String(list[i].Type),
+		}
+		if list[i].Id != "" {
+			attributes = append(attributes, semconv.MessagingMessageID(list[i].Id))
+		}
+		if len(list) > 1 {
+			attributes = append(attributes, semconv.MessagingBatchMessageCount(len(list)))
+		}
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(attributes...),
+		}
+
+		spanName := fmt.Sprintf("%s %s", list[i].Queue, semconv.MessagingOperationPublish.Value.AsString())
+		spanCtx, span := tracer.Start(ctx, spanName, opts...)
+		carrier := propagation.MapCarrier{}
+		c.Propagator.Inject(spanCtx, carrier)
+		list[i].RequestId = handler.InjectTraceContext(list[i].RequestId, carrier)
+		spans = append(spans, span)
+	}
+
+	return func(err error) {
+		for _, span := range spans {
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				span.RecordError(err)
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+			span.End()
+		}
+	}
+}
+
+// Middleware returns a background job middleware that creates a span for each job execution.
+// It extracts the trace context from the request id of the job and restores the original
+// request id for the following middlewares. The span starts a new trace linked to the span
+// of enqueuing, since a job may be executed long after it was enqueued and more than once.
+// If the provider is a no-op, the middleware only restores the request id.
+func (c Config) Middleware() handler.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next handler.SyncHandlerAdapter) handler.SyncHandlerAdapter {
+			return handler.SyncHandlerAdapterFunc(func(ctx context.Context, job bgjob.Job) handler.Result {
+				job.RequestId, _ = handler.ExtractTraceContext(job.RequestId)
+				return next.Handle(ctx, job)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next handler.SyncHandlerAdapter) handler.SyncHandlerAdapter {
+		return handler.SyncHandlerAdapterFunc(func(ctx context.Context, job bgjob.Job) handler.Result {
+			requestId, carrier := handler.ExtractTraceContext(job.RequestId)
+			job.RequestId = requestId
+
+			attributes := []attribute.KeyValue{
+				tracing.RequestId.String(requestId),
+				semconv.MessagingSystem("bgjob"),
+				semconv.MessagingOperationKey.String("deliver"),
+				semconv.MessagingDestinationName(job.Queue),
+				semconv.MessagingMessageID(job.Id),
+				JobType.String(job.Type),
+				JobAttempt.Int(int(job.Attempt)),
+			}
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attributes...),
+				trace.WithNewRoot(),
+			}
+			enqueueSpan := trace.SpanContextFromContext(c.Propagator.Extract(context.Background(), carrier))
+			if enqueueSpan.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: enqueueSpan}))
+			}
+
+			spanName := fmt.Sprintf("%s deliver", job.Queue)
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			result := next.Handle(ctx, job)
+			switch {
+			case result.Retry:
+				span.RecordError(result.Err)
+				span.SetStatus(codes.Error, statusDescription(result.Err, "job will be retried"))
+			case result.MoveToDlq:
+				span.RecordError(result.Err)
+				span.SetStatus(codes.Error, statusDescription(result.Err, "job will be moved to dlq"))
+			default:
+				span.SetStatus(codes.Ok, "")
+			}
+
+			return result
+		})
+	}
+}
+
+// statusDescription returns the span status description of the failed execution.
+func statusDescription(err error, message string) string {
+	if err == nil {
+		return message
+	}
+	return errors.WithMessage(err, message).Error()
+}
@@ -1,5 +1,12 @@
 package tracing
 
+const (
+	// ProtocolHttp exports spans via OTLP over HTTP.
+	ProtocolHttp = "http"
+	// ProtocolGrpc exports spans via OTLP over gRPC.
+	ProtocolGrpc = "grpc"
+)
+
 // Config holds the configuration for the tracing provider.
 type Config struct {
 	// Enable determines whether tracing is enabled.
@@ -16,4 +23,48 @@ type Config struct {
 	InstanceId string
 	// Attributes contains additional custom attributes for the resource.
 	Attributes map[string]string
+	// Protocol is the OTLP exporter protocol, ProtocolHttp by default.
+	Protocol string
+	// Headers are sent with every export request, e.g. for authorization.
+	Headers map[string]string
+	// Tls enables a secure connection to the collector, the connection is insecure if nil.
+	Tls *TlsConfig
+	// Sampling configures span sampling, every span is sampled if nil.
+	Sampling *SamplingConfig
+	// Propagators lists the names of the context propagators, see NewPropagator.
+	Propagators []string
+}
+
+// TlsConfig holds the TLS settings of the connection to the collector.
+// Setting both CertFile and KeyFile enables mutual TLS.
+type TlsConfig struct {
+	// CaFile is the path to the PEM encoded root certificates, the system pool is used if empty.
+	CaFile string
+	// CertFile is the path to the PEM encoded client certificate.
+	CertFile string
+	// KeyFile is the path to the PEM encoded private key of the client certificate.
+	KeyFile string
+	// ServerName overrides the server name used to verify the collector certificate.
+	ServerName string
+	// InsecureSkipVerify disables verification of the collector certificate.
+	InsecureSkipVerify bool
+}
+
+// SamplingConfig holds the sampling settings.
+// Sampling is parent based: a span with a local parent follows the decision of the parent,
+// while the ratio and the rules decide for root spans and spans with a sampled remote parent.
+type SamplingConfig struct {
+	// Ratio is the fraction of root traces to sample, from 0 to 1, every trace is sampled if nil.
+	Ratio *float64
+	// Rules override the sampling ratio for matching span names, the first matching rule is used.
+	Rules []SamplingRule
+}
+
+// SamplingRule overrides the sampling ratio for spans of an endpoint.
+type SamplingRule struct {
+	// SpanName is the span name to match, e.g. an HTTP endpoint or a gRPC method.
+	// A trailing "*" matches any span name with the preceding prefix.
+	SpanName string
+	// Ratio is the fraction of matching traces to sample, from 0 to 1, 0 disables sampling.
+	Ratio float64
 }
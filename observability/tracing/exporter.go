@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"google.golang.org/grpc/credentials"
+)
+
+// newExporter creates an OTLP exporter for the protocol of the configuration.
+func newExporter(ctx context.Context, config Config) (*otlptrace.Exporter, error) {
+	tlsConfig, err := NewTlsClientConfig(config.Tls)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new tls config")
+	}
+
+	switch config.Protocol {
+	case "", ProtocolHttp:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.Address),
+			otlptracehttp.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		} else {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case ProtocolGrpc:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(config.Address),
+			otlptracegrpc.WithHeaders(config.Headers),
+		}
+		if tlsConfig != nil {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, errors.Errorf("unknown otlp protocol '%s'", config.Protocol)
+	}
+}
+
+// NewTlsClientConfig loads the certificates of the configuration. It returns nil if TLS is disabled.
+func NewTlsClientConfig(config *TlsConfig) (*tls.Config, error) {
+	if config == nil {
+		return nil, nil // nolint:nilnil
+	}
+
+	tlsConfig := &tls.Config{ // nolint:gosec
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if config.CaFile != "" {
+		caCert, err := os.ReadFile(config.CaFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "read ca file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("no certificates found in '%s'", config.CaFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -75,3 +75,46 @@ func (c Config) Middleware() request.Middleware {
 		}
 	}
 }
+
+// StreamMiddleware returns a gRPC client stream middleware that creates a span for each outgoing stream.
+// The span lasts from opening the stream until it completes, see request.OnStreamDone.
+// If the provider is a no-op, it returns a pass-through middleware.
+func (c Config) StreamMiddleware() request.StreamMiddleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next request.StreamRoundTripper) request.StreamRoundTripper {
+			return next
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next request.StreamRoundTripper) request.StreamRoundTripper {
+		return func(ctx context.Context, builder *request.StreamBuilder) (isp.BackendService_RequestStreamClient, error) {
+			attributes := []attribute.KeyValue{
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+			}
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes...),
+			}
+
+			spanName := fmt.Sprintf("GRPC stream %s", builder.Endpoint)
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+
+			c.Propagator.Inject(ctx, grpc.MetadataCarrier(builder.MD))
+
+			endSpan := func(err error) {
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				span.End()
+			}
+			stream, err := next(ctx, builder)
+			if err != nil {
+				endSpan(err)
+				return nil, err
+			}
+			return request.OnStreamDone(ctx, stream, endSpan), nil
+		}
+	}
+}
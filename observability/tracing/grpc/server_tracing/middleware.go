@@ -85,3 +85,51 @@ func (c Config) Middleware() grpc.Middleware {
 		}
 	}
 }
+
+// StreamMiddleware returns a gRPC server stream middleware that creates a span for each incoming stream.
+// The span covers the whole stream and is created as in Middleware.
+func (c Config) StreamMiddleware() grpc.StreamMiddleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+			return next
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next grpc.StreamHandlerFunc) grpc.StreamHandlerFunc {
+		return func(ctx context.Context, stream *grpc.Stream) error {
+			md, _ := metadata.FromIncomingContext(ctx)
+			if md == nil {
+				md = metadata.MD{}
+			}
+			spanName, _ := grpc.StringFromMd(grpc.ProxyMethodNameHeader, md)
+			if spanName == "" {
+				return next(ctx, stream)
+			}
+
+			ctx = c.Propagator.Extract(ctx, grpc2.MetadataCarrier(md))
+
+			attributes := []attribute.KeyValue{
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+			}
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attributes...),
+			}
+
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			err := next(ctx, stream)
+
+			if err != nil {
+				logLevel := logutil.LogLevelForError(err)
+				if logLevel == log.ErrorLevel {
+					span.RecordError(err)
+				}
+			}
+
+			return err
+		}
+	}
+}
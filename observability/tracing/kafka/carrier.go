@@ -0,0 +1,52 @@
+// Package kafka provides utilities for Kafka tracing integration.
+package kafka
+
+import (
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// RecordCarrier implements the TextMapCarrier interface for Kafka record headers.
+type RecordCarrier struct {
+	record *kgo.Record
+}
+
+// NewRecordCarrier creates a carrier over the headers of the record.
+func NewRecordCarrier(record *kgo.Record) RecordCarrier {
+	return RecordCarrier{
+		record: record,
+	}
+}
+
+// Get returns the value of the last header with the given key.
+// It returns an empty string if the header does not exist.
+func (c RecordCarrier) Get(key string) string {
+	for i := len(c.record.Headers) - 1; i >= 0; i-- {
+		if c.record.Headers[i].Key == key {
+			return string(c.record.Headers[i].Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces the values of the headers with the given key or appends a new header.
+func (c RecordCarrier) Set(key string, value string) {
+	found := false
+	for i := range c.record.Headers {
+		if c.record.Headers[i].Key == key {
+			c.record.Headers[i].Value = []byte(value)
+			found = true
+		}
+	}
+	if !found {
+		c.record.Headers = append(c.record.Headers, kgo.RecordHeader{Key: key, Value: []byte(value)})
+	}
+}
+
+// Keys returns the keys of all record headers.
+func (c RecordCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.record.Headers))
+	for _, header := range c.record.Headers {
+		keys = append(keys, header.Key)
+	}
+	return keys
+}
@@ -0,0 +1,184 @@
+// Package consumer_tracing provides Kafka consumer middlewares for distributed tracing.
+package consumer_tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/handler"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka"
+	"github.com/txix-open/isp-kit/requestid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the tracer for Kafka consumer tracing.
+const tracerName = "isp-kit/observability/tracing/kafka"
+
+// Config holds the configuration for the Kafka consumer tracing middlewares.
+type Config struct {
+	// Provider is the tracer provider used to create tracers.
+	Provider tracing.TracerProvider
+	// Propagator is the text map propagator for context propagation.
+	Propagator tracing.Propagator
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() Config {
+	return Config{
+		Provider:   tracing.DefaultProvider,
+		Propagator: tracing.DefaultPropagator,
+	}
+}
+
+// Middleware returns a Kafka consumer middleware that creates spans for incoming records.
+// It extracts trace context from the record headers, creates a consumer span, and records
+// the processing result (commit, retry or dlq). If the provider is a no-op,
+// it returns a pass-through middleware.
+func (c Config) Middleware() handler.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next handler.SyncHandlerAdapter) handler.SyncHandlerAdapter {
+			return handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+				return next.Handle(ctx, delivery)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next handler.SyncHandlerAdapter) handler.SyncHandlerAdapter {
+		return handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+			source := delivery.Source()
+			ctx = c.Propagator.Extract(ctx, kafka.NewRecordCarrier(source))
+
+			attributes := append(
+				recordAttributes(delivery),
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+				semconv.MessagingKafkaDestinationPartition(int(source.Partition)),
+				semconv.MessagingKafkaMessageOffset(int(source.Offset)),
+			)
+			if len(source.Key) > 0 {
+				attributes = append(attributes, semconv.MessagingKafkaMessageKey(string(source.Key)))
+			}
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attributes...),
+			}
+
+			spanName := fmt.Sprintf("%s deliver", source.Topic)
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			result := next.Handle(ctx, delivery)
+			setResultStatus(span, result)
+
+			return result
+		})
+	}
+}
+
+// BatchMiddleware returns a Kafka batch consumer middleware that creates a span for each batch.
+// The span is linked to the trace context extracted from the headers of every record of the batch
+// and records the processing results. If the provider is a no-op, it returns a pass-through middleware.
+func (c Config) BatchMiddleware() batch_handler.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next batch_handler.SyncHandlerAdapter) batch_handler.SyncHandlerAdapter {
+			return batch_handler.SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) batch_handler.BatchResult {
+				return next.Handle(ctx, batch)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next batch_handler.SyncHandlerAdapter) batch_handler.SyncHandlerAdapter {
+		return batch_handler.SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) batch_handler.BatchResult {
+			if len(batch) == 0 {
+				return next.Handle(ctx, batch)
+			}
+
+			links := make([]trace.Link, 0, len(batch))
+			for _, delivery := range batch {
+				recordCtx := c.Propagator.Extract(context.Background(), kafka.NewRecordCarrier(delivery.Source()))
+				spanContext := trace.SpanContextFromContext(recordCtx)
+				if spanContext.IsValid() {
+					links = append(links, trace.Link{SpanContext: spanContext})
+				}
+			}
+
+			source := batch[0].Source()
+			attributes := append(
+				recordAttributes(batch[0]),
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+				semconv.MessagingKafkaDestinationPartition(int(source.Partition)),
+				semconv.MessagingBatchMessageCount(len(batch)),
+			)
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attributes...),
+				trace.WithLinks(links...),
+			}
+
+			spanName := fmt.Sprintf("%s deliver", source.Topic)
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			result := next.Handle(ctx, batch)
+			failed := 0
+			for i := range batch {
+				itemResult := result.Get(i)
+				switch {
+				case itemResult.Retry:
+					failed++
+					span.RecordError(itemResult.RetryError)
+				case itemResult.MoveToDlq:
+					failed++
+					span.RecordError(itemResult.DlqError)
+				}
+			}
+			if failed > 0 {
+				span.SetStatus(codes.Error, fmt.Sprintf("%d of %d messages are not processed", failed, len(batch)))
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+
+			return result
+		})
+	}
+}
+
+// recordAttributes returns the common span attributes of the consumed record.
+func recordAttributes(delivery *consumer.Delivery) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.MessagingSystem("kafka"),
+		semconv.MessagingOperationKey.String("deliver"),
+		semconv.MessagingDestinationName(delivery.Source().Topic),
+		semconv.MessagingKafkaConsumerGroup(delivery.ConsumerGroupId()),
+	}
+}
+
+// setResultStatus sets the span status according to the processing result.
+func setResultStatus(span trace.Span, result handler.Result) {
+	switch {
+	case result.Commit:
+		span.SetStatus(codes.Ok, "")
+	case result.Retry:
+		span.RecordError(result.RetryError)
+		span.SetStatus(codes.Error, statusDescription(result.RetryError, "message will be retried"))
+	case result.MoveToDlq:
+		span.RecordError(result.DlqError)
+		span.SetStatus(codes.Error, statusDescription(result.DlqError, "message will be moved to dlq"))
+	}
+}
+
+// statusDescription returns the span status description of the failed processing.
+func statusDescription(err error, message string) string {
+	if err == nil {
+		return message
+	}
+	return errors.WithMessage(err, message).Error()
+}
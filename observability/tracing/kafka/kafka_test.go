@@ -0,0 +1,88 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax/batch_handler"
+	"github.com/txix-open/isp-kit/kafkax/consumer"
+	"github.com/txix-open/isp-kit/kafkax/handler"
+	"github.com/txix-open/isp-kit/kafkax/publisher"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka/consumer_tracing"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka/publisher_tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRecordCarrier(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	record := &kgo.Record{Headers: []kgo.RecordHeader{{Key: "a", Value: []byte("1")}}}
+	carrier := kafka.NewRecordCarrier(record)
+	require.Equal("1", carrier.Get("a"))
+	require.Empty(carrier.Get("b"))
+
+	carrier.Set("a", "2")
+	carrier.Set("b", "3")
+	require.Equal([]kgo.RecordHeader{
+		{Key: "a", Value: []byte("2")},
+		{Key: "b", Value: []byte("3")},
+	}, record.Headers)
+	require.Equal([]string{"a", "b"}, carrier.Keys())
+}
+
+func TestPropagation(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	propagator := propagation.TraceContext{}
+
+	record := &kgo.Record{Topic: "events", Key: []byte("key"), Value: []byte("value")}
+	pub := publisher_tracing.Config{Provider: provider, Propagator: propagator}.Middleware()(
+		publisher.RoundTripperFunc(func(ctx context.Context, rs ...*kgo.Record) error {
+			return nil
+		}),
+	)
+	err := pub.Publish(context.Background(), record)
+	require.NoError(err)
+	require.NotEmpty(kafka.NewRecordCarrier(record).Get("traceparent"))
+
+	var consumerSpan trace.SpanContext
+	cons := consumer_tracing.Config{Provider: provider, Propagator: propagator}.Middleware()(
+		handler.SyncHandlerAdapterFunc(func(ctx context.Context, delivery *consumer.Delivery) handler.Result {
+			consumerSpan = trace.SpanContextFromContext(ctx)
+			return handler.Commit()
+		}),
+	)
+	cons.Handle(context.Background(), consumer.NewDelivery(nil, nil, record, "group"))
+
+	batch := consumer_tracing.Config{Provider: provider, Propagator: propagator}.BatchMiddleware()(
+		batch_handler.SyncHandlerAdapterFunc(func(ctx context.Context, batch []*consumer.Delivery) batch_handler.BatchResult {
+			return batch_handler.CommitAll()
+		}),
+	)
+	batch.Handle(context.Background(), []*consumer.Delivery{consumer.NewDelivery(nil, nil, record, "group")})
+
+	spans := recorder.Ended()
+	require.Len(spans, 3)
+	publishSpan, deliverSpan, batchSpan := spans[0], spans[1], spans[2]
+	require.Equal("events publish", publishSpan.Name())
+	require.Equal(trace.SpanKindProducer, publishSpan.SpanKind())
+	require.Equal("events deliver", deliverSpan.Name())
+	require.Equal(trace.SpanKindConsumer, deliverSpan.SpanKind())
+	require.Equal(codes.Ok, deliverSpan.Status().Code)
+	require.Equal(publishSpan.SpanContext().SpanID(), deliverSpan.Parent().SpanID())
+	require.Equal(deliverSpan.SpanContext(), consumerSpan)
+	require.False(batchSpan.Parent().IsValid())
+	require.Len(batchSpan.Links(), 1)
+	require.Equal(publishSpan.SpanContext().SpanID(), batchSpan.Links()[0].SpanContext.SpanID())
+}
@@ -0,0 +1,97 @@
+// Package publisher_tracing provides Kafka publisher middleware for distributed tracing.
+package publisher_tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/isp-kit/kafkax/publisher"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"github.com/txix-open/isp-kit/observability/tracing/kafka"
+	"github.com/txix-open/isp-kit/requestid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the tracer for Kafka publisher tracing.
+const tracerName = "isp-kit/observability/tracing/kafka"
+
+// Config holds the configuration for the Kafka publisher tracing middleware.
+type Config struct {
+	// Provider is the tracer provider used to create tracers.
+	Provider tracing.TracerProvider
+	// Propagator is the text map propagator for context propagation.
+	Propagator tracing.Propagator
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() Config {
+	return Config{
+		Provider:   tracing.DefaultProvider,
+		Propagator: tracing.DefaultPropagator,
+	}
+}
+
+// Middleware returns a Kafka publisher middleware that creates spans for outgoing records.
+// It creates a producer span for each record, injects its trace context into the record headers
+// and records the publishing status. If the provider is a no-op, it returns a pass-through middleware.
+func (c Config) Middleware() publisher.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next publisher.RoundTripper) publisher.RoundTripper {
+			return publisher.RoundTripperFunc(func(ctx context.Context, rs ...*kgo.Record) error {
+				return next.Publish(ctx, rs...)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next publisher.RoundTripper) publisher.RoundTripper {
+		return publisher.RoundTripperFunc(func(ctx context.Context, rs ...*kgo.Record) error {
+			spans := make([]trace.Span, 0, len(rs))
+			for _, r := range rs {
+				attributes := []attribute.KeyValue{
+					tracing.RequestId.String(requestid.FromContext(ctx)),
+					semconv.MessagingSystem("kafka"),
+					semconv.MessagingOperationPublish,
+					semconv.MessagingDestinationName(r.Topic),
+					semconv.MessagingMessagePayloadSizeBytes(len(r.Value)),
+				}
+				if len(r.Key) > 0 {
+					attributes = append(attributes, semconv.MessagingKafkaMessageKey(string(r.Key)))
+				}
+				if r.Value == nil {
+					attributes = append(attributes, semconv.MessagingKafkaMessageTombstone(true))
+				}
+				if len(rs) > 1 {
+					attributes = append(attributes, semconv.MessagingBatchMessageCount(len(rs)))
+				}
+
+				opts := []trace.SpanStartOption{
+					trace.WithSpanKind(trace.SpanKindProducer),
+					trace.WithAttributes(attributes...),
+				}
+
+				spanName := fmt.Sprintf("%s %s", r.Topic, semconv.MessagingOperationPublish.Value.AsString())
+				spanCtx, span := tracer.Start(ctx, spanName, opts...)
+				c.Propagator.Inject(spanCtx, kafka.NewRecordCarrier(r))
+				spans = append(spans, span)
+			}
+
+			err := next.Publish(ctx, rs...)
+			for _, span := range spans {
+				if err != nil {
+					span.SetStatus(codes.Error, err.Error())
+					span.RecordError(err)
+				} else {
+					span.SetStatus(codes.Ok, "")
+				}
+				span.End()
+			}
+
+			return err
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// PropagatorTraceContext is the W3C Trace Context propagator.
+	PropagatorTraceContext = "tracecontext"
+	// PropagatorBaggage is the W3C Baggage propagator.
+	PropagatorBaggage = "baggage"
+	// PropagatorB3 is the B3 single header propagator.
+	PropagatorB3 = "b3"
+	// PropagatorB3Multi is the B3 multiple headers propagator.
+	PropagatorB3Multi = "b3multi"
+)
+
+// NewPropagator creates a composite propagator from the propagator names,
+// e.g. ["tracecontext", "baggage", "b3"]. It returns DefaultPropagator if no names are provided.
+//
+//nolint:ireturn
+func NewPropagator(names []string) (Propagator, error) {
+	if len(names) == 0 {
+		return DefaultPropagator, nil
+	}
+
+	propagators := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case PropagatorTraceContext:
+			propagators = append(propagators, propagation.TraceContext{})
+		case PropagatorBaggage:
+			propagators = append(propagators, propagation.Baggage{})
+		case PropagatorB3:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case PropagatorB3Multi:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		default:
+			return nil, errors.Errorf("unknown propagator '%s'", name)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
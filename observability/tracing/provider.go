@@ -8,7 +8,6 @@ import (
 	"github.com/txix-open/isp-kit/log"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -27,8 +26,8 @@ const (
 )
 
 // NewProviderFromConfiguration creates a new tracer provider from the given configuration.
-// It returns a no-op provider if tracing is disabled. The provider is configured to export
-// traces via OTLP over HTTP to the specified address.
+// It returns a no-op provider if tracing is disabled. The provider exports traces via OTLP
+// over HTTP or gRPC to the specified address and samples spans according to config.Sampling.
 func NewProviderFromConfiguration(ctx context.Context, logger log.Logger, config Config) (Provider, error) {
 	if !config.Enable {
 		return NewNoopProvider(), nil
@@ -37,15 +36,33 @@ func NewProviderFromConfiguration(ctx context.Context, logger log.Logger, config
 	stdLogger := log.StdLoggerWithLevel(logger, log.InfoLevel, log.String("worker", "tracer"))
 	otel.SetLogger(stdr.New(stdLogger))
 
-	exporter, err := otlptracehttp.New(
-		ctx,
-		otlptracehttp.WithEndpoint(config.Address),
-		otlptracehttp.WithInsecure(),
-	)
+	sampler, err := NewSampler(config.Sampling)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new sampler")
+	}
+
+	exporter, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "new otlp exporter")
+	}
+
+	res, err := NewResource(config)
 	if err != nil {
-		return nil, errors.WithMessage(err, "new otlp http exporter")
+		return nil, errors.WithMessage(err, "new resource")
 	}
 
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	)
+	return provider, nil
+}
+
+// NewResource creates the resource describing the service: environment, name, version,
+// instance id and the custom attributes of the configuration.
+// The resource is shared by traces, metrics and logs exported via OTLP.
+func NewResource(config Config) (*resource.Resource, error) {
 	attributes := []attribute.KeyValue{
 		semconv.DeploymentEnvironment(config.Environment),
 		semconv.ServiceVersion(config.ModuleVersion),
@@ -55,21 +72,11 @@ func NewProviderFromConfiguration(ctx context.Context, logger log.Logger, config
 	for key, value := range config.Attributes {
 		attributes = append(attributes, attribute.String(key, value))
 	}
-	res, err := resource.Merge(
+	return resource.Merge(
 		resource.Default(),
 		resource.NewWithAttributes(
 			semconv.SchemaURL,
 			attributes...,
 		),
 	)
-	if err != nil {
-		return nil, errors.WithMessage(err, "new resource")
-	}
-
-	provider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-	)
-	return provider, nil
 }
@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// defaultSamplingRatio samples every root trace if the ratio is not set.
+	defaultSamplingRatio = 1.0
+)
+
+// NewSampler creates a parent based sampler from the configuration.
+// It samples every span if the configuration is nil.
+// Spans with a sampled remote parent are sampled unless a rule says otherwise,
+// e.g. a healthcheck called by a traced client can still be excluded.
+// Returns an error if the ratio or the ratio of a rule is out of the range from 0 to 1.
+//
+//nolint:ireturn
+func NewSampler(config *SamplingConfig) (sdktrace.Sampler, error) {
+	if config == nil {
+		return sdktrace.AlwaysSample(), nil
+	}
+
+	ratio := defaultSamplingRatio
+	if config.Ratio != nil {
+		ratio = *config.Ratio
+	}
+	err := validateRatio(ratio)
+	if err != nil {
+		return nil, errors.WithMessage(err, "sampling ratio")
+	}
+	for _, rule := range config.Rules {
+		err := validateRatio(rule.Ratio)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "sampling ratio of rule '%s'", rule.SpanName)
+		}
+	}
+
+	return sdktrace.ParentBased(
+		newRuleSampler(config.Rules, sdktrace.TraceIDRatioBased(ratio)),
+		sdktrace.WithRemoteParentSampled(newRuleSampler(config.Rules, sdktrace.AlwaysSample())),
+	), nil
+}
+
+// validateRatio checks that the sampling ratio is in the range from 0 to 1.
+func validateRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 || math.IsNaN(ratio) {
+		return errors.Errorf("expected value from 0 to 1, got %v", ratio)
+	}
+	return nil
+}
+
+// ruleSampler applies the sampler of the first rule matching the span name
+// and the fallback sampler otherwise.
+type ruleSampler struct {
+	rules    []SamplingRule
+	samplers []sdktrace.Sampler
+	fallback sdktrace.Sampler
+}
+
+// newRuleSampler creates a rule sampler falling back to the sampler.
+//
+//nolint:ireturn
+func newRuleSampler(rules []SamplingRule, fallback sdktrace.Sampler) sdktrace.Sampler {
+	if len(rules) == 0 {
+		return fallback
+	}
+	samplers := make([]sdktrace.Sampler, 0, len(rules))
+	for _, rule := range rules {
+		samplers = append(samplers, sdktrace.TraceIDRatioBased(rule.Ratio))
+	}
+	return ruleSampler{
+		rules:    rules,
+		samplers: samplers,
+		fallback: fallback,
+	}
+}
+
+// ShouldSample returns the decision of the sampler of the first matching rule.
+func (s ruleSampler) ShouldSample(parameters sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for i, rule := range s.rules {
+		if rule.matches(parameters.Name) {
+			return s.samplers[i].ShouldSample(parameters)
+		}
+	}
+	return s.fallback.ShouldSample(parameters)
+}
+
+// Description returns the description of the sampler.
+func (s ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules=%d,fallback=%s}", len(s.rules), s.fallback.Description())
+}
+
+// matches reports whether the rule matches the span name.
+func (r SamplingRule) matches(spanName string) bool {
+	prefix, isPrefix := strings.CutSuffix(r.SpanName, "*")
+	if isPrefix {
+		return strings.HasPrefix(spanName, prefix)
+	}
+	return spanName == r.SpanName
+}
@@ -0,0 +1,76 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSampler(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	sampler, err := tracing.NewSampler(&tracing.SamplingConfig{
+		Rules: []tracing.SamplingRule{
+			{SpanName: "GET /internal/*", Ratio: 0},
+			{SpanName: "health", Ratio: 0},
+		},
+	})
+	require.NoError(err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler))
+	tracer := provider.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "GET /api/orders")
+	require.True(span.SpanContext().IsSampled())
+	_, child := tracer.Start(ctx, "health")
+	require.True(child.SpanContext().IsSampled())
+
+	_, span = tracer.Start(context.Background(), "GET /internal/health")
+	require.False(span.SpanContext().IsSampled())
+	_, span = tracer.Start(context.Background(), "health")
+	require.False(span.SpanContext().IsSampled())
+
+	carrier := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	remoteCtx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	_, span = tracer.Start(remoteCtx, "health")
+	require.False(span.SpanContext().IsSampled())
+	_, span = tracer.Start(remoteCtx, "GET /api/orders")
+	require.True(span.SpanContext().IsSampled())
+}
+
+func TestSamplerRatio(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ratio := 0.0
+	sampler, err := tracing.NewSampler(&tracing.SamplingConfig{Ratio: &ratio})
+	require.NoError(err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler))
+	_, span := provider.Tracer("test").Start(context.Background(), "GET /api/orders")
+	require.False(span.SpanContext().IsSampled())
+
+	for _, invalid := range []float64{-0.1, 1.5} {
+		_, err = tracing.NewSampler(&tracing.SamplingConfig{Ratio: &invalid})
+		require.Error(err)
+		_, err = tracing.NewSampler(&tracing.SamplingConfig{
+			Rules: []tracing.SamplingRule{{SpanName: "health", Ratio: invalid}},
+		})
+		require.Error(err)
+	}
+}
+
+func TestNewPropagator(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	propagator, err := tracing.NewPropagator([]string{"tracecontext", "baggage", "b3"})
+	require.NoError(err)
+	require.ElementsMatch([]string{"traceparent", "tracestate", "baggage", "b3"}, propagator.Fields())
+
+	_, err = tracing.NewPropagator([]string{"jaeger"})
+	require.Error(err)
+}
@@ -17,3 +17,12 @@ func (n noop) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.Trac
 // TraceQueryEnd performs no operation.
 func (n noop) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
 }
+
+// TraceCopyFromStart returns the context unchanged without creating a span.
+func (n noop) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	return ctx
+}
+
+// TraceCopyFromEnd performs no operation.
+func (n noop) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+}
@@ -20,13 +20,16 @@ import (
 // QueryParametersKey is the attribute key for storing query arguments.
 var QueryParametersKey = attribute.Key("pgx.query.parameters")
 
+// CopyColumnsKey is the attribute key for storing columns of copied rows.
+var CopyColumnsKey = attribute.Key("pgx.copy.columns")
+
 // contextKey is used for storing the span in the context.
 type contextKey struct{}
 
 // contextKeyValue is the global context key instance.
 var contextKeyValue = contextKey{}
 
-// Tracer implements pgx.QueryTracer for tracing SQL queries.
+// Tracer implements pgx.QueryTracer and pgx.CopyFromTracer for tracing SQL queries.
 type Tracer struct {
 	tracer trace.Tracer
 	config Config
@@ -81,6 +84,36 @@ func (t Tracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.Trace
 	return context.WithValue(ctx, contextKeyValue, span) //nolint:spancheck
 }
 
+// TraceCopyFromStart creates a span for the beginning of a copy of rows into a table.
+// The span is named by the operation label from context or "COPY" if it is not set
+// and includes the table and copied columns.
+func (t Tracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	label := sql_metrics.OperationLabelFromContext(ctx)
+	if label == "" {
+		label = "COPY"
+	}
+
+	attributes := []attribute.KeyValue{
+		tracing.RequestId.String(requestid.FromContext(ctx)),
+		semconv.DBSQLTable(data.TableName.Sanitize()),
+		CopyColumnsKey.StringSlice(data.ColumnNames),
+	}
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	}
+
+	spanName := fmt.Sprintf("SQL query %s", label)
+	ctx, span := t.tracer.Start(ctx, spanName, opts...) //nolint:spancheck
+
+	return context.WithValue(ctx, contextKeyValue, span) //nolint:spancheck
+}
+
+// TraceCopyFromEnd ends the span for a copy of rows and records any errors.
+func (t Tracer) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.TraceQueryEnd(ctx, conn, pgx.TraceQueryEndData{CommandTag: data.CommandTag, Err: data.Err})
+}
+
 // TraceQueryEnd ends the span for a database query and records any errors.
 // It skips recording errors for sql.ErrNoRows.
 func (t Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
@@ -0,0 +1,48 @@
+// Package stomp provides utilities for STOMP tracing integration.
+package stomp
+
+import (
+	"github.com/go-stomp/stomp/v3/frame"
+)
+
+// HeaderCarrier implements the TextMapCarrier interface for STOMP frame headers.
+type HeaderCarrier struct {
+	header *frame.Header
+}
+
+// NewHeaderCarrier creates a carrier over the frame headers.
+func NewHeaderCarrier(header *frame.Header) HeaderCarrier {
+	return HeaderCarrier{
+		header: header,
+	}
+}
+
+// Get returns the value of the first header with the given key.
+// It returns an empty string if the header does not exist.
+func (c HeaderCarrier) Get(key string) string {
+	if c.header == nil {
+		return ""
+	}
+	return c.header.Get(key)
+}
+
+// Set replaces the value of the header with the given key or adds a new header.
+func (c HeaderCarrier) Set(key string, value string) {
+	if c.header == nil {
+		return
+	}
+	c.header.Set(key, value)
+}
+
+// Keys returns the keys of all frame headers.
+func (c HeaderCarrier) Keys() []string {
+	if c.header == nil {
+		return nil
+	}
+	keys := make([]string, 0, c.header.Len())
+	for i := range c.header.Len() {
+		key, _ := c.header.GetAt(i)
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,90 @@
+// Package consumer_tracing provides STOMP consumer middleware for distributed tracing.
+package consumer_tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-stomp/stomp/v3"
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/observability/tracing"
+	stomp_tracing "github.com/txix-open/isp-kit/observability/tracing/stomp"
+	"github.com/txix-open/isp-kit/requestid"
+	"github.com/txix-open/isp-kit/stompx/handler"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the tracer for STOMP consumer tracing.
+const tracerName = "isp-kit/observability/tracing/stomp"
+
+// Config holds the configuration for the STOMP consumer tracing middleware.
+type Config struct {
+	// Provider is the tracer provider used to create tracers.
+	Provider tracing.TracerProvider
+	// Propagator is the text map propagator for context propagation.
+	Propagator tracing.Propagator
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() Config {
+	return Config{
+		Provider:   tracing.DefaultProvider,
+		Propagator: tracing.DefaultPropagator,
+	}
+}
+
+// Middleware returns a STOMP consumer middleware that creates spans for incoming messages.
+// It extracts trace context from the frame headers, creates a consumer span, and records
+// the processing result (ack or requeue). If the provider is a no-op,
+// it returns a pass-through middleware.
+func (c Config) Middleware() handler.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next handler.HandlerAdapter) handler.HandlerAdapter {
+			return handler.AdapterFunc(func(ctx context.Context, msg *stomp.Message) handler.Result {
+				return next.Handle(ctx, msg)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next handler.HandlerAdapter) handler.HandlerAdapter {
+		return handler.AdapterFunc(func(ctx context.Context, msg *stomp.Message) handler.Result {
+			ctx = c.Propagator.Extract(ctx, stomp_tracing.NewHeaderCarrier(msg.Header))
+
+			attributes := []attribute.KeyValue{
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+				semconv.MessagingSystem("stomp"),
+				semconv.MessagingOperationKey.String("deliver"),
+				semconv.MessagingDestinationName(msg.Destination),
+				semconv.MessagingMessagePayloadSizeBytes(len(msg.Body)),
+			}
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(attributes...),
+			}
+
+			spanName := fmt.Sprintf("%s deliver", msg.Destination)
+
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			result := next.Handle(ctx, msg)
+			switch {
+			case result.Ack:
+				span.SetStatus(codes.Ok, "")
+			case result.Requeue:
+				span.RecordError(result.Err)
+				description := "message will be requeued"
+				if result.Err != nil {
+					description = errors.WithMessage(result.Err, description).Error()
+				}
+				span.SetStatus(codes.Error, description)
+			}
+
+			return result
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package publisher_tracing provides STOMP publisher middleware for distributed tracing.
+package publisher_tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/txix-open/isp-kit/observability/tracing"
+	"github.com/txix-open/isp-kit/requestid"
+	"github.com/txix-open/isp-kit/stompx/publisher"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the tracer for STOMP publisher tracing.
+const tracerName = "isp-kit/observability/tracing/stomp"
+
+// Config holds the configuration for the STOMP publisher tracing middleware.
+type Config struct {
+	// Provider is the tracer provider used to create tracers.
+	Provider tracing.TracerProvider
+	// Propagator is the text map propagator for context propagation.
+	Propagator tracing.Propagator
+}
+
+// NewConfig creates a new Config with default values.
+func NewConfig() Config {
+	return Config{
+		Provider:   tracing.DefaultProvider,
+		Propagator: tracing.DefaultPropagator,
+	}
+}
+
+// Middleware returns a STOMP publisher middleware that creates spans for outgoing messages.
+// It injects trace context into the frame headers, creates a producer span, and records
+// the publishing status. If the provider is a no-op, it returns a pass-through middleware.
+func (c Config) Middleware() publisher.Middleware {
+	if tracing.IsNoop(c.Provider) {
+		return func(next publisher.RoundTripper) publisher.RoundTripper {
+			return publisher.RoundTripperFunc(func(ctx context.Context, queue string, msg *publisher.Message) error {
+				return next.Publish(ctx, queue, msg)
+			})
+		}
+	}
+
+	tracer := c.Provider.Tracer(tracerName)
+	return func(next publisher.RoundTripper) publisher.RoundTripper {
+		return publisher.RoundTripperFunc(func(ctx context.Context, queue string, msg *publisher.Message) error {
+			attributes := []attribute.KeyValue{
+				tracing.RequestId.String(requestid.FromContext(ctx)),
+				semconv.MessagingSystem("stomp"),
+				semconv.MessagingOperationPublish,
+				semconv.MessagingDestinationName(queue),
+				semconv.MessagingMessagePayloadSizeBytes(len(msg.Body)),
+			}
+
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindProducer),
+				trace.WithAttributes(attributes...),
+			}
+
+			spanName := fmt.Sprintf("%s %s", queue, semconv.MessagingOperationPublish.Value.AsString())
+
+			ctx, span := tracer.Start(ctx, spanName, opts...)
+			defer span.End()
+
+			carrier := propagation.MapCarrier{}
+			c.Propagator.Inject(ctx, carrier)
+			for key, value := range carrier {
+				msg = msg.WithHeader(key, value)
+			}
+
+			err := next.Publish(ctx, queue, msg)
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				span.RecordError(err)
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+
+			return err
+		})
+	}
+}
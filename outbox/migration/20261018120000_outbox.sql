@@ -0,0 +1,18 @@
+-- +goose Up
+create table outbox_message
+(
+    id          serial8 primary key,
+    destination text      not null,
+    key         bytea,
+    body        bytea     not null,
+    headers     jsonb     not null default '{}',
+    request_id  text      not null default '',
+    attempt     int4      not null default 0,
+    last_error  text,
+    created_at  timestamp not null
+);
+
+create index ix_outbox_message__destination_id on outbox_message (destination, id);
+
+-- +goose Down
+drop table outbox_message;
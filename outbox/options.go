@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/txix-open/isp-kit/metrics"
+)
+
+const (
+	defaultBatchSize      = 100
+	defaultPollInterval   = 1 * time.Second
+	defaultBackoffInitial = 1 * time.Second
+	defaultBackoffMax     = 1 * time.Minute
+)
+
+// Option is a function that configures a Relay.
+type Option func(r *Relay)
+
+// WithBatchSize sets the maximum number of messages published at once.
+// By default, it is 100.
+func WithBatchSize(batchSize int) Option {
+	return func(r *Relay) {
+		r.batchSize = max(batchSize, 1)
+	}
+}
+
+// WithPollInterval sets the interval between polls of the outbox table.
+// By default, it is 1 second.
+func WithPollInterval(interval time.Duration) Option {
+	return func(r *Relay) {
+		r.pollInterval = interval
+	}
+}
+
+// WithBackoff sets the exponential delay before retrying a failed delivery,
+// starting from initial and capped by maxDelay.
+// By default, the delay grows from 1 second to 1 minute.
+func WithBackoff(initial time.Duration, maxDelay time.Duration) Option {
+	return func(r *Relay) {
+		r.backoffInitial = initial
+		r.backoffMax = max(maxDelay, initial)
+	}
+}
+
+// WithMetrics sets the metrics registry for the relay metrics.
+// By default, metrics.DefaultRegistry is used.
+func WithMetrics(registry *metrics.Registry) Option {
+	return func(r *Relay) {
+		r.metricsRegistry = registry
+	}
+}
@@ -0,0 +1,100 @@
+// Package outbox implements the transactional outbox pattern on top of PostgreSQL.
+// Messages are written into the outbox table inside the same transaction as the
+// business data, so they are published if and only if the transaction commits.
+// A Relay delivers stored messages to RabbitMQ or Kafka publishers in order,
+// retrying failures with backoff.
+//
+// The outbox table must be created by the migration from the migration directory.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/db"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/requestid"
+)
+
+const (
+	// writeLockPrefix is the prefix of the advisory lock key serializing writers of a destination.
+	writeLockPrefix = "outbox_write_"
+)
+
+// Message is a message to publish through the outbox.
+type Message struct {
+	// Key is a routing key for RabbitMQ or a record key for Kafka.
+	// If empty, the publisher defaults are used.
+	Key []byte
+	// Body is the message payload.
+	Body []byte
+	// Headers are additional message headers.
+	Headers map[string]string
+}
+
+// Entry is a message read from the outbox for delivery.
+type Entry struct {
+	Message
+
+	Id        int64
+	RequestId string
+	Attempt   int
+	CreatedAt time.Time
+}
+
+// Sender delivers outbox entries to a message broker.
+type Sender interface {
+	// Send publishes entries in the given order. It returns an error if any entry is not published,
+	// in which case the whole batch is delivered again later.
+	Send(ctx context.Context, entries []Entry) error
+}
+
+// SenderFunc is an adapter that allows a function to be used as a Sender.
+type SenderFunc func(ctx context.Context, entries []Entry) error
+
+// Send implements the Sender interface by calling the underlying function.
+func (f SenderFunc) Send(ctx context.Context, entries []Entry) error {
+	return f(ctx, entries)
+}
+
+// Write stores messages for the destination in the outbox table.
+// Pass the *db.Tx of the business transaction to publish messages only if it commits.
+// The request id from the context is stored with each message and propagated on delivery.
+//
+// Write takes a transaction-level advisory lock of the destination, so transactions writing
+// to the same destination wait for each other until commit. Thus message ids grow in commit order
+// and the Relay never sends a message before a message with a lower id that is not committed yet.
+// Call Write at the end of the business transaction to hold the lock as short as possible.
+func Write(ctx context.Context, tx db.DB, destination string, messages ...Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", writeLockPrefix+destination)
+	if err != nil {
+		return errors.WithMessagef(err, "lock outbox destination '%s'", destination)
+	}
+
+	requestId := requestid.FromContext(ctx)
+	now := time.Now().UTC()
+	for _, message := range messages {
+		headers := message.Headers
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headersJson, err := json.Marshal(headers)
+		if err != nil {
+			return errors.WithMessage(err, "marshal headers")
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO outbox_message (destination, key, body, headers, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+			destination, message.Key, message.Body, string(headersJson), requestId, now,
+		)
+		if err != nil {
+			return errors.WithMessagef(err, "insert outbox message for '%s'", destination)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package outbox_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/db"
+	"github.com/txix-open/isp-kit/dbx"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/outbox"
+	"github.com/txix-open/isp-kit/requestid"
+	"github.com/txix-open/isp-kit/test"
+	"github.com/txix-open/isp-kit/test/dbt"
+)
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+
+	test, require := test.New(t)
+	testDb := dbt.New(test, dbx.WithMigrationRunner("./migration", test.Logger()))
+	destination := "relay_" + test.Id()
+
+	lock := sync.Mutex{}
+	received := make([]outbox.Entry, 0)
+	attempts := 0
+	sender := outbox.SenderFunc(func(ctx context.Context, entries []outbox.Entry) error {
+		lock.Lock()
+		defer lock.Unlock()
+		attempts++
+		if attempts == 1 {
+			return errors.New("broker is unavailable")
+		}
+		received = append(received, entries...)
+		return nil
+	})
+
+	ctx := requestid.ToContext(t.Context(), "test-request-id")
+	err := testDb.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		return outbox.Write(ctx, tx, destination,
+			outbox.Message{Key: []byte("1"), Body: []byte("first"), Headers: map[string]string{"type": "test"}},
+			outbox.Message{Key: []byte("2"), Body: []byte("second")},
+		)
+	})
+	require.NoError(err)
+
+	err = testDb.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		err := outbox.Write(ctx, tx, destination, outbox.Message{Body: []byte("rolled back")})
+		require.NoError(err)
+		return errors.New("rollback")
+	})
+	require.Error(err)
+
+	relay := outbox.NewRelay(testDb, test.Logger(),
+		outbox.WithPollInterval(100*time.Millisecond),
+		outbox.WithBackoff(100*time.Millisecond, 100*time.Millisecond),
+		outbox.WithMetrics(metrics.NewRegistry()),
+	)
+	relay.Register(destination, sender)
+	relay.Run(t.Context())
+	t.Cleanup(relay.Shutdown)
+
+	time.Sleep(time.Second)
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(received, 2)
+	require.Equal("first", string(received[0].Body))
+	require.Equal("test", received[0].Headers["type"])
+	require.Equal("test-request-id", received[0].RequestId)
+	require.Equal(1, received[0].Attempt)
+	require.Equal("second", string(received[1].Body))
+
+	count := 0
+	err = testDb.SelectRow(t.Context(), &count, "SELECT count(*) FROM outbox_message WHERE destination = $1", destination)
+	require.NoError(err)
+	require.Zero(count)
+}
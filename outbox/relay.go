@@ -0,0 +1,224 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/db"
+	"github.com/txix-open/isp-kit/dbx"
+	"github.com/txix-open/isp-kit/json"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/outbox_metrics"
+	"github.com/txix-open/isp-kit/metrics/worker_metrics"
+	"github.com/txix-open/isp-kit/worker"
+)
+
+// Relay delivers outbox messages to the registered senders.
+// Each destination is served by its own worker, which publishes messages in the order of ids
+// and is guarded by a PostgreSQL advisory lock, so only one replica delivers the destination
+// at a time. Write makes ids of a destination grow in commit order. A failed batch is retried
+// with exponential backoff and blocks the following messages of the destination to preserve the order.
+//
+// Relay is safe for concurrent use by multiple goroutines.
+type Relay struct {
+	db              db.Transactional
+	logger          log.Logger
+	batchSize       int
+	pollInterval    time.Duration
+	backoffInitial  time.Duration
+	backoffMax      time.Duration
+	metricsRegistry *metrics.Registry
+	metrics         *outbox_metrics.Storage
+
+	lock    sync.Locker
+	senders map[string]Sender
+	workers []*worker.Worker
+}
+
+// NewRelay creates a new Relay reading the outbox table through the provided database client.
+//
+// Example:
+//
+//	relay := outbox.NewRelay(dbCli, logger, outbox.WithBatchSize(50))
+//	relay.Register("orders", outbox.NewKafkaSender(ordersPublisher))
+//	relay.Run(ctx)
+func NewRelay(db db.Transactional, logger log.Logger, opts ...Option) *Relay {
+	r := &Relay{
+		db:              db,
+		logger:          logger,
+		batchSize:       defaultBatchSize,
+		pollInterval:    defaultPollInterval,
+		backoffInitial:  defaultBackoffInitial,
+		backoffMax:      defaultBackoffMax,
+		metricsRegistry: metrics.DefaultRegistry,
+		lock:            &sync.Mutex{},
+		senders:         make(map[string]Sender),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.metrics = outbox_metrics.NewStorage(r.metricsRegistry)
+	return r
+}
+
+// Register sets the sender for messages written to the destination.
+// It must be called before Run.
+func (r *Relay) Register(destination string, sender Sender) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.senders[destination] = sender
+}
+
+// Run starts delivery workers for all registered destinations. The operation is non-blocking.
+func (r *Relay) Run(ctx context.Context) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for destination, sender := range r.senders {
+		d := delivery{
+			relay:       r,
+			destination: destination,
+			sender:      sender,
+		}
+		name := "outbox_" + destination
+		w := worker.NewFallible(
+			d,
+			worker.WithInterval(r.pollInterval),
+			worker.WithBackoff(r.backoffInitial, r.backoffMax),
+			worker.WithLocker(dbx.NewAdvisoryLock(r.db, name)),
+			worker.WithName(name),
+			worker.WithLogger(r.logger),
+			worker.WithMetrics(worker_metrics.NewStorage(r.metricsRegistry)),
+		)
+		w.Run(ctx)
+		r.workers = append(r.workers, w)
+	}
+}
+
+// Shutdown stops all delivery workers and waits for in-flight batches to complete.
+func (r *Relay) Shutdown() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, w := range r.workers {
+		w.Shutdown()
+	}
+	r.workers = nil
+}
+
+// row is an outbox table row.
+type row struct {
+	Id        int64
+	Key       []byte
+	Body      []byte
+	Headers   []byte
+	RequestId string
+	Attempt   int
+	CreatedAt time.Time
+}
+
+// delivery is a worker job delivering messages of a single destination.
+type delivery struct {
+	relay       *Relay
+	destination string
+	sender      Sender
+}
+
+// Do delivers batches until the outbox of the destination is drained or a batch fails.
+func (d delivery) Do(ctx context.Context) error {
+	for {
+		count, err := d.deliverBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if count < d.relay.batchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// deliverBatch locks the oldest messages of the destination, sends them and deletes them on success.
+// On failure, the attempt counter and the last error of the batch are updated.
+func (d delivery) deliverBatch(ctx context.Context) (int, error) {
+	count := 0
+	var sendErr error
+	err := d.relay.db.RunInTransaction(ctx, func(ctx context.Context, tx *db.Tx) error {
+		rows := make([]row, 0)
+		err := tx.Select(ctx, &rows, `SELECT id, key, body, headers, request_id, attempt, created_at
+		FROM outbox_message WHERE destination = $1 ORDER BY id LIMIT $2 FOR UPDATE`,
+			d.destination, d.relay.batchSize,
+		)
+		if err != nil {
+			return errors.WithMessage(err, "select outbox messages")
+		}
+		count = len(rows)
+		if count == 0 {
+			d.relay.metrics.SetOldestMessageAge(d.destination, 0)
+			return nil
+		}
+		d.relay.metrics.SetOldestMessageAge(d.destination, time.Now().UTC().Sub(rows[0].CreatedAt))
+
+		entries, ids, err := toEntries(rows)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		sendErr = d.sender.Send(ctx, entries)
+		d.relay.metrics.ObservePublishDuration(d.destination, time.Since(start))
+		if sendErr != nil {
+			d.relay.metrics.IncErrorCount(d.destination)
+			_, err = tx.Exec(ctx, `UPDATE outbox_message SET attempt = attempt + 1, last_error = $2 WHERE id = ANY($1)`,
+				ids, sendErr.Error(),
+			)
+			if err != nil {
+				return errors.WithMessage(err, "update failed outbox messages")
+			}
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM outbox_message WHERE id = ANY($1)", ids)
+		if err != nil {
+			return errors.WithMessage(err, "delete sent outbox messages")
+		}
+		d.relay.metrics.AddSentCount(d.destination, count)
+		return nil
+	}, db.MetricsLabel("outbox_relay"))
+	if err != nil {
+		return 0, errors.WithMessagef(err, "deliver outbox messages for '%s'", d.destination)
+	}
+	if sendErr != nil {
+		return 0, errors.WithMessagef(sendErr, "send outbox messages for '%s'", d.destination)
+	}
+	return count, nil
+}
+
+// toEntries converts table rows to entries and collects their ids.
+func toEntries(rows []row) ([]Entry, []int64, error) {
+	entries := make([]Entry, 0, len(rows))
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		headers := make(map[string]string)
+		err := json.Unmarshal(row.Headers, &headers)
+		if err != nil {
+			return nil, nil, errors.WithMessagef(err, "unmarshal headers of outbox message %d", row.Id)
+		}
+		entries = append(entries, Entry{
+			Message: Message{
+				Key:     row.Key,
+				Body:    row.Body,
+				Headers: headers,
+			},
+			Id:        row.Id,
+			RequestId: row.RequestId,
+			Attempt:   row.Attempt,
+			CreatedAt: row.CreatedAt,
+		})
+		ids = append(ids, row.Id)
+	}
+	return entries, ids, nil
+}
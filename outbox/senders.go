@@ -0,0 +1,102 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/txix-open/grmq/publisher"
+	kafkaPublisher "github.com/txix-open/isp-kit/kafkax/publisher"
+	"github.com/txix-open/isp-kit/requestid"
+)
+
+// RabbitSender delivers outbox entries through a RabbitMQ publisher one by one.
+type RabbitSender struct {
+	publisher *publisher.Publisher
+}
+
+// NewRabbitSender creates a new RabbitSender for the publisher.
+// The message key, if set, overrides the routing key of the publisher.
+func NewRabbitSender(publisher *publisher.Publisher) RabbitSender {
+	return RabbitSender{
+		publisher: publisher,
+	}
+}
+
+// Send publishes entries in order and stops at the first failure.
+// The request id of each entry is passed through the context to the publisher middlewares.
+func (s RabbitSender) Send(ctx context.Context, entries []Entry) error {
+	for _, entry := range entries {
+		routingKey := s.publisher.RoutingKey
+		if len(entry.Key) > 0 {
+			routingKey = string(entry.Key)
+		}
+		headers := amqp091.Table{}
+		for key, value := range entry.Headers {
+			headers[key] = value
+		}
+		msg := &amqp091.Publishing{
+			Headers: headers,
+			Body:    entry.Body,
+		}
+
+		err := s.publisher.PublishTo(entryContext(ctx, entry.RequestId), s.publisher.Exchange, routingKey, msg)
+		if err != nil {
+			return errors.WithMessagef(err, "publish outbox message %d", entry.Id)
+		}
+	}
+	return nil
+}
+
+// KafkaSender delivers outbox entries through a Kafka publisher.
+type KafkaSender struct {
+	publisher *kafkaPublisher.Publisher
+}
+
+// NewKafkaSender creates a new KafkaSender for the publisher.
+// The message key is used as the record key, so messages with the same key keep their order within a partition.
+func NewKafkaSender(publisher *kafkaPublisher.Publisher) KafkaSender {
+	return KafkaSender{
+		publisher: publisher,
+	}
+}
+
+// Send publishes entries in order. Consecutive entries with the same request id are published
+// in a single call, the request id is passed through the context to the publisher middlewares.
+func (s KafkaSender) Send(ctx context.Context, entries []Entry) error {
+	for start := 0; start < len(entries); {
+		end := start + 1
+		for end < len(entries) && entries[end].RequestId == entries[start].RequestId {
+			end++
+		}
+
+		records := make([]*kgo.Record, 0, end-start)
+		for _, entry := range entries[start:end] {
+			headers := make([]kgo.RecordHeader, 0, len(entry.Headers))
+			for key, value := range entry.Headers {
+				headers = append(headers, kgo.RecordHeader{Key: key, Value: []byte(value)})
+			}
+			records = append(records, &kgo.Record{
+				Key:     entry.Key,
+				Value:   entry.Body,
+				Headers: headers,
+			})
+		}
+
+		err := s.publisher.Publish(entryContext(ctx, entries[start].RequestId), records...)
+		if err != nil {
+			return errors.WithMessagef(err, "publish outbox messages %d-%d", entries[start].Id, entries[end-1].Id)
+		}
+		start = end
+	}
+	return nil
+}
+
+// entryContext returns the context with the request id of the entry if it is set.
+func entryContext(ctx context.Context, requestId string) context.Context {
+	if requestId == "" {
+		return ctx
+	}
+	return requestid.ToContext(ctx, requestId)
+}
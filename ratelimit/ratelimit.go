@@ -0,0 +1,20 @@
+// Package ratelimit provides the token bucket limit of message processing
+// shared by consumers of grmqx, kafkax and stompx.
+package ratelimit
+
+import (
+	"golang.org/x/time/rate"
+)
+
+// Config represents a token bucket limit of message processing.
+type Config struct {
+	// MessagesPerSecond is the number of messages processed per second (required).
+	MessagesPerSecond float64 `validate:"required,gt=0" schema:"Количество сообщений в секунду"`
+	// Burst is the number of messages which can be processed without waiting (default 1).
+	Burst int `schema:"Максимальный всплеск,количество сообщений, которые могут быть обработаны без ожидания, по умолчанию 1"`
+}
+
+// Limiter creates a token bucket limiter for the rate limit.
+func (c Config) Limiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(c.MessagesPerSecond), max(c.Burst, 1))
+}
@@ -5,6 +5,7 @@ package rc
 
 import (
 	"maps"
+	"slices"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -18,16 +19,24 @@ type Validator interface {
 	ValidateToError(value any) error
 }
 
-// Config manages configuration state with support for override merging and validation.
+// Config manages configuration state with support for override merging, validation
+// and change subscriptions.
 // It is safe for concurrent use.
 type Config struct {
 	prevConfig     []byte
 	overrideConfig []byte
 	delim          string
 	validator      Validator
+	subscriptions  []subscription
 	lock           sync.Locker
 }
 
+// subscription binds a key path pattern to a change handler.
+type subscription struct {
+	pattern string
+	handler func(newConfig []byte, diff Diff) error
+}
+
 // New creates a new Config instance with the provided validator and override data.
 // The overrideData is merged with new configuration data during upgrades.
 // The default delimiter for hierarchical paths is "~".
@@ -41,40 +50,96 @@ func New(validator Validator, overrideData []byte) *Config {
 	}
 }
 
+// UpgradeResult describes an applied upgrade.
+type UpgradeResult struct {
+	// Diff is the diff of flattened keys between the previous and the new configuration.
+	Diff Diff
+	// SubscriberErrors are the errors returned by subscribers. They do not reject the upgrade,
+	// since the new configuration is already stored when subscribers are called.
+	SubscriberErrors []error
+}
+
 // Upgrade processes new configuration data by merging it with overrides,
 // unmarshaling into the provided config pointer, and validating the result.
 // If validation succeeds, it stores the new config as the previous config.
 // The prevConfigPtr is populated with the previous configuration state if available.
 // Returns an error if merging, unmarshaling, or validation fails.
+// Subscribers are called after the new configuration is stored, their errors are ignored,
+// use UpgradeWithDiff to get them.
 func (c *Config) Upgrade(data []byte, newConfigPtr any, prevConfigPtr any) error {
+	_, err := c.UpgradeWithDiff(data, newConfigPtr, prevConfigPtr)
+	return err
+}
+
+// UpgradeWithDiff behaves like Upgrade and additionally returns the diff of flattened keys
+// between the previous and the new configuration. On the first upgrade every key is reported as added.
+//
+// After the new configuration is stored, subscribers whose pattern matches any changed key
+// are called in subscription order outside the lock, so they may read the configuration or call Upgrade.
+// All subscribers are called even if some of them fail, their errors are returned in the result
+// and are not returned as the error of the upgrade.
+func (c *Config) UpgradeWithDiff(data []byte, newConfigPtr any, prevConfigPtr any) (UpgradeResult, error) {
+	newConfig, diff, subscriptions, err := c.upgrade(data, newConfigPtr, prevConfigPtr)
+	if err != nil {
+		return UpgradeResult{}, err
+	}
+	return UpgradeResult{
+		Diff:             diff,
+		SubscriberErrors: notify(subscriptions, newConfig, diff),
+	}, nil
+}
+
+// upgrade stores the new configuration and returns it with the diff and the subscriptions to notify.
+func (c *Config) upgrade(data []byte, newConfigPtr any, prevConfigPtr any) ([]byte, Diff, []subscription, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	newConfig, err := c.mergeWithOverride(data)
 	if err != nil {
-		return errors.WithMessage(err, "merge with override new config")
+		return nil, nil, nil, errors.WithMessage(err, "merge with override new config")
 	}
 
 	err = json.Unmarshal(newConfig, newConfigPtr)
 	if err != nil {
-		return errors.WithMessage(err, "unmarshal new config")
+		return nil, nil, nil, errors.WithMessage(err, "unmarshal new config")
 	}
 
 	err = c.validator.ValidateToError(newConfigPtr)
 	if err != nil {
-		return errors.WithMessage(err, "validate config")
+		return nil, nil, nil, errors.WithMessage(err, "validate config")
 	}
 
 	if len(c.prevConfig) > 0 {
 		err = json.Unmarshal(c.prevConfig, prevConfigPtr)
 		if err != nil {
-			return errors.WithMessage(err, "unmarshal previous config")
+			return nil, nil, nil, errors.WithMessage(err, "unmarshal previous config")
 		}
 	}
 
+	diff, err := CompareConfigs(c.prevConfig, newConfig)
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "compare configs")
+	}
+
 	c.prevConfig = newConfig
 
-	return nil
+	return newConfig, diff, slices.Clone(c.subscriptions), nil
+}
+
+// notify calls subscribers whose pattern matches any changed key and returns their errors.
+func notify(subscriptions []subscription, newConfig []byte, diff Diff) []error {
+	var errs []error
+	for _, subscription := range subscriptions {
+		matched := diff.Match(subscription.pattern)
+		if matched.Empty() {
+			continue
+		}
+		err := subscription.handler(newConfig, matched)
+		if err != nil {
+			errs = append(errs, errors.WithMessagef(err, "subscriber %s", subscription.pattern))
+		}
+	}
+	return errs
 }
 
 // mergeWithOverride merges the provided config data with the override configuration.
@@ -123,3 +188,32 @@ func Upgrade[T any](rc *Config, data []byte) (newCfg T, prevCfg T, err error) {
 	err = rc.Upgrade(data, &newCfg, &prevCfg)
 	return newCfg, prevCfg, err
 }
+
+// UpgradeWithDiff is a generic wrapper for Config.UpgradeWithDiff that returns typed configuration structs.
+func UpgradeWithDiff[T any](rc *Config, data []byte) (newCfg T, prevCfg T, result UpgradeResult, err error) {
+	result, err = rc.UpgradeWithDiff(data, &newCfg, &prevCfg)
+	return newCfg, prevCfg, result, err
+}
+
+// Subscribe registers a handler called after a successful upgrade if any key matching
+// the pattern has changed. The handler receives the new configuration and the matching changes.
+// See Diff.Match for the pattern syntax.
+//
+// Handlers are called outside the lock of the Config, so they may call Upgrade.
+// Handlers of concurrent upgrades may be called concurrently.
+func Subscribe[T any](rc *Config, pattern string, handler func(newCfg T, diff Diff) error) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	rc.subscriptions = append(rc.subscriptions, subscription{
+		pattern: pattern,
+		handler: func(newConfig []byte, diff Diff) error {
+			var cfg T
+			err := json.Unmarshal(newConfig, &cfg)
+			if err != nil {
+				return errors.WithMessage(err, "unmarshal new config")
+			}
+			return handler(cfg, diff)
+		},
+	})
+}
@@ -1,8 +1,11 @@
 package rc_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"github.com/txix-open/isp-kit/rc"
 )
@@ -84,3 +87,131 @@ func TestConfig_Delim(t *testing.T) {
 	require.EqualValues(expectedNewCfg, newCfg)
 	require.EqualValues(expectedPrevCfg, prevCfg)
 }
+
+func TestConfig_UpgradeWithDiff(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	type cfgType struct {
+		Database struct {
+			Dsn      string
+			Password string
+		}
+		Kafka struct {
+			Brokers []string
+		}
+		Timeout int
+	}
+	config := rc.New(noneValidation{}, nil)
+
+	dbChanges := make([]rc.Diff, 0)
+	rc.Subscribe(config, "database.*", func(newCfg cfgType, diff rc.Diff) error {
+		dbChanges = append(dbChanges, diff)
+		if newCfg.Database.Dsn == "" {
+			return errors.New("empty dsn")
+		}
+		return nil
+	})
+	kafkaCalls := 0
+	rc.Subscribe(config, "kafka.*", func(newCfg cfgType, diff rc.Diff) error {
+		kafkaCalls++
+		return nil
+	})
+
+	cfg1 := `{"database": {"dsn": "dsn1", "password": "pass1"}, "kafka": {"brokers": ["a"]}, "timeout": 1}`
+	_, _, result, err := rc.UpgradeWithDiff[cfgType](config, []byte(cfg1))
+	require.NoError(err)
+	require.Empty(result.SubscriberErrors)
+	diff := result.Diff
+	require.EqualValues([]string{"database.dsn", "database.password", "kafka.brokers.[0]", "timeout"}, diff.Paths())
+	require.EqualValues(1, kafkaCalls)
+	require.Len(dbChanges, 1)
+
+	cfg2 := `{"database": {"dsn": "dsn2", "password": "pass2"}, "kafka": {"brokers": ["a"]}}`
+	newCfg, prevCfg, result, err := rc.UpgradeWithDiff[cfgType](config, []byte(cfg2))
+	require.NoError(err)
+	diff = result.Diff
+	require.EqualValues("dsn2", newCfg.Database.Dsn)
+	require.EqualValues("dsn1", prevCfg.Database.Dsn)
+	require.EqualValues(rc.Diff{
+		{Path: "database.dsn", Type: rc.ChangeModified, OldValue: "dsn1", NewValue: "dsn2"},
+		{Path: "database.password", Type: rc.ChangeModified, OldValue: "***", NewValue: "***"},
+		{Path: "timeout", Type: rc.ChangeRemoved, OldValue: float64(1), NewValue: nil},
+	}, diff)
+	require.True(diff.Changed("database.*"))
+	require.False(diff.Changed("kafka.*"))
+	require.EqualValues(1, kafkaCalls)
+	require.Len(dbChanges, 2)
+	require.EqualValues(diff[:2], dbChanges[1])
+
+	cfg3 := `{"database": {"password": "pass2"}, "kafka": {"brokers": ["a", "b"]}}`
+	_, _, result, err = rc.UpgradeWithDiff[cfgType](config, []byte(cfg3))
+	require.NoError(err)
+	require.Len(result.SubscriberErrors, 1)
+	require.ErrorContains(result.SubscriberErrors[0], "empty dsn")
+	require.EqualValues(2, kafkaCalls)
+
+	cfg4 := `{"database": {"password": "pass3"}, "kafka": {"brokers": ["a"]}}`
+	_, _, err = rc.Upgrade[cfgType](config, []byte(cfg4))
+	require.NoError(err)
+	require.EqualValues(3, kafkaCalls)
+}
+
+func TestConfig_SubscriberReentrancy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	type cfgType struct {
+		Database struct {
+			Dsn string
+		}
+		Derived string
+	}
+	config := rc.New(noneValidation{}, nil)
+
+	derivedCfgs := make([]cfgType, 0)
+	rc.Subscribe(config, "database.*", func(newCfg cfgType, diff rc.Diff) error {
+		data := fmt.Sprintf(`{"database": {"dsn": %q}, "derived": %q}`, newCfg.Database.Dsn, newCfg.Database.Dsn+"-derived")
+		_, _, err := rc.Upgrade[cfgType](config, []byte(data))
+		return err
+	})
+	rc.Subscribe(config, "derived", func(newCfg cfgType, diff rc.Diff) error {
+		derivedCfgs = append(derivedCfgs, newCfg)
+		return nil
+	})
+
+	var result rc.UpgradeResult
+	done := make(chan error)
+	go func() {
+		var err error
+		_, _, result, err = rc.UpgradeWithDiff[cfgType](config, []byte(`{"database": {"dsn": "dsn1"}}`))
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(err)
+		require.Empty(result.SubscriberErrors)
+	case <-time.After(time.Second):
+		require.Fail("upgrade from subscriber is deadlocked")
+	}
+	require.Len(derivedCfgs, 1)
+	require.EqualValues("dsn1-derived", derivedCfgs[0].Derived)
+}
+
+func TestDiff_Match(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	diff := rc.Diff{
+		{Path: "database.dsn"},
+		{Path: "database.pool.maxOpen"},
+		{Path: "kafka.consumers.[0].topic"},
+		{Path: "timeout"},
+	}
+	require.EqualValues([]string{"database.dsn", "database.pool.maxOpen"}, diff.Match("database.*").Paths())
+	require.EqualValues([]string{"database.dsn"}, diff.Match("database.dsn").Paths())
+	require.EqualValues([]string{"kafka.consumers.[0].topic"}, diff.Match("kafka.*.*.topic").Paths())
+	require.EqualValues([]string{"timeout"}, diff.Match("timeout").Paths())
+	require.Len(diff.Match("*"), 4)
+	require.Empty(diff.Match("database"))
+}
@@ -0,0 +1,170 @@
+package rc
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/txix-open/bellows"
+	"github.com/txix-open/isp-kit/cluster"
+	"github.com/txix-open/isp-kit/json"
+)
+
+const (
+	// DiffPathDelimiter separates segments of flattened configuration keys in Diff.
+	DiffPathDelimiter = "."
+)
+
+// ChangeType describes how a flattened configuration key has changed.
+type ChangeType string
+
+const (
+	// ChangeAdded means the key is present only in the new configuration.
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved means the key is present only in the previous configuration.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeModified means the key is present in both configurations with different values.
+	ChangeModified ChangeType = "changed"
+)
+
+// Change describes a single changed flattened configuration key.
+// Values of secret keys (see cluster.IsSecretKey) are replaced with cluster.SecretMask.
+type Change struct {
+	Path     string
+	Type     ChangeType
+	OldValue any
+	NewValue any
+}
+
+// Diff is a list of changes between two configurations sorted by path.
+type Diff []Change
+
+// Empty reports whether there are no changes.
+func (d Diff) Empty() bool {
+	return len(d) == 0
+}
+
+// Match returns the changes whose paths match the pattern.
+//
+// The pattern consists of segments separated by DiffPathDelimiter.
+// A "*" segment matches any single segment, a trailing "*" matches any remainder,
+// so "database.*" matches both "database.dsn" and "database.pool.maxOpen".
+func (d Diff) Match(pattern string) Diff {
+	matched := make(Diff, 0)
+	for _, change := range d {
+		if matchPath(pattern, change.Path) {
+			matched = append(matched, change)
+		}
+	}
+	return matched
+}
+
+// Changed reports whether any path matching the pattern has changed.
+// See Match for the pattern syntax.
+func (d Diff) Changed(pattern string) bool {
+	for _, change := range d {
+		if matchPath(pattern, change.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Paths returns the changed paths.
+func (d Diff) Paths() []string {
+	paths := make([]string, 0, len(d))
+	for _, change := range d {
+		paths = append(paths, change.Path)
+	}
+	return paths
+}
+
+// CompareConfigs returns the diff between two JSON configurations.
+// Empty prevConfig is treated as an empty object.
+// Returns an error if any of the configurations is not a JSON object.
+func CompareConfigs(prevConfig []byte, newConfig []byte) (Diff, error) {
+	prev, err := flattenConfig(prevConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "flatten previous config")
+	}
+	next, err := flattenConfig(newConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err, "flatten new config")
+	}
+
+	diff := make(Diff, 0)
+	for path, newValue := range next {
+		oldValue, ok := prev[path]
+		switch {
+		case !ok:
+			diff = append(diff, newChange(path, ChangeAdded, nil, newValue))
+		case !reflect.DeepEqual(oldValue, newValue):
+			diff = append(diff, newChange(path, ChangeModified, oldValue, newValue))
+		}
+	}
+	for path, oldValue := range prev {
+		_, ok := next[path]
+		if !ok {
+			diff = append(diff, newChange(path, ChangeRemoved, oldValue, nil))
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Path < diff[j].Path
+	})
+	return diff, nil
+}
+
+// newChange creates a Change hiding secret values.
+func newChange(path string, changeType ChangeType, oldValue any, newValue any) Change {
+	if cluster.IsSecretKey(path) {
+		oldValue = hideValue(oldValue)
+		newValue = hideValue(newValue)
+	}
+	return Change{
+		Path:     path,
+		Type:     changeType,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+}
+
+// hideValue masks non-empty values.
+func hideValue(value any) any {
+	if value == nil || value == "" {
+		return value
+	}
+	return cluster.SecretMask
+}
+
+// flattenConfig unmarshals the JSON configuration and flattens it into a map of paths.
+func flattenConfig(data []byte) (map[string]any, error) {
+	config := make(map[string]any)
+	if len(data) == 0 {
+		return config, nil
+	}
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unmarshal config")
+	}
+	return bellows.Flatten(config, bellows.WithSep(DiffPathDelimiter)), nil
+}
+
+// matchPath reports whether the path matches the pattern.
+func matchPath(pattern string, path string) bool {
+	patternSegments := strings.Split(pattern, DiffPathDelimiter)
+	pathSegments := strings.Split(path, DiffPathDelimiter)
+	for i, segment := range patternSegments {
+		isLast := i == len(patternSegments)-1
+		if segment == "*" && isLast {
+			return len(pathSegments) > i
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if segment != "*" && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return len(pathSegments) == len(patternSegments)
+}
@@ -8,12 +8,19 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/pkg/errors"
+	"github.com/txix-open/isp-kit/infra/consumer_control"
 	"github.com/txix-open/isp-kit/log"
 	"github.com/txix-open/isp-kit/stompx/consumer"
 	"github.com/txix-open/isp-kit/stompx/publisher"
 	"golang.org/x/sync/errgroup"
 )
 
+var (
+	// ErrConsumerNotFound is returned when no consumer reads the requested queue.
+	ErrConsumerNotFound = consumer_control.ErrConsumerNotFound
+)
+
 type state struct {
 	consumers  []*consumer.Watcher
 	publishers []*publisher.Publisher
@@ -25,6 +32,7 @@ type Client struct {
 	locker  sync.Locker
 	state   *state
 	prevCfg Config
+	paused  map[string]bool
 	logger  log.Logger
 }
 
@@ -34,6 +42,7 @@ func New(logger log.Logger) *Client {
 		locker:  &sync.Mutex{},
 		state:   nil,
 		prevCfg: Config{},
+		paused:  make(map[string]bool),
 		logger:  logger,
 	}
 }
@@ -72,6 +81,56 @@ func (c *Client) Shutdown() {
 	_ = closeGroup.Wait()
 }
 
+// Pause closes sessions of consumers of the queue until Resume is called.
+// In-flight messages are processed before the connections are closed.
+// The pause is kept when the configuration is upgraded.
+// Returns ErrConsumerNotFound if no consumer reads the queue.
+func (c *Client) Pause(queue string) error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	consumers := c.consumersOf(queue)
+	if len(consumers) == 0 {
+		return errors.WithMessagef(ErrConsumerNotFound, "queue '%s'", queue)
+	}
+	c.paused[queue] = true
+	for _, consumer := range consumers {
+		consumer.Pause()
+	}
+	return nil
+}
+
+// Resume starts new sessions of consumers of the queue paused by Pause.
+// Returns ErrConsumerNotFound if no consumer reads the queue.
+func (c *Client) Resume(queue string) error {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	consumers := c.consumersOf(queue)
+	if len(consumers) == 0 {
+		return errors.WithMessagef(ErrConsumerNotFound, "queue '%s'", queue)
+	}
+	delete(c.paused, queue)
+	for _, consumer := range consumers {
+		consumer.Resume()
+	}
+	return nil
+}
+
+// consumersOf returns running consumers reading the queue.
+func (c *Client) consumersOf(queue string) []*consumer.Watcher {
+	if c.state == nil {
+		return nil
+	}
+	consumers := make([]*consumer.Watcher, 0)
+	for _, consumer := range c.state.consumers {
+		if consumer.Queue() == queue {
+			consumers = append(consumers, consumer)
+		}
+	}
+	return consumers
+}
+
 // Upgrade updates the configuration and synchronously initializes the client
 // with a guarantee that all components are ready. It returns the first error
 // encountered during initialization, or nil if successful.
@@ -107,6 +166,9 @@ func (c *Client) upgrade(ctx context.Context, justServe bool, newConfig Config)
 	c.setNewState()
 
 	for _, consumerCli := range newConfig.Consumers {
+		if c.paused[consumerCli.Queue()] {
+			consumerCli.Pause()
+		}
 		if justServe {
 			consumerCli.Serve(ctx)
 		} else {
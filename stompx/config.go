@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-stomp/stomp/v3"
 	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/observability/tracing/stomp/publisher_tracing"
+	"github.com/txix-open/isp-kit/ratelimit"
 	"github.com/txix-open/isp-kit/stompx/consumer"
 	"github.com/txix-open/isp-kit/stompx/publisher"
 )
@@ -42,14 +44,20 @@ type ConsumerConfig struct {
 	Password string `schema:"Пароль"`
 	// ConnHeaders are additional connection headers.
 	ConnHeaders map[string]string `schema:"Дополнительные параметры подключения"`
+	// RateLimit is the token bucket limit of message processing.
+	RateLimit *ratelimit.Config `schema:"Ограничение скорости обработки сообщений,по умолчанию не ограничена"`
 }
 
 // DefaultConsumer creates a consumer configuration with logging, middleware,
 // and connection support based on the provided parameters.
+// If RateLimit is set, message processing is limited by a token bucket.
 func DefaultConsumer(cfg ConsumerConfig, handler consumer.Handler, logger log.Logger, restMiddlewares ...consumer.Middleware) consumer.Config {
 	middlewares := []consumer.Middleware{
 		ConsumerRequestId(),
 	}
+	if cfg.RateLimit != nil {
+		middlewares = append(middlewares, ConsumerRateLimit(cfg.RateLimit.Limiter()))
+	}
 	middlewares = append(middlewares, restMiddlewares...)
 
 	concurrency := 1
@@ -98,11 +106,13 @@ type PublisherConfig struct {
 	ConnHeaders map[string]string `schema:"Дополнительные параметры подключения"`
 }
 
-// DefaultPublisher creates a message publisher with middleware and connection settings.
+// DefaultPublisher creates a message publisher with persistence, request id and tracing
+// middlewares and connection settings.
 func DefaultPublisher(cfg PublisherConfig, restMiddlewares ...publisher.Middleware) *publisher.Publisher {
 	middlewares := []publisher.Middleware{
 		PublisherPersistent(),
 		PublisherRequestId(),
+		publisher_tracing.NewConfig().Middleware(),
 	}
 	middlewares = append(middlewares, restMiddlewares...)
 
@@ -9,6 +9,8 @@ import (
 	"go.uber.org/atomic"
 )
 
+var errPaused = errors.New("consumer is paused")
+
 // Watcher manages the lifecycle of a consumer with automatic reconnection support.
 type Watcher struct {
 	config        Config
@@ -17,6 +19,8 @@ type Watcher struct {
 	mustReconnect *atomic.Bool
 	reportErrOnce *sync.Once
 	alive         *atomic.Bool
+	paused        *atomic.Bool
+	pauseChanged  chan struct{}
 }
 
 // NewWatcher creates a new Watcher with the provided configuration.
@@ -31,6 +35,8 @@ func NewWatcher(config Config) *Watcher {
 		mustReconnect: mustReconnect,
 		reportErrOnce: &sync.Once{},
 		alive:         atomic.NewBool(true),
+		paused:        atomic.NewBool(false),
+		pauseChanged:  make(chan struct{}, 1),
 	}
 }
 
@@ -63,6 +69,29 @@ func (w *Watcher) Shutdown() {
 	<-w.shutdownDone
 }
 
+// Pause closes the consumer session until Resume is called.
+// In-flight messages are processed before the connection is closed.
+func (w *Watcher) Pause() {
+	w.paused.Store(true)
+	w.notifyPauseChanged()
+}
+
+// Resume starts a new consumer session after Pause.
+func (w *Watcher) Resume() {
+	w.paused.Store(false)
+	w.notifyPauseChanged()
+}
+
+// Paused reports whether the watcher is paused.
+func (w *Watcher) Paused() bool {
+	return w.paused.Load()
+}
+
+// Queue returns the queue the watcher consumes from.
+func (w *Watcher) Queue() string {
+	return w.config.Queue
+}
+
 // Healthcheck returns an error if the watcher is not receiving messages.
 func (w *Watcher) Healthcheck(ctx context.Context) error {
 	if w.alive.Load() {
@@ -77,11 +106,18 @@ func (w *Watcher) run(ctx context.Context, firstSessionErr chan error) {
 	}()
 
 	for {
+		if !w.awaitResume(ctx, firstSessionErr) {
+			return
+		}
+
 		w.alive.Store(true)
 		err := w.runSession(firstSessionErr)
 		if err == nil { // normal close
 			return
 		}
+		if errors.Is(err, errPaused) {
+			continue
+		}
 
 		consumer := &Consumer{
 			Config: w.config,
@@ -123,11 +159,41 @@ func (w *Watcher) runSession(firstSessionErr chan error) (err error) {
 	go func() {
 		errCh <- c.Run()
 	}()
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case <-w.close:
+			return nil
+		case <-w.pauseChanged:
+			if w.paused.Load() {
+				return errPaused
+			}
+		}
+	}
+}
+
+// awaitResume blocks while the watcher is paused.
+// Returns false if the watcher is shut down or the context is done.
+func (w *Watcher) awaitResume(ctx context.Context, firstSessionErr chan error) bool {
+	for w.paused.Load() {
+		w.reportFirstSessionError(firstSessionErr, nil) // to unblock Run
+		select {
+		case <-ctx.Done():
+			return false
+		case <-w.close:
+			return false
+		case <-w.pauseChanged:
+		}
+	}
+	return true
+}
+
+// notifyPauseChanged wakes up the session loop to apply the pause state.
+func (w *Watcher) notifyPauseChanged() {
 	select {
-	case err := <-errCh:
-		return err
-	case <-w.close:
-		return nil
+	case w.pauseChanged <- struct{}{}:
+	default:
 	}
 }
 
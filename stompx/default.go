@@ -2,15 +2,17 @@ package stompx
 
 import (
 	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/observability/tracing/stomp/consumer_tracing"
 	"github.com/txix-open/isp-kit/stompx/handler"
 )
 
-// NewResultHandler creates a result handler with logging and panic recovery.
+// NewResultHandler creates a result handler with logging, tracing and panic recovery.
 func NewResultHandler(logger log.Logger, adapter handler.HandlerAdapter) handler.ResultHandler {
 	return handler.NewHandler(
 		logger,
 		adapter,
 		handler.Log(logger),
+		consumer_tracing.NewConfig().Middleware(),
 		handler.Recovery(),
 	)
 }
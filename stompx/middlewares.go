@@ -7,6 +7,7 @@ import (
 	"github.com/txix-open/isp-kit/requestid"
 	"github.com/txix-open/isp-kit/stompx/consumer"
 	"github.com/txix-open/isp-kit/stompx/publisher"
+	"golang.org/x/time/rate"
 )
 
 // PublisherPersistent adds a `persistent=true` header to all outgoing messages.
@@ -111,3 +112,18 @@ func ConsumerRequestId() consumer.Middleware {
 		})
 	}
 }
+
+// ConsumerRateLimit limits the rate of message processing with the token bucket limiter.
+// If a token can not be obtained, the message is negatively acknowledged.
+func ConsumerRateLimit(limiter *rate.Limiter) consumer.Middleware {
+	return func(next consumer.Handler) consumer.Handler {
+		return consumer.HandlerFunc(func(ctx context.Context, delivery *consumer.Delivery) {
+			err := limiter.Wait(ctx)
+			if err != nil {
+				_ = delivery.Nack()
+				return
+			}
+			next.Handle(ctx, delivery)
+		})
+	}
+}
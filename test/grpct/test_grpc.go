@@ -41,6 +41,13 @@ func (m *MockServer) Mock(endpoint string, handler any) *MockServer {
 	return m
 }
 
+// MockStream registers a stream handler for the specified endpoint.
+// Returns the MockServer for method chaining.
+func (m *MockServer) MockStream(endpoint string, handler grpc.StreamHandlerFunc) *MockServer {
+	m.router.HandleStream(endpoint, m.Wrapper.StreamEndpoint(handler))
+	return m
+}
+
 // TestServer creates and starts a gRPC server with the provided service,
 // returning both the server and a configured client. The server listens
 // on a random local port and is automatically shut down when the test completes.
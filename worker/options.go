@@ -2,6 +2,11 @@ package worker
 
 import (
 	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics/worker_metrics"
 )
 
 // Option is a function type that configures a Worker.
@@ -30,3 +35,89 @@ func WithConcurrency(concurrency int) Option {
 		w.concurrency = concurrency
 	}
 }
+
+// WithCron schedules job runs by a standard cron expression or a descriptor
+// such as "@hourly" or "@every 5m". It replaces the fixed interval.
+// By default, cron workers do not run at startup. It panics if the spec is invalid.
+//
+// Example:
+//
+//	w := worker.New(myJob, worker.WithCron("0 */2 * * *"))
+func WithCron(spec string) Option {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		panic(errors.WithMessagef(err, "parse cron spec '%s'", spec))
+	}
+	return WithSchedule(schedule)
+}
+
+// WithSchedule schedules job runs by an arbitrary cron.Schedule.
+// It replaces the fixed interval.
+func WithSchedule(schedule cron.Schedule) Option {
+	return func(w *Worker) {
+		w.schedule = schedule
+	}
+}
+
+// WithJitter adds a random delay in [0, jitter) before each run
+// to spread load between replicas.
+func WithJitter(jitter time.Duration) Option {
+	return func(w *Worker) {
+		w.jitter = jitter
+	}
+}
+
+// WithRunAtStartup sets whether the first run happens immediately after Run.
+// By default, interval workers run at startup and cron workers wait for the schedule.
+func WithRunAtStartup(runAtStartup bool) Option {
+	return func(w *Worker) {
+		w.runAtStartup = &runAtStartup
+	}
+}
+
+// WithBackoff delays runs after failures of a FallibleJob with exponential backoff
+// starting from initial and limited by maxDelay. The regular schedule resumes after a successful run.
+func WithBackoff(initial time.Duration, maxDelay time.Duration) Option {
+	return func(w *Worker) {
+		w.backoffInitial = initial
+		w.backoffMax = maxDelay
+	}
+}
+
+// WithLocker guards each run with a distributed lock,
+// so only one replica executes the job at a time. Runs are skipped if the lock is held.
+//
+// Example:
+//
+//	w := worker.New(myJob, worker.WithLocker(dbx.NewAdvisoryLock(dbCli, "cleanup")))
+func WithLocker(locker Locker) Option {
+	return func(w *Worker) {
+		w.locker = locker
+	}
+}
+
+// WithName sets the worker name used in logs and metrics labels.
+// The default name is the type of the job, e.g. "*cleanup.Job", so workers of different jobs
+// have separate metrics. Set a unique name if several workers run jobs of the same type,
+// otherwise their metrics are merged into one series.
+func WithName(name string) Option {
+	return func(w *Worker) {
+		w.name = name
+	}
+}
+
+// WithLogger enables logging of job runs. Failed runs are logged with error level,
+// completed and skipped runs with debug level.
+func WithLogger(logger log.Logger) Option {
+	return func(w *Worker) {
+		w.logger = logger
+	}
+}
+
+// WithMetrics sets the storage for job run metrics labeled by the worker name, see WithName.
+// By default, metrics are registered in metrics.DefaultRegistry.
+func WithMetrics(storage *worker_metrics.Storage) Option {
+	return func(w *Worker) {
+		w.metrics = storage
+	}
+}
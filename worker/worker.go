@@ -1,11 +1,20 @@
 // Package worker provides a configurable worker for periodic task execution
 // with concurrency control and graceful shutdown support.
+// Runs can be scheduled by a fixed interval or a cron expression, randomized with jitter,
+// delayed with exponential backoff after failures and guarded by a distributed lock.
 package worker
 
 import (
 	"context"
+	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/txix-open/isp-kit/log"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/worker_metrics"
 )
 
 // Job is an interface for tasks executed by the worker.
@@ -14,13 +23,38 @@ type Job interface {
 	Do(ctx context.Context)
 }
 
+// FallibleJob is an interface for tasks that report failures.
+// Failed runs are logged, counted in metrics and delayed with backoff if configured.
+type FallibleJob interface {
+	// Do executes the task and returns an error if it failed.
+	Do(ctx context.Context) error
+}
+
+// Locker guards job runs so that only one replica executes the job at a time.
+type Locker interface {
+	// RunLocked runs fn if the lock is acquired and reports whether it was acquired.
+	RunLocked(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+// jobFunc adapts a Job to the FallibleJob signature.
+type jobFunc func(ctx context.Context) error
+
 // Worker manages periodic execution of a Job with configurable concurrency.
 type Worker struct {
-	interval    time.Duration
-	concurrency int
-	wg          *sync.WaitGroup
-	stop        chan struct{}
-	job         Job
+	interval       time.Duration
+	schedule       cron.Schedule
+	jitter         time.Duration
+	runAtStartup   *bool
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+	locker         Locker
+	name           string
+	logger         log.Logger
+	metrics        *worker_metrics.Storage
+	concurrency    int
+	wg             *sync.WaitGroup
+	stop           chan struct{}
+	job            jobFunc
 }
 
 // New creates a new Worker with the specified Job and options.
@@ -30,12 +64,37 @@ type Worker struct {
 //
 //	w := worker.New(myJob, worker.WithInterval(2*time.Second), worker.WithConcurrency(3))
 func New(job Job, opts ...Option) *Worker {
+	return newWorker(jobName(job), func(ctx context.Context) error {
+		job.Do(ctx)
+		return nil
+	}, opts...)
+}
+
+// NewFallible creates a new Worker with the specified FallibleJob and options.
+// Default configuration is the same as for New.
+//
+// Example:
+//
+//	w := worker.NewFallible(myJob, worker.WithCron("*/5 * * * *"), worker.WithBackoff(time.Second, time.Minute))
+func NewFallible(job FallibleJob, opts ...Option) *Worker {
+	return newWorker(jobName(job), job.Do, opts...)
+}
+
+// jobName returns the default worker name, which is the type of the job, e.g. "*cleanup.Job".
+func jobName(job any) string {
+	return fmt.Sprintf("%T", job)
+}
+
+// newWorker creates a Worker running fn with the default configuration and applies the options.
+func newWorker(name string, fn jobFunc, opts ...Option) *Worker {
 	w := &Worker{
 		interval:    1 * time.Second,
+		name:        name,
+		metrics:     worker_metrics.NewStorage(metrics.DefaultRegistry),
 		concurrency: 1,
 		wg:          &sync.WaitGroup{},
 		stop:        make(chan struct{}),
-		job:         job,
+		job:         fn,
 	}
 	for _, opt := range opts {
 		opt(w)
@@ -44,7 +103,7 @@ func New(job Job, opts ...Option) *Worker {
 }
 
 // Run starts the worker goroutines. The operation is non-blocking.
-// Each worker runs independently and processes jobs according to the configured interval.
+// Each worker runs independently and processes jobs according to the configured schedule.
 //
 // The context parameter controls the lifecycle of the workers. When the context is cancelled,
 // workers will complete their current job and exit.
@@ -65,11 +124,16 @@ func (w *Worker) Shutdown() {
 }
 
 // run is the main loop for a single worker goroutine.
-// It executes the job, waits for the configured interval, and repeats until
+// It executes the job, waits for the next scheduled run, and repeats until
 // the context is cancelled or Shutdown is called.
 func (w *Worker) run(ctx context.Context) {
 	defer w.wg.Done()
 
+	if !w.isRunAtStartup() && !w.wait(ctx, w.nextDelay(0)) {
+		return
+	}
+
+	failures := 0
 	for {
 		select {
 		case <-w.stop:
@@ -77,14 +141,108 @@ func (w *Worker) run(ctx context.Context) {
 		default:
 		}
 
-		w.job.Do(ctx)
+		err := w.execute(ctx)
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
 
-		select {
-		case <-ctx.Done():
-			return
-		case <-w.stop:
+		if !w.wait(ctx, w.nextDelay(failures)) {
 			return
-		case <-time.After(w.interval):
 		}
 	}
 }
+
+// execute runs the job once under the lock if configured, recording metrics and logs.
+func (w *Worker) execute(ctx context.Context) error {
+	start := time.Now()
+
+	acquired := true
+	var err error
+	if w.locker != nil {
+		acquired, err = w.locker.RunLocked(ctx, w.job)
+	} else {
+		err = w.job(ctx)
+	}
+
+	if !acquired && err == nil {
+		w.metrics.IncRunCount(w.name, worker_metrics.StatusSkipped)
+		w.debug(ctx, "worker: run skipped, lock is held by another instance")
+		return nil
+	}
+
+	duration := time.Since(start)
+	w.metrics.ObserveRunDuration(w.name, duration)
+	if err != nil {
+		w.metrics.IncRunCount(w.name, worker_metrics.StatusFail)
+		if w.logger != nil {
+			w.logger.Error(ctx, "worker: run failed",
+				log.String("worker", w.name),
+				log.String("error", err.Error()),
+				log.Int64("elapsedTimeMs", duration.Milliseconds()),
+			)
+		}
+		return err
+	}
+
+	w.metrics.IncRunCount(w.name, worker_metrics.StatusSuccess)
+	w.debug(ctx, "worker: run completed", log.Int64("elapsedTimeMs", duration.Milliseconds()))
+	return nil
+}
+
+// debug writes a debug log entry if a logger is configured.
+func (w *Worker) debug(ctx context.Context, message string, fields ...log.Field) {
+	if w.logger == nil {
+		return
+	}
+	w.logger.Debug(ctx, message, append([]log.Field{log.String("worker", w.name)}, fields...)...)
+}
+
+// isRunAtStartup reports whether the first run happens immediately.
+// By default, interval workers run at startup and cron workers wait for the schedule.
+func (w *Worker) isRunAtStartup() bool {
+	if w.runAtStartup != nil {
+		return *w.runAtStartup
+	}
+	return w.schedule == nil
+}
+
+// nextDelay calculates the delay before the next run.
+// After failures it uses exponential backoff if configured, otherwise the schedule.
+// Random jitter is added to the result.
+func (w *Worker) nextDelay(failures int) time.Duration {
+	var delay time.Duration
+	switch {
+	case failures > 0 && w.backoffInitial > 0:
+		delay = w.backoffInitial
+		for i := 1; i < failures && delay < w.backoffMax; i++ {
+			delay *= 2
+		}
+		delay = min(delay, w.backoffMax)
+	case w.schedule != nil:
+		delay = time.Until(w.schedule.Next(time.Now()))
+	default:
+		delay = w.interval
+	}
+
+	if w.jitter > 0 {
+		delay += rand.N(w.jitter)
+	}
+	return delay
+}
+
+// wait sleeps for the delay and reports whether the worker should continue.
+func (w *Worker) wait(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-w.stop:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
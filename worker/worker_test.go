@@ -6,7 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"github.com/txix-open/isp-kit/metrics"
+	"github.com/txix-open/isp-kit/metrics/worker_metrics"
 	"github.com/txix-open/isp-kit/worker"
 )
 
@@ -28,3 +31,136 @@ func TestWorker_Run(t *testing.T) {
 	require.EqualValues(t, 1, atomic.LoadInt32(&job.call))
 	w.Shutdown()
 }
+
+type fallibleJob struct {
+	calls    atomic.Int32
+	failures int32
+}
+
+func (j *fallibleJob) Do(ctx context.Context) error {
+	if j.calls.Add(1) <= j.failures {
+		return errors.New("job failed")
+	}
+	return nil
+}
+
+type locker struct {
+	acquired bool
+}
+
+func (l locker) RunLocked(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	if !l.acquired {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+type delaySchedule time.Duration
+
+func (s delaySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+func TestWorker_Schedule(t *testing.T) {
+	t.Parallel()
+
+	require.NotPanics(t, func() {
+		worker.WithCron("*/5 * * * *")
+	})
+	require.Panics(t, func() {
+		worker.WithCron("invalid")
+	})
+
+	job := &job{}
+	w := worker.New(
+		job,
+		worker.WithSchedule(delaySchedule(200*time.Millisecond)),
+		worker.WithMetrics(worker_metrics.NewStorage(metrics.NewRegistry())),
+	)
+	w.Run(t.Context())
+	time.Sleep(100 * time.Millisecond)
+	require.Zero(t, atomic.LoadInt32(&job.call))
+	time.Sleep(200 * time.Millisecond)
+	require.EqualValues(t, 1, atomic.LoadInt32(&job.call))
+	w.Shutdown()
+}
+
+func TestWorker_Backoff(t *testing.T) {
+	t.Parallel()
+
+	job := &fallibleJob{failures: 2}
+	w := worker.NewFallible(
+		job,
+		worker.WithInterval(10*time.Second),
+		worker.WithBackoff(50*time.Millisecond, time.Second),
+		worker.WithMetrics(worker_metrics.NewStorage(metrics.NewRegistry())),
+	)
+	w.Run(t.Context())
+	time.Sleep(250 * time.Millisecond)
+	require.EqualValues(t, 3, job.calls.Load())
+	time.Sleep(200 * time.Millisecond)
+	require.EqualValues(t, 3, job.calls.Load())
+	w.Shutdown()
+}
+
+func TestWorker_Locker(t *testing.T) {
+	t.Parallel()
+
+	registry := metrics.NewRegistry()
+	lockedJob := &job{}
+	w := worker.New(
+		lockedJob,
+		worker.WithInterval(50*time.Millisecond),
+		worker.WithName("locked"),
+		worker.WithLocker(locker{acquired: false}),
+		worker.WithMetrics(worker_metrics.NewStorage(registry)),
+	)
+	w.Run(t.Context())
+	time.Sleep(120 * time.Millisecond)
+	w.Shutdown()
+	require.Zero(t, atomic.LoadInt32(&lockedJob.call))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	skipped := 0.0
+	for _, family := range families {
+		if family.GetName() != "worker_run_count" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			skipped += metric.GetCounter().GetValue()
+		}
+	}
+	require.GreaterOrEqual(t, skipped, 2.0)
+}
+
+func TestWorker_DefaultName(t *testing.T) {
+	t.Parallel()
+
+	registry := metrics.NewRegistry()
+	storage := worker_metrics.NewStorage(registry)
+	w1 := worker.New(&job{}, worker.WithInterval(time.Minute), worker.WithMetrics(storage))
+	w2 := worker.NewFallible(&fallibleJob{}, worker.WithInterval(time.Minute), worker.WithMetrics(storage))
+	w1.Run(t.Context())
+	w2.Run(t.Context())
+	time.Sleep(100 * time.Millisecond)
+	w1.Shutdown()
+	w2.Shutdown()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	names := make([]string, 0)
+	for _, family := range families {
+		if family.GetName() != "worker_run_count" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "worker" {
+					names = append(names, label.GetValue())
+				}
+			}
+		}
+	}
+	require.ElementsMatch(t, []string{"*worker_test.job", "*worker_test.fallibleJob"}, names)
+}